@@ -0,0 +1,56 @@
+package waveparser
+
+import "math/bits"
+
+// SampleRate is the number of frames per second.
+func (w *Wav) SampleRate() uint32 {
+	return w.Header.RIFFChunkFmt.SampleRate
+}
+
+// Channels is the number of interleaved channels.
+func (w *Wav) Channels() uint16 {
+	return w.Header.RIFFChunkFmt.NumChannels
+}
+
+// BitsPerSample is the width of each sample of a channel.
+func (w *Wav) BitsPerSample() uint16 {
+	return w.Header.RIFFChunkFmt.BitsPerSample
+}
+
+// Format is the audio format (WaveFormatPCM, WaveFormatIEEEFloat...).
+func (w *Wav) Format() AudioFormat {
+	return w.Header.RIFFChunkFmt.AudioFormat
+}
+
+// SetSampleRate sets the sample rate, recomputing bytes/second. The
+// audio data isn't resampled, see Resample.
+func (w *Wav) SetSampleRate(rate uint32) {
+	format := &w.Header.RIFFChunkFmt
+	format.SampleRate = rate
+	format.BytesPerBloc, format.BytesPerSec = blockAlign(*format)
+}
+
+// SetChannels sets the number of channels, recomputing bytes/block and
+// bytes/second. A channel mask naming another number of speakers is
+// cleared. The audio data isn't converted, see OverrideChannels to
+// reinterpret it checking that it holds whole frames.
+func (w *Wav) SetChannels(n uint16) {
+	format := &w.Header.RIFFChunkFmt
+	format.NumChannels = n
+	format.BytesPerBloc, format.BytesPerSec = blockAlign(*format)
+	if mask := w.Header.Extension.ChannelMask; mask != 0 && bits.OnesCount32(mask) != int(n) {
+		w.Header.Extension.ChannelMask = 0
+	}
+}
+
+// SetBitsPerSample sets the width of the samples, recomputing
+// bytes/block and bytes/second. The audio data isn't converted, see
+// Convert.
+func (w *Wav) SetBitsPerSample(bitsPerSample uint16) {
+	format := &w.Header.RIFFChunkFmt
+	format.BitsPerSample = bitsPerSample
+	format.BytesPerBloc, format.BytesPerSec = blockAlign(*format)
+	if w.Header.Extension.ValidBitsPerSample != 0 {
+		w.Header.Extension.ValidBitsPerSample = bitsPerSample
+	}
+}
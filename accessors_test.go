@@ -0,0 +1,39 @@
+package waveparser
+
+import "testing"
+
+func TestAccessors(t *testing.T) {
+	wav, err := Load("./testdata/audios/float32le.wav")
+	assertNoError(t, err)
+
+	format := wav.Header.RIFFChunkFmt
+	if wav.SampleRate() != format.SampleRate ||
+		wav.Channels() != format.NumChannels ||
+		wav.BitsPerSample() != format.BitsPerSample ||
+		wav.Format() != WaveFormatIEEEFloat {
+		t.Fatalf("accessors differ from header %+v", format)
+	}
+}
+
+func TestHeaderSetters(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+
+	wav.SetSampleRate(16000)
+	wav.SetChannels(2)
+	wav.SetBitsPerSample(24)
+
+	expected := New(WaveFormatPCM, 2, 16000, 24).Header.RIFFChunkFmt
+	if wav.Header.RIFFChunkFmt != expected {
+		t.Fatalf("expected fmt %+v, got %+v", expected, wav.Header.RIFFChunkFmt)
+	}
+
+	wav.Header.Extension.ChannelMask = 0x3
+	wav.SetChannels(2)
+	if wav.Header.Extension.ChannelMask != 0x3 {
+		t.Fatal("matching channel mask must be kept")
+	}
+	wav.SetChannels(1)
+	if wav.Header.Extension.ChannelMask != 0 {
+		t.Fatal("channel mask of another layout must be cleared")
+	}
+}
@@ -0,0 +1,192 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+type (
+	// adtl holds the associated data list entries, keyed by cue id.
+	adtl struct {
+		labels map[uint32]string
+		notes  map[uint32]string
+		texts  map[uint32]LabeledText
+	}
+
+	// Annotation is a cue point with its associated data list entries,
+	// as written by editors like Audacity or Reaper for their labels.
+	Annotation struct {
+		Cue   CuePoint
+		Label string       // labl entry
+		Note  string       // note entry
+		Text  *LabeledText // ltxt entry, nil when there is none
+	}
+
+	// LabeledText is a ltxt entry, giving a cue point a length.
+	LabeledText struct {
+		SampleLength uint32
+		Purpose      [4]byte // eg: "rgn "
+		Country      uint16
+		Language     uint16
+		Dialect      uint16
+		CodePage     uint16
+		Text         string
+	}
+
+	ltxtHeader struct {
+		CueID        uint32
+		SampleLength uint32
+		Purpose      [4]byte
+		Country      uint16
+		Language     uint16
+		Dialect      uint16
+		CodePage     uint16
+	}
+)
+
+// ltxtHeaderSize is the size of the fixed fields of a ltxt entry.
+const ltxtHeaderSize = 20
+
+// Annotations associates the cue points with their LIST/adtl
+// entries, in the order of the cue chunk. Entries of cue ids absent
+// from the cue chunk are ignored.
+func (w *Wav) Annotations() ([]Annotation, error) {
+	points, err := w.CuePoints()
+	if err != nil {
+		return nil, err
+	}
+	list, err := w.adtl()
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := make([]Annotation, len(points))
+	for i, point := range points {
+		annotations[i] = Annotation{
+			Cue:   point,
+			Label: list.labels[point.ID],
+			Note:  list.notes[point.ID],
+		}
+		if text, ok := list.texts[point.ID]; ok {
+			annotations[i].Text = &text
+		}
+	}
+	return annotations, nil
+}
+
+// adtl parses the LIST/adtl chunk, empty when the file has none.
+func (w *Wav) adtl() (adtl, error) {
+	list := adtl{
+		labels: map[uint32]string{},
+		notes:  map[uint32]string{},
+		texts:  map[uint32]LabeledText{},
+	}
+	chunk := w.listChunk("adtl")
+	if chunk == nil {
+		return list, nil
+	}
+
+	err := parseSubchunks(chunk.Data[4:], func(id [4]byte, data []byte) error {
+		switch string(id[:]) {
+		case "labl", "note":
+			if len(data) < 4 {
+				return fmt.Errorf("%s entry too small: %d bytes", string(id[:]), len(data))
+			}
+			cueID := binary.LittleEndian.Uint32(data)
+			text := string(bytes.TrimRight(data[4:], "\x00"))
+			if string(id[:]) == "labl" {
+				list.labels[cueID] = text
+			} else {
+				list.notes[cueID] = text
+			}
+		case "ltxt":
+			var hdr ltxtHeader
+			if len(data) < ltxtHeaderSize {
+				return fmt.Errorf("ltxt entry too small: %d bytes", len(data))
+			}
+			binary.Read(bytes.NewReader(data), binary.LittleEndian, &hdr)
+			list.texts[hdr.CueID] = LabeledText{
+				SampleLength: hdr.SampleLength,
+				Purpose:      hdr.Purpose,
+				Country:      hdr.Country,
+				Language:     hdr.Language,
+				Dialect:      hdr.Dialect,
+				CodePage:     hdr.CodePage,
+				Text:         string(bytes.TrimRight(data[ltxtHeaderSize:], "\x00")),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return adtl{}, fmt.Errorf("error parsing LIST/adtl: %s", err)
+	}
+	return list, nil
+}
+
+// setAdtl replaces the LIST/adtl chunk, removing it when list is empty.
+func (w *Wav) setAdtl(list adtl) {
+	ids := map[uint32]bool{}
+	for id := range list.labels {
+		ids[id] = true
+	}
+	for id := range list.notes {
+		ids[id] = true
+	}
+	for id := range list.texts {
+		ids[id] = true
+	}
+	if len(ids) == 0 {
+		w.removeListChunk("adtl")
+		return
+	}
+
+	sorted := make([]uint32, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("adtl")
+	for _, id := range sorted {
+		if label, ok := list.labels[id]; ok {
+			writeSubchunk(buf, chunkID("labl"), cueText(id, label))
+		}
+		if note, ok := list.notes[id]; ok {
+			writeSubchunk(buf, chunkID("note"), cueText(id, note))
+		}
+		if text, ok := list.texts[id]; ok {
+			entry := &bytes.Buffer{}
+			binary.Write(entry, binary.LittleEndian, ltxtHeader{
+				CueID:        id,
+				SampleLength: text.SampleLength,
+				Purpose:      text.Purpose,
+				Country:      text.Country,
+				Language:     text.Language,
+				Dialect:      text.Dialect,
+				CodePage:     text.CodePage,
+			})
+			if text.Text != "" {
+				entry.WriteString(text.Text)
+				entry.WriteByte(0)
+			}
+			writeSubchunk(buf, chunkID("ltxt"), entry.Bytes())
+		}
+	}
+	w.setListChunk("adtl", buf.Bytes())
+}
+
+// remove drops every entry of the cue id.
+func (list adtl) remove(id uint32) {
+	delete(list.labels, id)
+	delete(list.notes, id)
+	delete(list.texts, id)
+}
+
+func cueText(id uint32, text string) []byte {
+	data := make([]byte, 4, 4+len(text)+1)
+	binary.LittleEndian.PutUint32(data, id)
+	return append(append(data, text...), 0)
+}
@@ -0,0 +1,63 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestAnnotations(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 8000)))
+	wav.SetCuePoints([]CuePoint{
+		{ID: 7, DataChunkID: chunkID("data"), SampleOffset: 4000},
+		{ID: 3, DataChunkID: chunkID("data"), SampleOffset: 1000},
+	})
+
+	// as written by Audacity for a labeled region
+	adtl := &bytes.Buffer{}
+	adtl.WriteString("adtl")
+	writeSubchunk(adtl, chunkID("labl"), cueText(3, "intro"))
+	writeSubchunk(adtl, chunkID("note"), cueText(3, "needs a fade"))
+	ltxt := &bytes.Buffer{}
+	binary.Write(ltxt, binary.LittleEndian, ltxtHeader{
+		CueID:        3,
+		SampleLength: 2000,
+		Purpose:      chunkID("rgn "),
+	})
+	writeSubchunk(adtl, chunkID("ltxt"), ltxt.Bytes())
+	writeSubchunk(adtl, chunkID("labl"), cueText(7, "marker"))
+	writeSubchunk(adtl, chunkID("labl"), cueText(9, "orphan"))
+	wav.setListChunk("adtl", adtl.Bytes())
+
+	buf := &bytes.Buffer{}
+	_, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+	parsed, err := ParseBytes(buf.Bytes())
+	assertNoError(t, err)
+
+	annotations, err := parsed.Annotations()
+	assertNoError(t, err)
+	if len(annotations) != 2 {
+		t.Fatalf("expected 2 annotations, got %+v", annotations)
+	}
+
+	intro := annotations[1]
+	if intro.Cue.ID != 3 || intro.Label != "intro" || intro.Note != "needs a fade" {
+		t.Fatalf("unexpected annotation %+v", intro)
+	}
+	if intro.Text == nil || intro.Text.SampleLength != 2000 || string(intro.Text.Purpose[:]) != "rgn " {
+		t.Fatalf("unexpected labeled text %+v", intro.Text)
+	}
+
+	marker := annotations[0]
+	if marker.Cue.SampleOffset != 4000 || marker.Label != "marker" || marker.Note != "" || marker.Text != nil {
+		t.Fatalf("unexpected annotation %+v", marker)
+	}
+
+	annotations, err = New(WaveFormatPCM, 1, 8000, 16).Annotations()
+	assertNoError(t, err)
+	if len(annotations) != 0 {
+		t.Fatalf("expected no annotations, got %+v", annotations)
+	}
+}
@@ -0,0 +1,209 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+)
+
+type (
+	aiffComm struct {
+		NumChannels     int16
+		NumSampleFrames uint32
+		SampleSize      int16
+		SampleRate      [10]byte // 80 bit IEEE 754 extended
+	}
+)
+
+// ParseAIFF parses an AIFF or AIFF-C stream. Uncompressed PCM,
+// little endian PCM (sowt), IEEE float (fl32, fl64) and G.711 (alaw,
+// ulaw) audio are supported.
+func ParseAIFF(r io.Reader) (*Wav, error) {
+	var form struct {
+		Ident     [4]byte
+		ChunkSize uint32
+		FormType  [4]byte
+	}
+	if err := binary.Read(r, binary.BigEndian, &form); err != nil {
+		return nil, err
+	}
+	if string(form.Ident[:]) != "FORM" {
+		return nil, fmt.Errorf("Invalid IFF identification: %s", string(form.Ident[:]))
+	}
+	formType := string(form.FormType[:])
+	if formType != "AIFF" && formType != "AIFC" {
+		return nil, fmt.Errorf("Invalid AIFF form type: %s", formType)
+	}
+
+	var (
+		comm        *aiffComm
+		compression = "NONE"
+		data        []byte
+	)
+
+	for comm == nil || data == nil {
+		var id [4]byte
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &id); err != nil {
+			return nil, fmt.Errorf("Expected AIFF chunkid: %s", err)
+		}
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return nil, fmt.Errorf("Expected AIFF chunkSize: %s", err)
+		}
+
+		// sizes are untrusted, the contents are only read as they come
+		padded := int64(size) + int64(size%2)
+		if name := string(id[:]); name != "COMM" && name != "SSND" {
+			if _, err := io.CopyN(ioutil.Discard, r, padded); err != nil {
+				return nil, fmt.Errorf("error reading AIFF chunk[%s]: %s", name, err)
+			}
+			continue
+		}
+
+		body, err := ioutil.ReadAll(io.LimitReader(r, padded))
+		if err == nil && int64(len(body)) < padded {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading AIFF chunk[%s]: %s", string(id[:]), err)
+		}
+		body = body[:size]
+
+		switch string(id[:]) {
+		case "COMM":
+			comm = &aiffComm{}
+			br := bytes.NewReader(body)
+			if err := binary.Read(br, binary.BigEndian, comm); err != nil {
+				return nil, fmt.Errorf("error parsing COMM chunk: %s", err)
+			}
+			if formType == "AIFC" {
+				var ctype [4]byte
+				if err := binary.Read(br, binary.BigEndian, &ctype); err != nil {
+					return nil, fmt.Errorf("error parsing AIFC compression type: %s", err)
+				}
+				compression = string(ctype[:])
+			}
+		case "SSND":
+			if len(body) < 8 {
+				return nil, fmt.Errorf("SSND chunk too small: %d bytes", len(body))
+			}
+			offset := binary.BigEndian.Uint32(body)
+			if uint64(offset) > uint64(len(body)-8) {
+				return nil, fmt.Errorf("SSND offset[%d] beyond chunk", offset)
+			}
+			data = body[8+offset:]
+		}
+	}
+
+	return aiffToWav(comm, compression, data)
+}
+
+func aiffToWav(comm *aiffComm, compression string, data []byte) (*Wav, error) {
+	if comm.NumChannels <= 0 || comm.SampleSize <= 0 {
+		return nil, fmt.Errorf(
+			"invalid COMM chunk: channels[%d] sample size[%d]",
+			comm.NumChannels,
+			comm.SampleSize,
+		)
+	}
+
+	format := WaveFormatPCM
+	bits := uint16((comm.SampleSize + 7) / 8 * 8)
+	bigEndian := true
+
+	switch compression {
+	case "NONE", "twos":
+	case "sowt":
+		bigEndian = false
+	case "fl32", "FL32":
+		format, bits = WaveFormatIEEEFloat, 32
+	case "fl64", "FL64":
+		format, bits = WaveFormatIEEEFloat, 64
+	case "alaw", "ALAW":
+		format, bits, bigEndian = WaveFormatALAW, 8, false
+	case "ulaw", "ULAW":
+		format, bits, bigEndian = WaveFormatMULAW, 8, false
+	default:
+		return nil, fmt.Errorf("unsupported AIFF-C compression: %s", compression)
+	}
+
+	rate := extendedToFloat(comm.SampleRate)
+	return foreignToWav(
+		format,
+		uint16(comm.NumChannels),
+		rate,
+		bits,
+		bigEndian,
+		int(comm.NumSampleFrames),
+		data,
+	)
+}
+
+// foreignToWav converts the audio of other containers (AIFF, CAF...)
+// to a Wav, swapping big endian samples and making 8 bits PCM, signed
+// on those containers, unsigned. Up to frames whole frames are kept,
+// a negative frames keeps all of them.
+func foreignToWav(
+	format AudioFormat,
+	channels uint16,
+	rate float64,
+	bits uint16,
+	bigEndian bool,
+	frames int,
+	data []byte,
+) (*Wav, error) {
+	if rate <= 0 || rate > math.MaxUint32 {
+		return nil, fmt.Errorf("invalid sample rate: %f", rate)
+	}
+
+	size := int(bits / 8)
+	frameSize := size * int(channels)
+	if frames < 0 || frames*frameSize > len(data) {
+		frames = len(data) / frameSize
+	}
+
+	converted := make([]byte, frames*frameSize)
+	copy(converted, data)
+
+	if bigEndian {
+		for i := 0; i < len(converted); i += size {
+			reverse(converted[i : i+size])
+		}
+	}
+	if format == WaveFormatPCM && bits == 8 {
+		for i := range converted {
+			converted[i] += 128
+		}
+	}
+
+	wav := New(format, channels, uint32(math.Round(rate)), bits)
+	wav.Data = converted
+	wav.Header.DataBlockSize = uint32(len(converted))
+	wav.Header.RIFFHdr.ChunkSize = riffChunkSize(nil, wav.Header.DataBlockSize)
+	return wav, nil
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+// extendedToFloat converts an 80 bit IEEE 754 extended precision number.
+func extendedToFloat(b [10]byte) float64 {
+	exponent := int(binary.BigEndian.Uint16(b[:2]))
+	mantissa := binary.BigEndian.Uint64(b[2:])
+
+	sign := 1.0
+	if exponent&0x8000 != 0 {
+		sign = -1
+		exponent &= 0x7fff
+	}
+	if exponent == 0 && mantissa == 0 {
+		return 0
+	}
+	return sign * math.Ldexp(float64(mantissa), exponent-16383-63)
+}
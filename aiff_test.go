@@ -0,0 +1,149 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// floatToExtended encodes integral sample rates as 80 bit extended floats.
+func floatToExtended(v float64) [10]byte {
+	var b [10]byte
+	frac, exp := math.Frexp(v)
+	binary.BigEndian.PutUint16(b[:2], uint16(exp-1+16383))
+	binary.BigEndian.PutUint64(b[2:], uint64(frac*(1<<64)))
+	return b
+}
+
+func aiffFile(t *testing.T, formType string, compression string, channels int16, bits int16, rate float64, data []byte) []byte {
+	t.Helper()
+
+	comm := &bytes.Buffer{}
+	frameSize := int(channels) * int((bits+7)/8)
+	if compression == "fl32" {
+		frameSize = int(channels) * 4
+	}
+	binary.Write(comm, binary.BigEndian, aiffComm{
+		NumChannels:     channels,
+		NumSampleFrames: uint32(len(data) / frameSize),
+		SampleSize:      bits,
+		SampleRate:      floatToExtended(rate),
+	})
+	if formType == "AIFC" {
+		comm.WriteString(compression)
+		comm.Write([]byte{0, 0})
+	}
+
+	body := &bytes.Buffer{}
+	body.WriteString(formType)
+	for _, chunk := range []struct {
+		id   string
+		data []byte
+	}{
+		{"COMM", comm.Bytes()},
+		{"NAME", []byte("odd")},
+		{"SSND", append(make([]byte, 8), data...)},
+	} {
+		body.WriteString(chunk.id)
+		binary.Write(body, binary.BigEndian, uint32(len(chunk.data)))
+		body.Write(chunk.data)
+		if len(chunk.data)%2 != 0 {
+			body.WriteByte(0)
+		}
+	}
+
+	file := &bytes.Buffer{}
+	file.WriteString("FORM")
+	binary.Write(file, binary.BigEndian, uint32(body.Len()))
+	file.Write(body.Bytes())
+	return file.Bytes()
+}
+
+func TestParseAIFF(t *testing.T) {
+
+	type tcase struct {
+		name        string
+		formType    string
+		compression string
+		bits        int16
+		data        []byte
+		format      AudioFormat
+		expected    []float64
+	}
+
+	tcases := []tcase{
+		{
+			name:     "pcm16",
+			formType: "AIFF",
+			bits:     16,
+			data:     []byte{0x40, 0x00, 0xc0, 0x00},
+			format:   WaveFormatPCM,
+			expected: []float64{0.5, -0.5},
+		},
+		{
+			name:     "pcm8",
+			formType: "AIFF",
+			bits:     8,
+			data:     []byte{0x40, 0xc0},
+			format:   WaveFormatPCM,
+			expected: []float64{0.5, -0.5},
+		},
+		{
+			name:        "sowt",
+			formType:    "AIFC",
+			compression: "sowt",
+			bits:        16,
+			data:        []byte{0x00, 0x40, 0x00, 0xc0},
+			format:      WaveFormatPCM,
+			expected:    []float64{0.5, -0.5},
+		},
+		{
+			name:        "fl32",
+			formType:    "AIFC",
+			compression: "fl32",
+			bits:        32,
+			data:        []byte{0x3f, 0x00, 0x00, 0x00, 0xbf, 0x00, 0x00, 0x00},
+			format:      WaveFormatIEEEFloat,
+			expected:    []float64{0.5, -0.5},
+		},
+	}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			raw := aiffFile(t, tcase.formType, tcase.compression, 1, tcase.bits, 44100, tcase.data)
+
+			wav, err := ParseAIFF(bytes.NewReader(raw))
+			assertNoError(t, err)
+
+			format := wav.Header.RIFFChunkFmt
+			if format.AudioFormat != tcase.format || format.SampleRate != 44100 || format.NumChannels != 1 {
+				t.Fatalf("unexpected format %#v", format)
+			}
+
+			samples, err := wav.Samples()
+			assertNoError(t, err)
+			assertSamplesClose(t, tcase.expected, samples, 1e-9)
+		})
+	}
+}
+
+func TestParseAIFFErrors(t *testing.T) {
+	_, err := ParseAIFF(bytes.NewReader([]byte("RIFF\x00\x00\x00\x04WAVE")))
+	assertError(t, err)
+
+	raw := aiffFile(t, "AIFC", "ima4", 1, 16, 8000, []byte{0, 0})
+	_, err = ParseAIFF(bytes.NewReader(raw))
+	assertError(t, err)
+
+	// declared chunk sizes beyond the file, up to the wrapping 0xffffffff
+	for _, chunk := range []string{
+		"COMM\xff\xff\xff\xff",
+		"COMM\x7f\xff\xff\xff\x00\x01",
+		"COMM\x00\x00\x00\x12\x00\x01",
+		"junk\xff\xff\xff\xff",
+	} {
+		_, err = ParseAIFF(bytes.NewReader([]byte("FORM\x00\x00\x00\x0cAIFF" + chunk)))
+		assertError(t, err)
+	}
+}
@@ -0,0 +1,119 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"time"
+)
+
+// Align finds the shift, up to maxLag either way, that best lines up
+// b with a, all channels mixed down. A positive offset means b is
+// delayed: its audio starts offset after the same audio on a. The
+// score is the normalized cross correlation at that shift, 1 for
+// identical audio.
+func Align(a, b *Wav, maxLag time.Duration) (offset time.Duration, score float64, err error) {
+	fa, fb := a.Header.RIFFChunkFmt, b.Header.RIFFChunkFmt
+	if fa.SampleRate != fb.SampleRate || fa.SampleRate == 0 {
+		return 0, 0, fmt.Errorf("can't align sample rates[%d] and [%d]", fa.SampleRate, fb.SampleRate)
+	}
+	if maxLag < 0 {
+		return 0, 0, fmt.Errorf("invalid max lag[%s]", maxLag)
+	}
+
+	sa, err := a.Samples()
+	if err != nil {
+		return 0, 0, err
+	}
+	sb, err := b.Samples()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	lag, score, err := alignSamples(sa, sb, int(fa.NumChannels), int(fb.NumChannels), maxLagFrames(maxLag, fa.SampleRate))
+	if err != nil {
+		return 0, 0, err
+	}
+	return framesDuration(lag, fa.SampleRate), score, nil
+}
+
+func maxLagFrames(maxLag time.Duration, rate uint32) int {
+	return int(math.Round(maxLag.Seconds() * float64(rate)))
+}
+
+func framesDuration(frames int, rate uint32) time.Duration {
+	return time.Duration(math.Round(float64(frames) * float64(time.Second) / float64(rate)))
+}
+
+// alignSamples returns the lag, in frames, maximizing the normalized
+// cross correlation of the mono mixes of a and b.
+func alignSamples(a, b []float64, channelsA, channelsB, maxLag int) (int, float64, error) {
+	ma, err := remix(a, channelsA, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	mb, err := remix(b, channelsB, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	n := nextPowerOfTwo(len(ma) + len(mb))
+	xa := make([]complex128, n)
+	xb := make([]complex128, n)
+	for i, s := range ma {
+		xa[i] = complex(s, 0)
+	}
+	for i, s := range mb {
+		xb[i] = complex(s, 0)
+	}
+	fft(xa, false)
+	fft(xb, false)
+	for i := range xa {
+		xa[i] = cmplx.Conj(xa[i]) * xb[i]
+	}
+	// xa[lag] now correlates a[i] with b[i+lag], negative lags wrap
+	fft(xa, true)
+
+	energyA, energyB := cumulativeEnergy(ma), cumulativeEnergy(mb)
+	bestLag, bestScore := 0, math.Inf(-1)
+	for lag := -maxLag; lag <= maxLag; lag++ {
+		// overlap of a[startA:startA+size] with b[startB:startB+size]
+		startA, startB := 0, lag
+		if lag < 0 {
+			startA, startB = -lag, 0
+		}
+		size := len(ma) - startA
+		if len(mb)-startB < size {
+			size = len(mb) - startB
+		}
+		if size <= 0 {
+			continue
+		}
+
+		ea := energyA[startA+size] - energyA[startA]
+		eb := energyB[startB+size] - energyB[startB]
+		if ea <= 0 || eb <= 0 {
+			continue
+		}
+
+		score := real(xa[(lag+n)%n]) / math.Sqrt(ea*eb)
+		if score > bestScore {
+			bestLag, bestScore = lag, score
+		}
+	}
+
+	if math.IsInf(bestScore, -1) {
+		return 0, 0, nil
+	}
+	return bestLag, bestScore, nil
+}
+
+// cumulativeEnergy returns the running sum of squares, with e[i] the
+// energy of x[:i].
+func cumulativeEnergy(x []float64) []float64 {
+	e := make([]float64, len(x)+1)
+	for i, s := range x {
+		e[i+1] = e[i] + s*s
+	}
+	return e
+}
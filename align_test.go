@@ -0,0 +1,65 @@
+package waveparser
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestAlign(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	noise := make([]float64, 8000)
+	for i := range noise {
+		noise[i] = rng.Float64() - 0.5
+	}
+
+	a := New(WaveFormatIEEEFloat, 1, 8000, 32)
+	assertNoError(t, a.SetSamples(noise))
+
+	// b starts 5ms later, in stereo
+	delayed := make([]float64, 0, 2*len(noise))
+	for _, s := range append(make([]float64, 40), noise[:len(noise)-40]...) {
+		delayed = append(delayed, s, s)
+	}
+	b := New(WaveFormatIEEEFloat, 2, 8000, 32)
+	assertNoError(t, b.SetSamples(delayed))
+
+	offset, score, err := Align(a, b, 10*time.Millisecond)
+	assertNoError(t, err)
+	if offset != 5*time.Millisecond {
+		t.Fatalf("expected 5ms offset, got %s", offset)
+	}
+	if math.Abs(score-1) > 1e-6 {
+		t.Fatalf("expected score 1, got %f", score)
+	}
+
+	offset, _, err = Align(b, a, 10*time.Millisecond)
+	assertNoError(t, err)
+	if offset != -5*time.Millisecond {
+		t.Fatalf("expected -5ms offset, got %s", offset)
+	}
+
+	_, _, err = Align(a, New(WaveFormatPCM, 1, 16000, 16), time.Millisecond)
+	assertError(t, err)
+}
+
+func TestCompareAligned(t *testing.T) {
+	samples := make([]float64, 1000)
+	for i := range samples {
+		samples[i] = math.Sin(float64(i)*0.3) * math.Sin(float64(i)*0.011)
+	}
+
+	a := New(WaveFormatIEEEFloat, 1, 1000, 32)
+	assertNoError(t, a.SetSamples(samples[10:]))
+	b := New(WaveFormatIEEEFloat, 1, 1000, 32)
+	assertNoError(t, b.SetSamples(samples[:990]))
+
+	report := Compare(a, b, CompareOptions{Samples: true, MaxLag: 20 * time.Millisecond})
+	if report.Offset != 10*time.Millisecond {
+		t.Fatalf("expected 10ms offset, got %s", report.Offset)
+	}
+	if report.DifferentSamples != 0 {
+		t.Fatalf("expected aligned samples to match, %d differ", report.DifferentSamples)
+	}
+}
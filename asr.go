@@ -0,0 +1,40 @@
+package waveparser
+
+import "math"
+
+// ToASRInput returns a copy of w in the input format of most speech
+// recognizers: mono 16 bits PCM, at 16 kHz unless configured
+// otherwise. Surround audio is mixed down with Downmix.
+func ToASRInput(w *Wav, opts ...ASROption) (*Wav, error) {
+	o := newASROptions(opts)
+
+	if w.Header.RIFFChunkFmt.NumChannels > 2 {
+		mono, err := w.Downmix(LayoutMono)
+		if err != nil {
+			return nil, err
+		}
+		w = mono
+	}
+
+	format := New(WaveFormatPCM, 1, o.sampleRate, 16).Header.RIFFChunkFmt
+	if !o.normalize {
+		return convertResampled(w, format)
+	}
+
+	// normalizing before quantizing keeps the resolution of quiet audio
+	intermediate := New(WaveFormatIEEEFloat, 1, o.sampleRate, 64).Header.RIFFChunkFmt
+	converted, err := convertResampled(w, intermediate)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := converted.Stats()
+	if err != nil {
+		return nil, err
+	}
+	if !math.IsInf(stats.Peak, -1) {
+		if err := converted.Gain(o.peak - stats.Peak); err != nil {
+			return nil, err
+		}
+	}
+	return Convert(converted, format)
+}
@@ -0,0 +1,33 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToASRInput(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 2, 48000, 32)
+	samples := make([]float64, 2*48000)
+	for i := range samples {
+		samples[i] = 0.01 * math.Sin(2*math.Pi*300*float64(i/2)/48000)
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	asr, err := ToASRInput(wav)
+	assertNoError(t, err)
+	expected := New(WaveFormatPCM, 1, 16000, 16).Header.RIFFChunkFmt
+	if !SameFormat(asr.Header.RIFFChunkFmt, expected) || len(asr.Data) != 2*16000 {
+		t.Fatalf("unexpected format %+v with %d bytes", asr.Header.RIFFChunkFmt, len(asr.Data))
+	}
+
+	narrowband, err := ToASRInput(wav, WithASRSampleRate(8000), WithASRPeak(-3))
+	assertNoError(t, err)
+	if narrowband.Header.RIFFChunkFmt.SampleRate != 8000 {
+		t.Fatalf("expected 8 kHz, got %d", narrowband.Header.RIFFChunkFmt.SampleRate)
+	}
+	stats, err := narrowband.Stats()
+	assertNoError(t, err)
+	if math.Abs(stats.Peak+3) > 0.01 {
+		t.Fatalf("expected peak at -3 dBFS, got %f", stats.Peak)
+	}
+}
@@ -0,0 +1,75 @@
+package waveparser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+type (
+	auHeader struct {
+		Magic      [4]byte
+		DataOffset uint32
+		DataSize   uint32
+		Encoding   uint32
+		SampleRate uint32
+		Channels   uint32
+	}
+)
+
+// auUnknownSize is the data size of streams written without knowing it.
+const auUnknownSize = 0xffffffff
+
+// ParseAU parses a Sun AU (.au, .snd) stream. µ-law, A-law, linear
+// PCM and IEEE float audio are supported.
+func ParseAU(r io.Reader) (*Wav, error) {
+	var hdr auHeader
+	if err := binary.Read(r, binary.BigEndian, &hdr); err != nil {
+		return nil, err
+	}
+	if string(hdr.Magic[:]) != ".snd" {
+		return nil, fmt.Errorf("Invalid AU identification: %s", string(hdr.Magic[:]))
+	}
+	if hdr.DataOffset < 24 {
+		return nil, fmt.Errorf("invalid AU data offset: %d", hdr.DataOffset)
+	}
+	if hdr.Channels == 0 || hdr.Channels > 0xffff {
+		return nil, fmt.Errorf("invalid AU number of channels: %d", hdr.Channels)
+	}
+
+	// skip the annotation
+	if _, err := io.CopyN(ioutil.Discard, r, int64(hdr.DataOffset)-24); err != nil {
+		return nil, fmt.Errorf("error reading AU annotation: %s", err)
+	}
+
+	format := WaveFormatPCM
+	bigEndian := true
+	var bits uint16
+
+	switch hdr.Encoding {
+	case 1:
+		format, bits, bigEndian = WaveFormatMULAW, 8, false
+	case 2, 3, 4, 5:
+		bits = uint16(hdr.Encoding-1) * 8
+	case 6:
+		format, bits = WaveFormatIEEEFloat, 32
+	case 7:
+		format, bits = WaveFormatIEEEFloat, 64
+	case 27:
+		format, bits, bigEndian = WaveFormatALAW, 8, false
+	default:
+		return nil, fmt.Errorf("unsupported AU encoding: %d", hdr.Encoding)
+	}
+
+	data := r
+	if hdr.DataSize != auUnknownSize {
+		data = io.LimitReader(r, int64(hdr.DataSize))
+	}
+	audio, err := ioutil.ReadAll(data)
+	if err != nil {
+		return nil, fmt.Errorf("error reading AU data: %s", err)
+	}
+
+	return foreignToWav(format, uint16(hdr.Channels), float64(hdr.SampleRate), bits, bigEndian, -1, audio)
+}
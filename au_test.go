@@ -0,0 +1,98 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func auFile(encoding uint32, channels uint32, dataSize uint32, data []byte) []byte {
+	annotation := []byte("recorded by pbx\x00")
+	file := &bytes.Buffer{}
+	binary.Write(file, binary.BigEndian, auHeader{
+		Magic:      [4]byte{'.', 's', 'n', 'd'},
+		DataOffset: uint32(24 + len(annotation)),
+		DataSize:   dataSize,
+		Encoding:   encoding,
+		SampleRate: 8000,
+		Channels:   channels,
+	})
+	file.Write(annotation)
+	file.Write(data)
+	return file.Bytes()
+}
+
+func TestParseAU(t *testing.T) {
+
+	type tcase struct {
+		name     string
+		encoding uint32
+		data     []byte
+		format   AudioFormat
+		expected []float64
+	}
+
+	ulaw := New(WaveFormatMULAW, 1, 8000, 8)
+	assertNoError(t, ulaw.SetSamples([]float64{0.5, -0.5}))
+
+	tcases := []tcase{
+		{
+			name:     "ulaw",
+			encoding: 1,
+			data:     ulaw.Data,
+			format:   WaveFormatMULAW,
+			expected: []float64{0.5, -0.5},
+		},
+		{
+			name:     "pcm8",
+			encoding: 2,
+			data:     []byte{0x40, 0xc0},
+			format:   WaveFormatPCM,
+			expected: []float64{0.5, -0.5},
+		},
+		{
+			name:     "pcm16",
+			encoding: 3,
+			data:     []byte{0x40, 0x00, 0xc0, 0x00},
+			format:   WaveFormatPCM,
+			expected: []float64{0.5, -0.5},
+		},
+		{
+			name:     "float32",
+			encoding: 6,
+			data:     []byte{0x3f, 0x00, 0x00, 0x00, 0xbf, 0x00, 0x00, 0x00},
+			format:   WaveFormatIEEEFloat,
+			expected: []float64{0.5, -0.5},
+		},
+	}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			for _, size := range []uint32{uint32(len(tcase.data)), auUnknownSize} {
+				wav, err := ParseAU(bytes.NewReader(auFile(tcase.encoding, 1, size, tcase.data)))
+				assertNoError(t, err)
+
+				format := wav.Header.RIFFChunkFmt
+				if format.AudioFormat != tcase.format || format.SampleRate != 8000 || format.NumChannels != 1 {
+					t.Fatalf("unexpected format %#v", format)
+				}
+
+				samples, err := wav.Samples()
+				assertNoError(t, err)
+				assertSamplesClose(t, tcase.expected, samples, 0.04)
+			}
+		})
+	}
+}
+
+func TestParseAUErrors(t *testing.T) {
+	_, err := ParseAU(bytes.NewReader([]byte("RIFF\x00\x00\x00\x04WAVE")))
+	assertError(t, err)
+
+	// G.721 ADPCM
+	_, err = ParseAU(bytes.NewReader(auFile(23, 1, 2, []byte{0, 0})))
+	assertError(t, err)
+
+	_, err = ParseAU(bytes.NewReader(auFile(3, 0, 2, []byte{0, 0})))
+	assertError(t, err)
+}
@@ -0,0 +1,139 @@
+package waveparser
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	// BatchOptions configures ProcessDir.
+	BatchOptions struct {
+		Workers    int      // files processed concurrently, 1 when unset
+		Recursive  bool     // also walk the subdirectories
+		Extensions []string // file extensions processed, ".wav" when unset
+		Parse      []ParseOption
+	}
+
+	// BatchError lists the files ProcessDir failed to load or
+	// process, sorted by path.
+	BatchError struct {
+		Files []FileError
+	}
+
+	// FileError is the failure of a file of a batch.
+	FileError struct {
+		Path string
+		Err  error
+	}
+)
+
+// ProcessDir loads the WAV files in dir and calls fn with each of them,
+// using a pool of workers. Failing files don't stop the others, their
+// errors are returned together as a *BatchError. Cancelling ctx stops
+// loading new files, returning the context error.
+func ProcessDir(ctx context.Context, dir string, fn func(*Wav) error, opts BatchOptions) error {
+	paths, err := batchFiles(dir, opts)
+	if err != nil {
+		return err
+	}
+
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []FileError
+		jobs   = make(chan string)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				err := processFile(path, fn, opts.Parse)
+				if err != nil {
+					mu.Lock()
+					failed = append(failed, FileError{Path: path, Err: err})
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for _, path := range paths {
+		select {
+		case jobs <- path:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		sort.Slice(failed, func(i, j int) bool { return failed[i].Path < failed[j].Path })
+		return &BatchError{Files: failed}
+	}
+	return nil
+}
+
+func processFile(path string, fn func(*Wav) error, opts []ParseOption) error {
+	wav, err := Load(path, opts...)
+	if err != nil {
+		return err
+	}
+	return fn(wav)
+}
+
+// batchFiles lists the files of dir with the batch extensions.
+func batchFiles(dir string, opts BatchOptions) ([]string, error) {
+	extensions := opts.Extensions
+	if len(extensions) == 0 {
+		extensions = []string{".wav"}
+	}
+
+	var paths []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && !opts.Recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, ext := range extensions {
+			if strings.EqualFold(filepath.Ext(path), ext) {
+				paths = append(paths, path)
+				break
+			}
+		}
+		return nil
+	})
+	return paths, err
+}
+
+func (e *BatchError) Error() string {
+	msgs := make([]string, len(e.Files))
+	for i, f := range e.Files {
+		msgs[i] = f.Error()
+	}
+	return fmt.Sprintf("%d files failed: %s", len(e.Files), strings.Join(msgs, "; "))
+}
+
+func (e FileError) Error() string {
+	return fmt.Sprintf("[%s] %s", e.Path, e.Err)
+}
@@ -0,0 +1,61 @@
+package waveparser
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestProcessDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "waveparser")
+	assertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5}))
+	assertNoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+	for _, name := range []string{"a.wav", "b.WAV", "c.wav", filepath.Join("sub", "d.wav")} {
+		assertNoError(t, wav.Save(filepath.Join(dir, name)))
+	}
+	assertNoError(t, ioutil.WriteFile(filepath.Join(dir, "broken.wav"), []byte("RIFF"), 0644))
+	assertNoError(t, ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644))
+
+	var processed int32
+	count := func(*Wav) error {
+		atomic.AddInt32(&processed, 1)
+		return nil
+	}
+
+	err = ProcessDir(context.Background(), dir, count, BatchOptions{Workers: 3})
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Files) != 1 || filepath.Base(batchErr.Files[0].Path) != "broken.wav" {
+		t.Fatalf("expected broken.wav to fail, got %v", err)
+	}
+	if processed != 3 {
+		t.Fatalf("expected 3 files processed, got %d", processed)
+	}
+
+	processed = 0
+	failing := func(*Wav) error {
+		atomic.AddInt32(&processed, 1)
+		return errors.New("rejected")
+	}
+	err = ProcessDir(context.Background(), dir, failing, BatchOptions{Recursive: true})
+	if !errors.As(err, &batchErr) || len(batchErr.Files) != 5 {
+		t.Fatalf("expected every file to fail, got %v", err)
+	}
+	if processed != 4 {
+		t.Fatalf("expected 4 files processed, got %d", processed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = ProcessDir(ctx, dir, count, BatchOptions{})
+	if err != context.Canceled {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+type (
+	// Bext is the Broadcast Wave Format (EBU Tech 3285) extension chunk.
+	Bext struct {
+		Description          string
+		Originator           string
+		OriginatorReference  string
+		OriginationDate      string // yyyy-mm-dd
+		OriginationTime      string // hh:mm:ss
+		TimeReference        uint64 // first sample count since midnight
+		Version              uint16
+		UMID                 [64]byte
+		LoudnessValue        int16 // LUFS x100
+		LoudnessRange        int16 // LU x100
+		MaxTruePeakLevel     int16 // dBTP x100
+		MaxMomentaryLoudness int16 // LUFS x100
+		MaxShortTermLoudness int16 // LUFS x100
+		CodingHistory        string
+	}
+
+	bextChunk struct {
+		Description          [256]byte
+		Originator           [32]byte
+		OriginatorReference  [32]byte
+		OriginationDate      [10]byte
+		OriginationTime      [8]byte
+		TimeReference        uint64
+		Version              uint16
+		UMID                 [64]byte
+		LoudnessValue        int16
+		LoudnessRange        int16
+		MaxTruePeakLevel     int16
+		MaxMomentaryLoudness int16
+		MaxShortTermLoudness int16
+		Reserved             [180]byte
+	}
+)
+
+// Bext parses the bext chunk, returning nil when the file has none.
+func (w *Wav) Bext() (*Bext, error) {
+	chunk := w.Chunk("bext")
+	if chunk == nil {
+		return nil, nil
+	}
+
+	var raw bextChunk
+	r := bytes.NewReader(chunk.Data)
+	if err := binary.Read(r, binary.LittleEndian, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing bext chunk: %s", err)
+	}
+
+	history := make([]byte, r.Len())
+	r.Read(history)
+
+	return &Bext{
+		Description:          cstring(raw.Description[:]),
+		Originator:           cstring(raw.Originator[:]),
+		OriginatorReference:  cstring(raw.OriginatorReference[:]),
+		OriginationDate:      cstring(raw.OriginationDate[:]),
+		OriginationTime:      cstring(raw.OriginationTime[:]),
+		TimeReference:        raw.TimeReference,
+		Version:              raw.Version,
+		UMID:                 raw.UMID,
+		LoudnessValue:        raw.LoudnessValue,
+		LoudnessRange:        raw.LoudnessRange,
+		MaxTruePeakLevel:     raw.MaxTruePeakLevel,
+		MaxMomentaryLoudness: raw.MaxMomentaryLoudness,
+		MaxShortTermLoudness: raw.MaxShortTermLoudness,
+		CodingHistory:        cstring(history),
+	}, nil
+}
+
+// SetBext replaces the bext chunk, removing it when b is nil.
+// Text fields longer than the space reserved for them are truncated.
+func (w *Wav) SetBext(b *Bext) {
+	if b == nil {
+		w.RemoveChunk("bext")
+		return
+	}
+
+	raw := bextChunk{
+		TimeReference:        b.TimeReference,
+		Version:              b.Version,
+		UMID:                 b.UMID,
+		LoudnessValue:        b.LoudnessValue,
+		LoudnessRange:        b.LoudnessRange,
+		MaxTruePeakLevel:     b.MaxTruePeakLevel,
+		MaxMomentaryLoudness: b.MaxMomentaryLoudness,
+		MaxShortTermLoudness: b.MaxShortTermLoudness,
+	}
+	copy(raw.Description[:], b.Description)
+	copy(raw.Originator[:], b.Originator)
+	copy(raw.OriginatorReference[:], b.OriginatorReference)
+	copy(raw.OriginationDate[:], b.OriginationDate)
+	copy(raw.OriginationTime[:], b.OriginationTime)
+
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.LittleEndian, raw)
+	buf.WriteString(b.CodingHistory)
+	w.SetChunk("bext", buf.Bytes())
+}
+
+// cstring converts a fixed size, NUL padded, text field.
+func cstring(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
@@ -0,0 +1,18 @@
+package waveparser
+
+// MarshalBinary encodes w as a complete WAV file, like Bytes.
+func (w *Wav) MarshalBinary() ([]byte, error) {
+	return w.Bytes()
+}
+
+// UnmarshalBinary replaces w with the WAV file encoded on data, parsed
+// with the default options. The audio data doesn't share memory with
+// data.
+func (w *Wav) UnmarshalBinary(data []byte) error {
+	parsed, err := ParseBytes(data)
+	if err != nil {
+		return err
+	}
+	*w = *parsed
+	return nil
+}
@@ -0,0 +1,43 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"reflect"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = &Wav{}
+	_ encoding.BinaryUnmarshaler = &Wav{}
+)
+
+func TestBinaryMarshaling(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 8000, 16)
+	wav.SetInfo(Info{InfoTitle: "cached"})
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5, 0.25, -0.25}))
+	wav.Header.FirstSamplePos = wav.DataOffset()
+
+	data, err := wav.MarshalBinary()
+	assertNoError(t, err)
+
+	var decoded Wav
+	assertNoError(t, decoded.UnmarshalBinary(data))
+	if decoded.Header != wav.Header {
+		t.Fatalf("expected header %+v, got %+v", wav.Header, decoded.Header)
+	}
+	assertBytesEqual(t, wav.Data, decoded.Data)
+	if !reflect.DeepEqual(decoded.Chunks, wav.Chunks) {
+		t.Fatalf("expected chunks %v, got %v", wav.Chunks, decoded.Chunks)
+	}
+
+	// encoding aware transports use the WAV bytes
+	buf := &bytes.Buffer{}
+	assertNoError(t, gob.NewEncoder(buf).Encode(wav))
+	var transmitted Wav
+	assertNoError(t, gob.NewDecoder(buf).Decode(&transmitted))
+	assertBytesEqual(t, wav.Data, transmitted.Data)
+
+	assertError(t, decoded.UnmarshalBinary([]byte("not a wav")))
+}
@@ -0,0 +1,108 @@
+package waveparser
+
+import "fmt"
+
+type (
+	// Builder builds a Wav step by step, keeping its header consistent
+	// with the encoding chosen. Without settings it builds mono 16 bits
+	// PCM at 8000 Hz.
+	Builder struct {
+		format   AudioFormat
+		channels uint16
+		rate     uint32
+		bits     uint16
+		samples  []float64
+		info     Info
+		chunks   []Chunk
+	}
+)
+
+// NewBuilder creates a Builder with the default encoding.
+func NewBuilder() *Builder {
+	return &Builder{
+		format:   WaveFormatPCM,
+		channels: 1,
+		rate:     8000,
+		bits:     16,
+		info:     Info{},
+	}
+}
+
+// SampleRate sets the sample rate, in Hz.
+func (b *Builder) SampleRate(rate uint32) *Builder {
+	b.rate = rate
+	return b
+}
+
+// Channels sets the number of channels, samples are interleaved.
+func (b *Builder) Channels(n uint16) *Builder {
+	b.channels = n
+	return b
+}
+
+// Format sets the audio format and its bits per sample.
+func (b *Builder) Format(format AudioFormat, bitsPerSample uint16) *Builder {
+	b.format = format
+	b.bits = bitsPerSample
+	return b
+}
+
+// PCM8 encodes the audio as unsigned 8 bits PCM.
+func (b *Builder) PCM8() *Builder { return b.Format(WaveFormatPCM, 8) }
+
+// PCM16 encodes the audio as signed 16 bits PCM.
+func (b *Builder) PCM16() *Builder { return b.Format(WaveFormatPCM, 16) }
+
+// PCM24 encodes the audio as signed 24 bits PCM.
+func (b *Builder) PCM24() *Builder { return b.Format(WaveFormatPCM, 24) }
+
+// PCM32 encodes the audio as signed 32 bits PCM.
+func (b *Builder) PCM32() *Builder { return b.Format(WaveFormatPCM, 32) }
+
+// Float32 encodes the audio as 32 bits IEEE float.
+func (b *Builder) Float32() *Builder { return b.Format(WaveFormatIEEEFloat, 32) }
+
+// AddSamples appends interleaved samples, normalized as in
+// Wav.Samples.
+func (b *Builder) AddSamples(samples ...float64) *Builder {
+	b.samples = append(b.samples, samples...)
+	return b
+}
+
+// AddInfo sets a LIST/INFO entry, like InfoTitle.
+func (b *Builder) AddInfo(id, value string) *Builder {
+	b.info[id] = value
+	return b
+}
+
+// AddChunk appends a chunk to be written before the audio data.
+func (b *Builder) AddChunk(id string, data []byte) *Builder {
+	b.chunks = append(b.chunks, Chunk{ID: chunkID(id), Data: cloneBytes(data)})
+	return b
+}
+
+// Build encodes the samples, returning an error when the settings
+// don't make a valid file.
+func (b *Builder) Build() (*Wav, error) {
+	if b.channels == 0 || b.rate == 0 {
+		return nil, fmt.Errorf("invalid builder settings: channels[%d] samplerate[%d]", b.channels, b.rate)
+	}
+	if len(b.samples)%int(b.channels) != 0 {
+		return nil, fmt.Errorf(
+			"samples[%d] aren't whole frames of channels[%d]",
+			len(b.samples),
+			b.channels,
+		)
+	}
+
+	w := New(b.format, b.channels, b.rate, b.bits)
+	for _, chunk := range b.chunks {
+		w.Chunks = append(w.Chunks, Chunk{ID: chunk.ID, Data: cloneBytes(chunk.Data)})
+	}
+	w.SetInfo(b.info)
+	if err := w.SetSamples(b.samples); err != nil {
+		return nil, err
+	}
+	w.Header.FirstSamplePos = w.DataOffset()
+	return w, nil
+}
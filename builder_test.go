@@ -0,0 +1,69 @@
+package waveparser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	samples := []float64{0.5, -0.5, 0.25, -0.25}
+
+	wav, err := NewBuilder().
+		SampleRate(16000).
+		Channels(2).
+		PCM16().
+		AddSamples(samples...).
+		AddInfo(InfoTitle, "built").
+		AddChunk("acme", []byte("vendor")).
+		Build()
+	assertNoError(t, err)
+
+	expected := New(WaveFormatPCM, 2, 16000, 16)
+	if wav.Header.RIFFChunkFmt != expected.Header.RIFFChunkFmt {
+		t.Fatalf("expected fmt %+v, got %+v", expected.Header.RIFFChunkFmt, wav.Header.RIFFChunkFmt)
+	}
+
+	buf := &bytes.Buffer{}
+	_, err = wav.WriteTo(buf)
+	assertNoError(t, err)
+
+	parsed, err := ParseBytes(buf.Bytes())
+	assertNoError(t, err)
+	if parsed.Header != wav.Header {
+		t.Fatalf("expected header %+v, got %+v", wav.Header, parsed.Header)
+	}
+
+	got, err := parsed.Samples()
+	assertNoError(t, err)
+	assertSamplesClose(t, samples, got, 1e-4)
+
+	info, err := parsed.Info()
+	assertNoError(t, err)
+	if info[InfoTitle] != "built" {
+		t.Fatalf("expected title[built], got %v", info)
+	}
+	if chunk := parsed.Chunk("acme"); chunk == nil || string(chunk.Data) != "vendor" {
+		t.Fatalf("vendor chunk not kept: %v", chunk)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	type tcase struct {
+		name    string
+		builder *Builder
+	}
+
+	tcases := []tcase{
+		{name: "NoChannels", builder: NewBuilder().Channels(0)},
+		{name: "NoSampleRate", builder: NewBuilder().SampleRate(0)},
+		{name: "PartialFrame", builder: NewBuilder().Channels(2).AddSamples(0.1, 0.2, 0.3)},
+		{name: "UnsupportedBits", builder: NewBuilder().Format(WaveFormatPCM, 12).AddSamples(0.1)},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.builder.Build()
+			assertError(t, err)
+		})
+	}
+}
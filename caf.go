@@ -0,0 +1,131 @@
+package waveparser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+type (
+	cafDesc struct {
+		SampleRate       float64
+		FormatID         [4]byte
+		FormatFlags      uint32
+		BytesPerPacket   uint32
+		FramesPerPacket  uint32
+		ChannelsPerFrame uint32
+		BitsPerChannel   uint32
+	}
+)
+
+// CAF LPCM format flags
+const (
+	cafFlagFloat        = 1
+	cafFlagLittleEndian = 2
+)
+
+// ParseCAF parses an Apple Core Audio Format stream. Linear PCM
+// (integer or float, either endianness) and G.711 (alaw, ulaw) audio
+// are supported.
+func ParseCAF(r io.Reader) (*Wav, error) {
+	var hdr struct {
+		FileType [4]byte
+		Version  uint16
+		Flags    uint16
+	}
+	if err := binary.Read(r, binary.BigEndian, &hdr); err != nil {
+		return nil, err
+	}
+	if string(hdr.FileType[:]) != "caff" {
+		return nil, fmt.Errorf("Invalid CAF identification: %s", string(hdr.FileType[:]))
+	}
+	if hdr.Version != 1 {
+		return nil, fmt.Errorf("unsupported CAF version: %d", hdr.Version)
+	}
+
+	var desc *cafDesc
+	for {
+		var id [4]byte
+		var size int64
+		if err := binary.Read(r, binary.BigEndian, &id); err != nil {
+			return nil, fmt.Errorf("Expected CAF chunk type: %s", err)
+		}
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return nil, fmt.Errorf("Expected CAF chunk size: %s", err)
+		}
+
+		switch string(id[:]) {
+		case "desc":
+			desc = &cafDesc{}
+			if err := binary.Read(io.LimitReader(r, size), binary.BigEndian, desc); err != nil {
+				return nil, fmt.Errorf("error parsing desc chunk: %s", err)
+			}
+			if _, err := io.CopyN(ioutil.Discard, r, size-32); err != nil {
+				return nil, fmt.Errorf("error reading desc chunk: %s", err)
+			}
+		case "data":
+			if desc == nil {
+				return nil, fmt.Errorf("CAF data chunk before the desc chunk")
+			}
+			// a size of -1 runs until the end of the file
+			data := io.Reader(r)
+			if size >= 0 {
+				data = io.LimitReader(r, size)
+			}
+			audio, err := ioutil.ReadAll(data)
+			if err != nil {
+				return nil, fmt.Errorf("error reading CAF data chunk: %s", err)
+			}
+			if len(audio) < 4 {
+				return nil, fmt.Errorf("CAF data chunk too small: %d bytes", len(audio))
+			}
+			// skip the edit count
+			return cafToWav(desc, audio[4:])
+		default:
+			if size < 0 {
+				return nil, fmt.Errorf("invalid CAF chunk[%s] size: %d", string(id[:]), size)
+			}
+			if _, err := io.CopyN(ioutil.Discard, r, size); err != nil {
+				return nil, fmt.Errorf("error reading CAF chunk[%s]: %s", string(id[:]), err)
+			}
+		}
+	}
+}
+
+func cafToWav(desc *cafDesc, data []byte) (*Wav, error) {
+	channels := desc.ChannelsPerFrame
+	if channels == 0 || channels > 0xffff || desc.FramesPerPacket != 1 {
+		return nil, fmt.Errorf(
+			"invalid desc chunk: channels[%d] frames per packet[%d]",
+			channels,
+			desc.FramesPerPacket,
+		)
+	}
+
+	format := WaveFormatPCM
+	bits := uint16(desc.BitsPerChannel)
+	bigEndian := desc.FormatFlags&cafFlagLittleEndian == 0
+
+	switch string(desc.FormatID[:]) {
+	case "lpcm":
+		if desc.FormatFlags&cafFlagFloat != 0 {
+			format = WaveFormatIEEEFloat
+		}
+		if bits == 0 || bits%8 != 0 || desc.BytesPerPacket != uint32(bits/8)*channels {
+			return nil, fmt.Errorf(
+				"unsupported CAF LPCM packing: bits[%d] bytes per packet[%d]",
+				bits,
+				desc.BytesPerPacket,
+			)
+		}
+	case "alaw":
+		format, bits, bigEndian = WaveFormatALAW, 8, false
+	case "ulaw":
+		format, bits, bigEndian = WaveFormatMULAW, 8, false
+	default:
+		return nil, fmt.Errorf("unsupported CAF format: %s", string(desc.FormatID[:]))
+	}
+
+	return foreignToWav(format, uint16(channels), desc.SampleRate, bits, bigEndian, -1, data)
+}
@@ -0,0 +1,129 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func cafFile(t *testing.T, desc cafDesc, dataSize int64, data []byte) []byte {
+	t.Helper()
+
+	file := &bytes.Buffer{}
+	file.WriteString("caff")
+	binary.Write(file, binary.BigEndian, []uint16{1, 0})
+
+	file.WriteString("desc")
+	binary.Write(file, binary.BigEndian, int64(32))
+	binary.Write(file, binary.BigEndian, desc)
+
+	file.WriteString("free")
+	binary.Write(file, binary.BigEndian, int64(3))
+	file.Write([]byte{0, 0, 0})
+
+	file.WriteString("data")
+	binary.Write(file, binary.BigEndian, dataSize)
+	file.Write([]byte{0, 0, 0, 1}) // edit count
+	file.Write(data)
+	return file.Bytes()
+}
+
+func TestParseCAF(t *testing.T) {
+
+	type tcase struct {
+		name     string
+		desc     cafDesc
+		data     []byte
+		format   AudioFormat
+		expected []float64
+	}
+
+	lpcm := func(flags, bits uint32) cafDesc {
+		return cafDesc{
+			SampleRate:       48000,
+			FormatID:         [4]byte{'l', 'p', 'c', 'm'},
+			FormatFlags:      flags,
+			BytesPerPacket:   bits / 8 * 2,
+			FramesPerPacket:  1,
+			ChannelsPerFrame: 2,
+			BitsPerChannel:   bits,
+		}
+	}
+
+	tcases := []tcase{
+		{
+			name:     "pcm16be",
+			desc:     lpcm(0, 16),
+			data:     []byte{0x40, 0x00, 0xc0, 0x00},
+			format:   WaveFormatPCM,
+			expected: []float64{0.5, -0.5},
+		},
+		{
+			name:     "pcm24le",
+			desc:     lpcm(cafFlagLittleEndian, 24),
+			data:     []byte{0x00, 0x00, 0x40, 0x00, 0x00, 0xc0},
+			format:   WaveFormatPCM,
+			expected: []float64{0.5, -0.5},
+		},
+		{
+			name:     "pcm8",
+			desc:     lpcm(0, 8),
+			data:     []byte{0x40, 0xc0},
+			format:   WaveFormatPCM,
+			expected: []float64{0.5, -0.5},
+		},
+		{
+			name:     "float32be",
+			desc:     lpcm(cafFlagFloat, 32),
+			data:     []byte{0x3f, 0x00, 0x00, 0x00, 0xbf, 0x00, 0x00, 0x00},
+			format:   WaveFormatIEEEFloat,
+			expected: []float64{0.5, -0.5},
+		},
+	}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			for _, size := range []int64{int64(len(tcase.data) + 4), -1} {
+				raw := cafFile(t, tcase.desc, size, tcase.data)
+
+				wav, err := ParseCAF(bytes.NewReader(raw))
+				assertNoError(t, err)
+
+				format := wav.Header.RIFFChunkFmt
+				if format.AudioFormat != tcase.format || format.SampleRate != 48000 || format.NumChannels != 2 {
+					t.Fatalf("unexpected format %#v", format)
+				}
+
+				samples, err := wav.Samples()
+				assertNoError(t, err)
+				assertSamplesClose(t, tcase.expected, samples, 1e-9)
+			}
+		})
+	}
+}
+
+func TestParseCAFErrors(t *testing.T) {
+	_, err := ParseCAF(bytes.NewReader([]byte("RIFF\x00\x00\x00\x04WAVE")))
+	assertError(t, err)
+
+	aac := cafDesc{
+		SampleRate:       44100,
+		FormatID:         [4]byte{'a', 'a', 'c', ' '},
+		FramesPerPacket:  1,
+		ChannelsPerFrame: 1,
+	}
+	_, err = ParseCAF(bytes.NewReader(cafFile(t, aac, -1, []byte{0, 0})))
+	assertError(t, err)
+
+	// 24 bits in 32 bits containers
+	unpacked := cafDesc{
+		SampleRate:       44100,
+		FormatID:         [4]byte{'l', 'p', 'c', 'm'},
+		BytesPerPacket:   4,
+		FramesPerPacket:  1,
+		ChannelsPerFrame: 1,
+		BitsPerChannel:   24,
+	}
+	_, err = ParseCAF(bytes.NewReader(cafFile(t, unpacked, -1, []byte{0, 0, 0, 0})))
+	assertError(t, err)
+}
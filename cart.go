@@ -0,0 +1,161 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+type (
+	// Cart is the cart chunk (AES46), used by radio automation systems
+	// to schedule and play the audio.
+	Cart struct {
+		Version            string // eg: "0101"
+		Title              string
+		Artist             string
+		CutID              string
+		ClientID           string
+		Category           string
+		Classification     string
+		OutCue             string
+		StartDate          string // yyyy-mm-dd
+		StartTime          string // hh:mm:ss
+		EndDate            string // yyyy-mm-dd
+		EndTime            string // hh:mm:ss
+		ProducerAppID      string
+		ProducerAppVersion string
+		UserDef            string
+		LevelReference     int32 // sample value of 0 dB reference
+		PostTimers         []CartTimer
+		URL                string
+		TagText            string
+	}
+
+	// CartTimer is a cart timer marker, like "SEC1" (segue start) or
+	// "INT1" (intro end), placed at the frame given by Value.
+	CartTimer struct {
+		Usage string
+		Value uint32
+	}
+
+	cartTimer struct {
+		Usage [4]byte
+		Value uint32
+	}
+
+	cartChunk struct {
+		Version            [4]byte
+		Title              [64]byte
+		Artist             [64]byte
+		CutID              [64]byte
+		ClientID           [64]byte
+		Category           [64]byte
+		Classification     [64]byte
+		OutCue             [64]byte
+		StartDate          [10]byte
+		StartTime          [8]byte
+		EndDate            [10]byte
+		EndTime            [8]byte
+		ProducerAppID      [64]byte
+		ProducerAppVersion [64]byte
+		UserDef            [64]byte
+		LevelReference     int32
+		PostTimers         [8]cartTimer
+		Reserved           [276]byte
+		URL                [1024]byte
+	}
+)
+
+// Cart parses the cart chunk, returning nil when the file has none.
+// Unused timers (with no usage id) are left out.
+func (w *Wav) Cart() (*Cart, error) {
+	chunk := w.Chunk("cart")
+	if chunk == nil {
+		return nil, nil
+	}
+
+	var raw cartChunk
+	r := bytes.NewReader(chunk.Data)
+	if err := binary.Read(r, binary.LittleEndian, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing cart chunk: %s", err)
+	}
+
+	tagText := make([]byte, r.Len())
+	r.Read(tagText)
+
+	var timers []CartTimer
+	for _, timer := range raw.PostTimers {
+		usage := cstring(timer.Usage[:])
+		if usage == "" {
+			continue
+		}
+		timers = append(timers, CartTimer{Usage: usage, Value: timer.Value})
+	}
+
+	return &Cart{
+		Version:            cstring(raw.Version[:]),
+		Title:              cstring(raw.Title[:]),
+		Artist:             cstring(raw.Artist[:]),
+		CutID:              cstring(raw.CutID[:]),
+		ClientID:           cstring(raw.ClientID[:]),
+		Category:           cstring(raw.Category[:]),
+		Classification:     cstring(raw.Classification[:]),
+		OutCue:             cstring(raw.OutCue[:]),
+		StartDate:          cstring(raw.StartDate[:]),
+		StartTime:          cstring(raw.StartTime[:]),
+		EndDate:            cstring(raw.EndDate[:]),
+		EndTime:            cstring(raw.EndTime[:]),
+		ProducerAppID:      cstring(raw.ProducerAppID[:]),
+		ProducerAppVersion: cstring(raw.ProducerAppVersion[:]),
+		UserDef:            cstring(raw.UserDef[:]),
+		LevelReference:     raw.LevelReference,
+		PostTimers:         timers,
+		URL:                cstring(raw.URL[:]),
+		TagText:            cstring(tagText),
+	}, nil
+}
+
+// SetCart replaces the cart chunk, removing it when c is nil. Text
+// fields longer than the space reserved for them are truncated, as
+// are timers beyond the eight the chunk holds. An empty Version is
+// written as "0101".
+func (w *Wav) SetCart(c *Cart) {
+	if c == nil {
+		w.RemoveChunk("cart")
+		return
+	}
+
+	raw := cartChunk{LevelReference: c.LevelReference}
+	version := c.Version
+	if version == "" {
+		version = "0101"
+	}
+	copy(raw.Version[:], version)
+	copy(raw.Title[:], c.Title)
+	copy(raw.Artist[:], c.Artist)
+	copy(raw.CutID[:], c.CutID)
+	copy(raw.ClientID[:], c.ClientID)
+	copy(raw.Category[:], c.Category)
+	copy(raw.Classification[:], c.Classification)
+	copy(raw.OutCue[:], c.OutCue)
+	copy(raw.StartDate[:], c.StartDate)
+	copy(raw.StartTime[:], c.StartTime)
+	copy(raw.EndDate[:], c.EndDate)
+	copy(raw.EndTime[:], c.EndTime)
+	copy(raw.ProducerAppID[:], c.ProducerAppID)
+	copy(raw.ProducerAppVersion[:], c.ProducerAppVersion)
+	copy(raw.UserDef[:], c.UserDef)
+	copy(raw.URL[:], c.URL)
+	for i, timer := range c.PostTimers {
+		if i == len(raw.PostTimers) {
+			break
+		}
+		copy(raw.PostTimers[i].Usage[:], timer.Usage)
+		raw.PostTimers[i].Value = timer.Value
+	}
+
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.LittleEndian, raw)
+	buf.WriteString(c.TagText)
+	w.SetChunk("cart", buf.Bytes())
+}
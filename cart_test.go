@@ -0,0 +1,113 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestCart(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+
+	c, err := wav.Cart()
+	assertNoError(t, err)
+	if c != nil {
+		t.Fatalf("expected no cart chunk, got %+v", c)
+	}
+
+	var raw cartChunk
+	copy(raw.Version[:], "0101")
+	copy(raw.Title[:], "Morning jingle")
+	copy(raw.Artist[:], "Station")
+	copy(raw.CutID[:], "J0042")
+	copy(raw.StartDate[:], "2020-01-01")
+	copy(raw.StartTime[:], "00:00:00")
+	copy(raw.EndDate[:], "2020-12-31")
+	copy(raw.EndTime[:], "23:59:59")
+	raw.LevelReference = 32768
+	raw.PostTimers[0] = cartTimer{Usage: [4]byte{'S', 'E', 'C', '1'}, Value: 8000}
+	raw.PostTimers[3] = cartTimer{Usage: [4]byte{'I', 'N', 'T', '1'}, Value: 4000}
+	copy(raw.URL[:], "http://example.com/cuts/J0042")
+
+	chunk := &bytes.Buffer{}
+	binary.Write(chunk, binary.LittleEndian, raw)
+	if chunk.Len() != 2048 {
+		t.Fatalf("expected a 2048 bytes cart header, got %d", chunk.Len())
+	}
+	chunk.WriteString("tag text\r\n")
+	wav.SetChunk("cart", chunk.Bytes())
+
+	c, err = wav.Cart()
+	assertNoError(t, err)
+
+	if c.Version != "0101" || c.Title != "Morning jingle" || c.Artist != "Station" || c.CutID != "J0042" {
+		t.Fatalf("unexpected cart %+v", c)
+	}
+	if c.StartDate != "2020-01-01" || c.EndTime != "23:59:59" || c.LevelReference != 32768 {
+		t.Fatalf("unexpected cart %+v", c)
+	}
+	if c.URL != "http://example.com/cuts/J0042" || c.TagText != "tag text\r\n" {
+		t.Fatalf("unexpected cart %+v", c)
+	}
+	expected := []CartTimer{{Usage: "SEC1", Value: 8000}, {Usage: "INT1", Value: 4000}}
+	if len(c.PostTimers) != len(expected) {
+		t.Fatalf("expected timers %+v, got %+v", expected, c.PostTimers)
+	}
+	for i := range expected {
+		if c.PostTimers[i] != expected[i] {
+			t.Fatalf("timer[%d]: expected %+v, got %+v", i, expected[i], c.PostTimers[i])
+		}
+	}
+
+	wav.SetChunk("cart", chunk.Bytes()[:100])
+	_, err = wav.Cart()
+	assertError(t, err)
+}
+
+func TestSetCartRoundTrip(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.1, 0.2}))
+
+	expected := &Cart{
+		Version:   "0101",
+		Title:     "Generated spot",
+		CutID:     "G0001",
+		StartDate: "2021-06-01",
+		StartTime: "06:00:00",
+		EndDate:   "2021-06-30",
+		EndTime:   "22:00:00",
+		PostTimers: []CartTimer{
+			{Usage: "SEG1", Value: 1},
+		},
+		TagText: "<cart/>",
+	}
+	wav.SetCart(expected)
+
+	buf := &bytes.Buffer{}
+	_, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+	parsed, err := ParseBytes(buf.Bytes())
+	assertNoError(t, err)
+
+	got, err := parsed.Cart()
+	assertNoError(t, err)
+	if got.Title != expected.Title || got.CutID != expected.CutID || got.TagText != expected.TagText ||
+		got.StartDate != expected.StartDate || got.EndTime != expected.EndTime {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+	if len(got.PostTimers) != 1 || got.PostTimers[0] != expected.PostTimers[0] {
+		t.Fatalf("unexpected timers %+v", got.PostTimers)
+	}
+
+	wav.SetCart(&Cart{Title: "defaults"})
+	got, err = wav.Cart()
+	assertNoError(t, err)
+	if got.Version != "0101" {
+		t.Fatalf("expected default version, got %q", got.Version)
+	}
+
+	wav.SetCart(nil)
+	if wav.Chunk("cart") != nil {
+		t.Fatal("expected cart chunk removed")
+	}
+}
@@ -0,0 +1,137 @@
+package waveparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// FmtExtension holds the WAVE_FORMAT_EXTENSIBLE fields of the fmt
+	// chunk.
+	FmtExtension struct {
+		ValidBitsPerSample uint16
+		ChannelMask        uint32
+		SubFormat          [16]byte // GUID of the actual audio format
+	}
+
+	// Speaker is a speaker position bit of the extensible channel mask.
+	Speaker uint32
+
+	// ChannelLayout is the speaker position of each channel.
+	ChannelLayout []Speaker
+)
+
+const (
+	SpeakerFrontLeft          Speaker = 0x1
+	SpeakerFrontRight         Speaker = 0x2
+	SpeakerFrontCenter        Speaker = 0x4
+	SpeakerLowFrequency       Speaker = 0x8
+	SpeakerBackLeft           Speaker = 0x10
+	SpeakerBackRight          Speaker = 0x20
+	SpeakerFrontLeftOfCenter  Speaker = 0x40
+	SpeakerFrontRightOfCenter Speaker = 0x80
+	SpeakerBackCenter         Speaker = 0x100
+	SpeakerSideLeft           Speaker = 0x200
+	SpeakerSideRight          Speaker = 0x400
+	SpeakerTopCenter          Speaker = 0x800
+	SpeakerTopFrontLeft       Speaker = 0x1000
+	SpeakerTopFrontCenter     Speaker = 0x2000
+	SpeakerTopFrontRight      Speaker = 0x4000
+	SpeakerTopBackLeft        Speaker = 0x8000
+	SpeakerTopBackCenter      Speaker = 0x10000
+	SpeakerTopBackRight       Speaker = 0x20000
+)
+
+// Common layouts, in the channel order of WAVE_FORMAT_EXTENSIBLE.
+var (
+	LayoutMono    = ChannelLayout{SpeakerFrontCenter}
+	LayoutStereo  = ChannelLayout{SpeakerFrontLeft, SpeakerFrontRight}
+	Layout5Point1 = ChannelLayout{SpeakerFrontLeft, SpeakerFrontRight, SpeakerFrontCenter, SpeakerLowFrequency, SpeakerBackLeft, SpeakerBackRight}
+	Layout7Point1 = ChannelLayout{SpeakerFrontLeft, SpeakerFrontRight, SpeakerFrontCenter, SpeakerLowFrequency, SpeakerBackLeft, SpeakerBackRight, SpeakerSideLeft, SpeakerSideRight}
+)
+
+// size of the extensible fields after cbSize
+const fmtExtensionSize = 22
+
+// subFormatGUIDSuffix is shared by every KSDATAFORMAT_SUBTYPE GUID, the
+// first two bytes are the audio format code.
+var subFormatGUIDSuffix = [14]byte{
+	0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x80, 0x00, 0x00, 0xaa, 0x00, 0x38, 0x9b, 0x71,
+}
+
+var speakerNames = map[Speaker]string{
+	SpeakerFrontLeft:          "FL",
+	SpeakerFrontRight:         "FR",
+	SpeakerFrontCenter:        "FC",
+	SpeakerLowFrequency:       "LFE",
+	SpeakerBackLeft:           "BL",
+	SpeakerBackRight:          "BR",
+	SpeakerFrontLeftOfCenter:  "FLC",
+	SpeakerFrontRightOfCenter: "FRC",
+	SpeakerBackCenter:         "BC",
+	SpeakerSideLeft:           "SL",
+	SpeakerSideRight:          "SR",
+	SpeakerTopCenter:          "TC",
+	SpeakerTopFrontLeft:       "TFL",
+	SpeakerTopFrontCenter:     "TFC",
+	SpeakerTopFrontRight:      "TFR",
+	SpeakerTopBackLeft:        "TBL",
+	SpeakerTopBackCenter:      "TBC",
+	SpeakerTopBackRight:       "TBR",
+}
+
+// ChannelLayout maps each channel index to its speaker position, as
+// given by the extensible channel mask. Channels beyond the positions
+// set on the mask get 0. It returns nil when there is no mask.
+func (w *Wav) ChannelLayout() []Speaker {
+	return w.Header.ChannelLayout()
+}
+
+// ChannelLayout maps each channel index to its speaker position, see
+// Wav.ChannelLayout.
+func (hdr *WavHeader) ChannelLayout() []Speaker {
+	mask := hdr.Extension.ChannelMask
+	if mask == 0 {
+		return nil
+	}
+
+	layout := make([]Speaker, hdr.RIFFChunkFmt.NumChannels)
+	channel := 0
+	for bit := uint(0); bit < 32 && channel < len(layout); bit++ {
+		if mask&(1<<bit) != 0 {
+			layout[channel] = Speaker(1 << bit)
+			channel++
+		}
+	}
+	return layout
+}
+
+// IsExtensible reports whether the header came from a
+// WAVE_FORMAT_EXTENSIBLE fmt chunk.
+func (hdr *WavHeader) IsExtensible() bool {
+	return hdr.Extension != FmtExtension{}
+}
+
+func (s Speaker) String() string {
+	if name, ok := speakerNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Speaker(%#x)", uint32(s))
+}
+
+func (e FmtExtension) subFormat() (AudioFormat, error) {
+	var suffix [14]byte
+	copy(suffix[:], e.SubFormat[2:])
+	if suffix != subFormatGUIDSuffix {
+		return 0, fmt.Errorf("unsupported extensible sub format[%x]", e.SubFormat)
+	}
+	return AudioFormat(e.SubFormat[0]) | AudioFormat(e.SubFormat[1])<<8, nil
+}
+
+func (l ChannelLayout) String() string {
+	names := make([]string, len(l))
+	for i, speaker := range l {
+		names[i] = speaker.String()
+	}
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,117 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func extensibleWav(t *testing.T, subFormat AudioFormat, mask uint32, channels uint16) []byte {
+	t.Helper()
+
+	guid := [16]byte{byte(subFormat), byte(subFormat >> 8)}
+	copy(guid[2:], subFormatGUIDSuffix[:])
+
+	data := make([]byte, 4*int(channels))
+	buf := &bytes.Buffer{}
+	for _, v := range []interface{}{
+		RiffHeader{
+			Ident:     [4]byte{'R', 'I', 'F', 'F'},
+			ChunkSize: 4 + 8 + 40 + 8 + uint32(len(data)),
+			FileType:  [4]byte{'W', 'A', 'V', 'E'},
+		},
+		[4]byte{'f', 'm', 't', ' '},
+		RiffChunkFmt{
+			LengthOfHeader: 40,
+			AudioFormat:    WaveFormatExtensible,
+			NumChannels:    channels,
+			SampleRate:     48000,
+			BytesPerSec:    48000 * 2 * uint32(channels),
+			BytesPerBloc:   2 * channels,
+			BitsPerSample:  16,
+		},
+		uint16(fmtExtensionSize),
+		FmtExtension{ValidBitsPerSample: 16, ChannelMask: mask, SubFormat: guid},
+		[4]byte{'d', 'a', 't', 'a'},
+		uint32(len(data)),
+		data,
+	} {
+		assertNoError(t, binary.Write(buf, binary.LittleEndian, v))
+	}
+	return buf.Bytes()
+}
+
+func TestParseExtensible(t *testing.T) {
+	const mask = uint32(SpeakerFrontLeft | SpeakerFrontRight | SpeakerFrontCenter |
+		SpeakerLowFrequency | SpeakerSideLeft | SpeakerSideRight)
+
+	wav, err := ParseBytes(extensibleWav(t, WaveFormatPCM, mask, 6))
+	assertNoError(t, err)
+
+	if !wav.Header.IsExtensible() || wav.Format() != WaveFormatPCM {
+		t.Fatalf("expected extensible PCM, got %+v", wav.Header)
+	}
+
+	expected := []Speaker{
+		SpeakerFrontLeft,
+		SpeakerFrontRight,
+		SpeakerFrontCenter,
+		SpeakerLowFrequency,
+		SpeakerSideLeft,
+		SpeakerSideRight,
+	}
+	if layout := wav.ChannelLayout(); !reflect.DeepEqual(layout, expected) {
+		t.Fatalf("expected layout %v, got %v", expected, layout)
+	}
+
+	samples, err := wav.Samples()
+	assertNoError(t, err)
+	if len(samples) != 12 {
+		t.Fatalf("expected [12] samples, got [%d]", len(samples))
+	}
+}
+
+func TestExtensibleRoundTrip(t *testing.T) {
+	raw := extensibleWav(t, WaveFormatPCM, 0x60f, 6)
+	wav, err := ParseBytes(raw)
+	assertNoError(t, err)
+
+	data, err := wav.Bytes()
+	assertNoError(t, err)
+	assertBytesEqual(t, raw, data)
+
+	parsed, err := ParseBytes(data)
+	assertNoError(t, err)
+	if !reflect.DeepEqual(parsed.Header, wav.Header) {
+		t.Fatalf("expected header %+v, got %+v", wav.Header, parsed.Header)
+	}
+	if parsed.Header.Extension.ChannelMask != 0x60f || len(parsed.ChannelLayout()) != 6 {
+		t.Fatalf("expected mask 0x60f on 6 channels, got %+v", parsed.Header.Extension)
+	}
+	if parsed.DataOffset() != parsed.Header.FirstSamplePos {
+		t.Fatalf("expected data offset [%d], got [%d]", parsed.Header.FirstSamplePos, parsed.DataOffset())
+	}
+}
+
+func TestChannelLayoutShortMask(t *testing.T) {
+	wav, err := ParseBytes(extensibleWav(t, WaveFormatPCM, uint32(SpeakerFrontCenter), 2))
+	assertNoError(t, err)
+
+	expected := []Speaker{SpeakerFrontCenter, 0}
+	if layout := wav.ChannelLayout(); !reflect.DeepEqual(layout, expected) {
+		t.Fatalf("expected layout %v, got %v", expected, layout)
+	}
+
+	plain := New(WaveFormatPCM, 2, 8000, 16)
+	if plain.ChannelLayout() != nil {
+		t.Fatal("expected no layout without a channel mask")
+	}
+}
+
+func TestParseExtensibleUnknownSubFormat(t *testing.T) {
+	raw := extensibleWav(t, WaveFormatPCM, 0x3, 2)
+	raw[len(raw)-4*2-8-1] ^= 0xff // corrupt the GUID
+	_, err := ParseBytes(raw)
+	assertError(t, err)
+}
@@ -0,0 +1,32 @@
+package waveparser
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"hash"
+)
+
+// DataChecksum returns the hex encoded hash of the audio data, so
+// copies of the audio can be checked regardless of their chunks.
+func (w *Wav) DataChecksum(h hash.Hash) string {
+	h.Reset()
+	h.Write(w.Data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// AudioMD5 returns the MD5 of the audio data as FLAC computes it, with
+// 8 bits PCM samples signed, so it matches the MD5 stored on the
+// STREAMINFO of a lossless encoding of the same audio.
+func (w *Wav) AudioMD5() string {
+	f := w.Header.RIFFChunkFmt
+	if f.AudioFormat != WaveFormatPCM || f.BitsPerSample != 8 {
+		return w.DataChecksum(md5.New())
+	}
+
+	signed := make([]byte, len(w.Data))
+	for i, b := range w.Data {
+		signed[i] = b - 128
+	}
+	sum := md5.Sum(signed)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,34 @@
+package waveparser
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestDataChecksum(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5}))
+	wav.SetInfo(Info{InfoTitle: "ignored"})
+
+	if got := wav.DataChecksum(md5.New()); got != "097732d2b267dfd70590c83568c6a104" {
+		t.Fatalf("unexpected md5[%s]", got)
+	}
+	if got := wav.AudioMD5(); got != wav.DataChecksum(md5.New()) {
+		t.Fatalf("expected 16 bits audio md5 to hash the data, got[%s]", got)
+	}
+	if got := wav.DataChecksum(sha256.New()); len(got) != 64 {
+		t.Fatalf("unexpected sha256[%s]", got)
+	}
+}
+
+func TestAudioMD5SignsPCM8(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 8)
+	wav.Data = []byte{0x80, 0x00, 0xff}
+
+	sum := md5.Sum([]byte{0x00, 0x80, 0x7f})
+	if got := wav.AudioMD5(); got != hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected md5[%s]", got)
+	}
+}
@@ -0,0 +1,63 @@
+package waveparser
+
+import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+)
+
+type (
+	// ChunkID is the four character id of a RIFF chunk.
+	ChunkID [4]byte
+
+	// ChunkReader walks the chunks of a WAV stream, including fmt and
+	// data, for handling chunks the parser doesn't know about.
+	ChunkReader struct {
+		Header RiffHeader
+
+		r     io.Reader
+		chunk *io.LimitedReader
+		pad   int64
+	}
+)
+
+func (id ChunkID) String() string {
+	return string(id[:])
+}
+
+// NewChunkReader reads the RIFF header of r, returning a reader
+// positioned at its first chunk.
+func NewChunkReader(r io.Reader) (*ChunkReader, error) {
+	hdr, err := parseRIFFHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &ChunkReader{Header: *hdr, r: r}, nil
+}
+
+// Next advances to the next chunk, returning its id, its declared size
+// and a reader of its contents, valid until the next call. Contents
+// not read are skipped. At the end of the stream it returns io.EOF.
+func (c *ChunkReader) Next() (id ChunkID, size uint32, r io.Reader, err error) {
+	if c.chunk != nil {
+		skip := c.chunk.N + c.pad
+		c.chunk = nil
+		if _, err := io.CopyN(ioutil.Discard, c.r, skip); err != nil {
+			return id, 0, nil, err
+		}
+	}
+
+	if err := binary.Read(c.r, binary.BigEndian, &id); err != nil {
+		return id, 0, nil, err
+	}
+	if err := binary.Read(c.r, binary.LittleEndian, &size); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return id, 0, nil, err
+	}
+
+	c.chunk = &io.LimitedReader{R: c.r, N: int64(size)}
+	c.pad = int64(size % 2)
+	return id, size, c.chunk, nil
+}
@@ -0,0 +1,54 @@
+package waveparser
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestChunkReader(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 8)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5, 0.25}))
+	wav.SetChunk("acme", []byte("vendor"))
+
+	buf := &bytes.Buffer{}
+	_, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+
+	chunks, err := NewChunkReader(buf)
+	assertNoError(t, err)
+	if string(chunks.Header.FileType[:]) != "WAVE" {
+		t.Fatalf("unexpected RIFF header %+v", chunks.Header)
+	}
+
+	var ids []string
+	for {
+		id, size, r, err := chunks.Next()
+		if err == io.EOF {
+			break
+		}
+		assertNoError(t, err)
+		ids = append(ids, id.String())
+
+		switch id.String() {
+		case "acme":
+			data, err := ioutil.ReadAll(r)
+			assertNoError(t, err)
+			assertBytesEqual(t, []byte("vendor"), data)
+		case "data":
+			if size != 3 {
+				t.Fatalf("expected data size[3], got[%d]", size)
+			}
+		}
+	}
+
+	if len(ids) != 3 || ids[0] != "fmt " {
+		t.Fatalf("unexpected chunks %v", ids)
+	}
+}
+
+func TestChunkReaderInvalidRIFF(t *testing.T) {
+	_, err := NewChunkReader(bytes.NewReader([]byte("RIFX\x00\x00\x00\x00WAVE")))
+	assertError(t, err)
+}
@@ -0,0 +1,85 @@
+package waveparser
+
+// Chunk returns the first chunk with the given id, or nil if there is none.
+func (w *Wav) Chunk(id string) *Chunk {
+	i := w.chunkIndex(func(c Chunk) bool {
+		return string(c.ID[:]) == id
+	})
+	if i < 0 {
+		return nil
+	}
+	return &w.Chunks[i]
+}
+
+// SetChunk replaces the contents of the first chunk with the given id,
+// appending a new chunk if there is none.
+func (w *Wav) SetChunk(id string, data []byte) {
+	w.setChunk(func(c Chunk) bool {
+		return string(c.ID[:]) == id
+	}, chunkID(id), data)
+}
+
+// RemoveChunk removes every chunk with the given id.
+func (w *Wav) RemoveChunk(id string) {
+	w.removeChunks(func(c Chunk) bool {
+		return string(c.ID[:]) == id
+	})
+}
+
+// listChunk returns the LIST chunk of the given type (INFO, adtl...).
+func (w *Wav) listChunk(listType string) *Chunk {
+	i := w.chunkIndex(isList(listType))
+	if i < 0 {
+		return nil
+	}
+	return &w.Chunks[i]
+}
+
+func (w *Wav) setListChunk(listType string, data []byte) {
+	w.setChunk(isList(listType), chunkID("LIST"), data)
+}
+
+func (w *Wav) removeListChunk(listType string) {
+	w.removeChunks(isList(listType))
+}
+
+func isList(listType string) func(Chunk) bool {
+	return func(c Chunk) bool {
+		return string(c.ID[:]) == "LIST" &&
+			len(c.Data) >= 4 &&
+			string(c.Data[:4]) == listType
+	}
+}
+
+func (w *Wav) chunkIndex(match func(Chunk) bool) int {
+	for i, chunk := range w.Chunks {
+		if match(chunk) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (w *Wav) setChunk(match func(Chunk) bool, id [4]byte, data []byte) {
+	if i := w.chunkIndex(match); i >= 0 {
+		w.Chunks[i].Data = data
+		return
+	}
+	w.Chunks = append(w.Chunks, Chunk{ID: id, Data: data})
+}
+
+func (w *Wav) removeChunks(match func(Chunk) bool) {
+	kept := w.Chunks[:0]
+	for _, chunk := range w.Chunks {
+		if !match(chunk) {
+			kept = append(kept, chunk)
+		}
+	}
+	w.Chunks = kept
+}
+
+func chunkID(id string) [4]byte {
+	var cid [4]byte
+	copy(cid[:], id+"    ")
+	return cid
+}
@@ -0,0 +1,25 @@
+package waveparser
+
+// Clone returns a deep copy of w, sharing no memory with it.
+func (w *Wav) Clone() *Wav {
+	clone := &Wav{
+		Header:  w.Header,
+		Data:    cloneBytes(w.Data),
+		Padding: append([]Padding(nil), w.Padding...),
+	}
+
+	for _, chunk := range w.Chunks {
+		clone.Chunks = append(clone.Chunks, Chunk{ID: chunk.ID, Data: cloneBytes(chunk.Data)})
+	}
+	for _, data := range w.DataChunks {
+		clone.DataChunks = append(clone.DataChunks, cloneBytes(data))
+	}
+	return clone
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
@@ -0,0 +1,26 @@
+package waveparser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	wav, err := Load("./testdata/r.wav")
+	assertNoError(t, err)
+
+	clone := wav.Clone()
+	if !reflect.DeepEqual(wav, clone) {
+		t.Fatal("clone differs from the original")
+	}
+
+	assertNoError(t, clone.Gain(-6))
+	clone.Chunks[0].Data[0] = 'X'
+	clone.Header.RIFFChunkFmt.SampleRate = 1
+
+	original, err := Load("./testdata/r.wav")
+	assertNoError(t, err)
+	if !reflect.DeepEqual(wav, original) {
+		t.Fatal("changing the clone changed the original")
+	}
+}
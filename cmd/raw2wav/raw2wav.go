@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+var formats = map[string]waveparser.AudioFormat{
+	"pcm":   waveparser.WaveFormatPCM,
+	"float": waveparser.WaveFormatIEEEFloat,
+	"alaw":  waveparser.WaveFormatALAW,
+	"mulaw": waveparser.WaveFormatMULAW,
+}
+
+// defaultBits are the bits per sample of each format when -bits isn't given.
+var defaultBits = map[string]uint{
+	"pcm":   16,
+	"float": 32,
+	"alaw":  8,
+	"mulaw": 8,
+}
+
+func main() {
+	var (
+		rate     uint
+		channels uint
+		bits     uint
+		format   string
+	)
+
+	flag.UintVar(&rate, "rate", 0, "sample rate")
+	flag.UintVar(&channels, "channels", 1, "number of channels")
+	flag.UintVar(&bits, "bits", 0, "bits per sample (default 16 for pcm, 32 for float, 8 for alaw and mulaw)")
+	flag.StringVar(&format, "format", "pcm", "sample encoding: pcm, float, alaw or mulaw")
+	flag.Parse()
+
+	audioFormat, ok := formats[format]
+	if rate == 0 || !ok {
+		fmt.Printf("usage: %s -rate <rate> [-channels <channels>] [-bits <bits>] [-format pcm|float|alaw|mulaw] < <raw file> > <wav file>\n", os.Args[0])
+		return
+	}
+	if bits == 0 {
+		bits = defaultBits[format]
+	}
+
+	wav, err := waveparser.LoadRaw(os.Stdin, waveparser.RiffChunkFmt{
+		AudioFormat:   audioFormat,
+		NumChannels:   uint16(channels),
+		SampleRate:    uint32(rate),
+		BitsPerSample: uint16(bits),
+	})
+	abortonerr(err, "reading raw audio")
+
+	_, err = wav.WriteTo(os.Stdout)
+	abortonerr(err, "writing wav")
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+func main() {
+
+	if len(os.Args) < 2 {
+		fmt.Printf("usage: %s <wav file> > <raw file>\n", os.Args[0])
+		return
+	}
+
+	wavpath := os.Args[1]
+	wav, err := waveparser.Load(wavpath)
+	abortonerr(err, "loading [%s]", wavpath)
+
+	fmt.Fprintln(os.Stderr, wav.Header.String())
+
+	_, err = os.Stdout.Write(wav.Data)
+	abortonerr(err, "writing raw audio")
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
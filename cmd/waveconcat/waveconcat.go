@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+func main() {
+	var (
+		output  string
+		gap     time.Duration
+		convert bool
+	)
+
+	flag.StringVar(&output, "o", "", "output wav file")
+	flag.DurationVar(&gap, "gap", 0, "silence inserted between inputs (eg: 500ms)")
+	flag.BoolVar(&convert, "convert", false, "convert inputs to the format of the first one instead of failing")
+	flag.Parse()
+
+	inputs := flag.Args()
+	if output == "" || len(inputs) == 0 {
+		fmt.Printf("usage: %s -o <output wav> [-gap <duration>] [-convert] <wav file>...\n", os.Args[0])
+		return
+	}
+
+	wavs := make([]*waveparser.Wav, len(inputs))
+	for i, input := range inputs {
+		wav, err := waveparser.Load(input)
+		abortonerr(err, "loading [%s]", input)
+		wavs[i] = wav
+	}
+
+	format := wavs[0].Header.RIFFChunkFmt
+	for i, wav := range wavs {
+		if waveparser.SameFormat(format, wav.Header.RIFFChunkFmt) {
+			continue
+		}
+		if !convert {
+			abortonerr(fmt.Errorf("format differs from [%s]", inputs[0]), "checking [%s]", inputs[i])
+		}
+		if rate := wav.Header.RIFFChunkFmt.SampleRate; rate != format.SampleRate {
+			// resampled as floats to quantize only once
+			float := waveparser.New(waveparser.WaveFormatIEEEFloat, format.NumChannels, rate, 64)
+			floats, err := waveparser.Convert(wav, float.Header.RIFFChunkFmt)
+			abortonerr(err, "converting [%s]", inputs[i])
+			wav, err = waveparser.Resample(floats, format.SampleRate, waveparser.ResampleSinc)
+			abortonerr(err, "resampling [%s]", inputs[i])
+		}
+		converted, err := waveparser.Convert(wav, format)
+		abortonerr(err, "converting [%s]", inputs[i])
+		wavs[i] = converted
+	}
+
+	out, err := os.Create(output)
+	abortonerr(err, "creating [%s]", output)
+	defer out.Close()
+
+	writer, err := waveparser.NewWriter(out, format)
+	abortonerr(err, "writing [%s]", output)
+
+	silence := make([]float64, gapFrames(gap, format.SampleRate)*int(format.NumChannels))
+
+	for i, wav := range wavs {
+		if i > 0 && len(silence) > 0 {
+			err = writer.WriteSamples(silence)
+			abortonerr(err, "writing gap into [%s]", output)
+		}
+		_, err = writer.Write(wav.Data)
+		abortonerr(err, "writing [%s] into [%s]", inputs[i], output)
+	}
+
+	abortonerr(writer.Close(), "finishing [%s]", output)
+}
+
+func gapFrames(gap time.Duration, sampleRate uint32) int {
+	return int(gap.Seconds() * float64(sampleRate))
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
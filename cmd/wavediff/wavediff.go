@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -8,14 +9,24 @@ import (
 )
 
 func main() {
-
-	if len(os.Args) < 3 {
-		fmt.Printf("usage: %s <wav file> <other wav file>\n", os.Args[0])
+	var (
+		opts waveparser.CompareOptions
+		md5  bool
+	)
+
+	flag.BoolVar(&opts.Samples, "samples", false, "also compare the audio samples")
+	flag.Float64Var(&opts.Tolerance, "tolerance", 0, "max difference between samples (normalized to [-1, 1])")
+	flag.BoolVar(&md5, "md5", false, "print the MD5 of the audio data of both files")
+	flag.DurationVar(&opts.MaxLag, "align", 0, "align the samples, shifting them up to the given duration, before comparing")
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		fmt.Printf("usage: %s [-samples] [-tolerance <tolerance>] [-align <max lag>] [-md5] <wav file> <other wav file>\n", os.Args[0])
 		return
 	}
 
-	wavpath1 := os.Args[1]
-	wavpath2 := os.Args[2]
+	wavpath1 := flag.Arg(0)
+	wavpath2 := flag.Arg(1)
 
 	wav1, err := waveparser.Load(wavpath1)
 	abortonerr(err, "loading [%s]", wavpath1)
@@ -23,94 +34,33 @@ func main() {
 	wav2, err := waveparser.Load(wavpath2)
 	abortonerr(err, "loading [%s]", wavpath2)
 
-	if diffHeaders(wavpath1, wav1.Header, wavpath2, wav2.Header) {
-		os.Exit(-1)
-	}
-}
-
-func diffHeaders(
-	wavpath1 string, h1 waveparser.WavHeader,
-	wavpath2 string, h2 waveparser.WavHeader,
-) bool {
-	wroteHeader := false
-	writeHeader := func() {
-		if wroteHeader {
-			return
-		}
-		wroteHeader = true
-		fmt.Printf("\n[%s] header differs from [%s] header\n", wavpath1, wavpath2)
-		fmt.Printf("[%s] values will be on the left, [%s] on the right\n\n", wavpath1, wavpath2)
-	}
-	writeDiff := func(f string, args ...interface{}) {
-		writeHeader()
-		fmt.Println(fmt.Sprintf(f, args...))
-	}
-
-	ident1 := h1.RIFFHdr.Ident
-	ident2 := h2.RIFFHdr.Ident
-
-	for i, b := range ident1 {
-		if ident2[i] != b {
-			writeDiff("RIFF Ident Byte[%d] differs: [%x] != [%x]", b, ident2[i])
-		}
+	if md5 {
+		fmt.Printf("%s  %s\n", wav1.AudioMD5(), wavpath1)
+		fmt.Printf("%s  %s\n", wav2.AudioMD5(), wavpath2)
 	}
 
-	chunksize1 := h1.RIFFHdr.ChunkSize
-	chunksize2 := h2.RIFFHdr.ChunkSize
-
-	if chunksize1 != chunksize2 {
-		writeDiff("ChunkSize: [%d] != [%d]", chunksize1, chunksize2)
-	}
-
-	ft1 := h1.RIFFHdr.FileType
-	ft2 := h2.RIFFHdr.FileType
-
-	for i, b := range ft1 {
-		if ft2[i] != b {
-			writeDiff("FileType Byte[%d] differs: [%x] != [%x]", b, ft2[i])
-		}
-	}
-
-	cf1 := h1.RIFFChunkFmt
-	cf2 := h2.RIFFChunkFmt
-
-	if cf1.LengthOfHeader != cf2.LengthOfHeader {
-		writeDiff("Length Of Header: [%d] != [%d]", cf1.LengthOfHeader, cf2.LengthOfHeader)
+	report := waveparser.Compare(wav1, wav2, opts)
+	if report.Offset != 0 {
+		fmt.Printf("[%s] is shifted by [%s]\n", wavpath2, report.Offset)
 	}
-
-	if cf1.AudioFormat != cf2.AudioFormat {
-		writeDiff("Audio Format: [%d] != [%d]", cf1.AudioFormat, cf2.AudioFormat)
-	}
-
-	if cf1.NumChannels != cf2.NumChannels {
-		writeDiff("Number Of Channels: [%d] != [%d]", cf1.NumChannels, cf2.NumChannels)
-	}
-
-	if cf1.SampleRate != cf2.SampleRate {
-		writeDiff("Samplerate: [%d] != [%d]", cf1.SampleRate, cf2.SampleRate)
-	}
-
-	if cf1.BytesPerSec != cf2.BytesPerSec {
-		writeDiff("Bytes Per Sec: [%d] != [%d]", cf1.BytesPerSec, cf2.BytesPerSec)
-	}
-
-	if cf1.BytesPerBloc != cf2.BytesPerBloc {
-		writeDiff("Bytes Per Sec: [%d] != [%d]", cf1.BytesPerBloc, cf2.BytesPerBloc)
-	}
-
-	if cf1.BitsPerSample != cf2.BitsPerSample {
-		writeDiff("Bits Per Sample: [%d] != [%d]", cf1.BitsPerSample, cf2.BitsPerSample)
+	if report.Equal() {
+		return
 	}
 
-	if h1.FirstSamplePos != h2.FirstSamplePos {
-		writeDiff("First Sample Position: [%d] != [%d]", h1.FirstSamplePos, h2.FirstSamplePos)
+	fmt.Printf("\n[%s] differs from [%s]\n", wavpath1, wavpath2)
+	fmt.Printf("[%s] values will be on the left, [%s] on the right\n\n", wavpath1, wavpath2)
+	for _, diff := range report.Differences {
+		fmt.Println(diff)
 	}
-
-	if h1.DataBlockSize != h2.DataBlockSize {
-		writeDiff("Data Block Size: [%d] != [%d]", h1.DataBlockSize, h2.DataBlockSize)
+	if report.DifferentSamples > 0 {
+		fmt.Printf(
+			"%d samples differ, first at [%d], max difference [%f]\n",
+			report.DifferentSamples,
+			report.FirstSampleDiff,
+			report.MaxSampleDiff,
+		)
 	}
-
-	return wroteHeader
+	os.Exit(-1)
 }
 
 func abortonerr(err error, f string, args ...interface{}) {
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+// byte offsets of the fields patched by wavefix, the parser requires
+// the fmt chunk to come right after the RIFF header.
+const (
+	riffSizePos     = 4
+	bytesPerSecPos  = 28
+	bytesPerBlocPos = 32
+)
+
+func main() {
+	var (
+		output string
+		strip  bool
+	)
+
+	flag.StringVar(&output, "o", "", "output (repaired) wav file")
+	flag.BoolVar(&strip, "strip", false, "strip everything after the data chunk")
+	flag.Parse()
+
+	if output == "" || flag.NArg() != 1 {
+		fmt.Printf("usage: %s -o <output wav> [-strip] <wav file>\n", os.Args[0])
+		return
+	}
+
+	input := flag.Arg(0)
+	fixed, err := repair(input, strip)
+	abortonerr(err, "repairing [%s]", input)
+	if fixed != nil {
+		abortonerr(ioutil.WriteFile(output, fixed, 0644), "writing [%s]", output)
+	}
+}
+
+// repair recovers the audio with waveparser.LoadSalvage and reports the
+// changes waveparser.Repair makes to the header on the file. The fixed
+// header and audio are written over the original file, keeping what
+// comes after the data chunk unless strip is set. It returns nil when
+// there is nothing to change.
+func repair(path string, strip bool) ([]byte, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	hdr, err := waveparser.ParseHeader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	wav, err := waveparser.LoadSalvage(path)
+	if err != nil {
+		return nil, err
+	}
+
+	salvaged := &waveparser.Wav{Header: hdr, Chunks: wav.Chunks, Data: wav.Data}
+	report, err := waveparser.Repair(salvaged)
+	if err != nil {
+		return nil, err
+	}
+
+	dataEnd := int(hdr.FirstSamplePos) + int(hdr.DataBlockSize) + int(hdr.DataBlockSize%2)
+	var trailing []byte
+	if dataEnd < len(raw) {
+		trailing = raw[dataEnd:]
+	}
+	stripped := strip && len(trailing) > 0
+	if stripped {
+		fmt.Printf("Stripped %d trailing bytes\n", len(trailing))
+		trailing = nil
+	}
+	if report.Repaired() || !stripped {
+		fmt.Println(report)
+	}
+	if !report.Repaired() && !stripped {
+		return nil, nil
+	}
+
+	format := salvaged.Header.RIFFChunkFmt
+	fixed := append([]byte{}, raw[:hdr.FirstSamplePos]...)
+	binary.LittleEndian.PutUint32(fixed[bytesPerSecPos:], format.BytesPerSec)
+	binary.LittleEndian.PutUint16(fixed[bytesPerBlocPos:], format.BytesPerBloc)
+	binary.LittleEndian.PutUint32(fixed[hdr.FirstSamplePos-4:], salvaged.Header.DataBlockSize)
+
+	fixed = append(fixed, salvaged.Data...)
+	if len(salvaged.Data)%2 != 0 {
+		fixed = append(fixed, 0)
+	}
+	fixed = append(fixed, trailing...)
+	binary.LittleEndian.PutUint32(fixed[riffSizePos:], uint32(len(fixed)-8))
+	return fixed, nil
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+func wavBytes(t *testing.T, wav *waveparser.Wav, samples int) []byte {
+	t.Helper()
+
+	if err := wav.SetSamples(make([]float64, samples)); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := wav.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func imaWav(t *testing.T) []byte {
+	return wavBytes(t, waveparser.New(waveparser.WaveFormatIMAADPCM, 1, 8000, 4), 8000)
+}
+
+func repairBytes(t *testing.T, raw []byte, strip bool) []byte {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "input.wav")
+	if err := ioutil.WriteFile(path, raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+	fixed, err := repair(path, strip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fixed
+}
+
+func TestRepairValidIMA(t *testing.T) {
+	if fixed := repairBytes(t, imaWav(t), false); fixed != nil {
+		t.Fatal("expected a valid IMA file to need no repair")
+	}
+}
+
+func TestRepairIMABytesPerSec(t *testing.T) {
+	raw := imaWav(t)
+	expected, err := waveparser.ParseHeader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broken := append([]byte{}, raw...)
+	binary.LittleEndian.PutUint32(broken[bytesPerSecPos:], 8000*4)
+
+	fixed := repairBytes(t, broken, false)
+	if !bytes.Equal(raw, fixed) {
+		hdr, _ := waveparser.ParseHeader(bytes.NewReader(fixed))
+		t.Fatalf("expected header %+v, got %+v", expected, hdr)
+	}
+}
+
+func TestRepairTruncatedIMA(t *testing.T) {
+	raw := imaWav(t)
+	hdr, err := waveparser.ParseHeader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block := int(hdr.RIFFChunkFmt.BytesPerBloc)
+	truncated := raw[:len(raw)-block/2]
+
+	wav, err := waveparser.ParseBytes(repairBytes(t, truncated, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(wav.Header.DataBlockSize) != int(hdr.DataBlockSize)-block {
+		t.Fatalf("expected data size [%d], got [%d]", int(hdr.DataBlockSize)-block, wav.Header.DataBlockSize)
+	}
+	if wav.Header.RIFFChunkFmt.BytesPerSec != hdr.RIFFChunkFmt.BytesPerSec {
+		t.Fatalf("expected bytes/second [%d], got [%d]", hdr.RIFFChunkFmt.BytesPerSec, wav.Header.RIFFChunkFmt.BytesPerSec)
+	}
+}
+
+func TestRepairKeepsTrailingChunks(t *testing.T) {
+	valid := wavBytes(t, waveparser.New(waveparser.WaveFormatPCM, 1, 8000, 16), 100)
+
+	tail := []byte("LIST\x04\x00\x00\x00INFO")
+	withTail := append(append([]byte{}, valid...), tail...)
+	binary.LittleEndian.PutUint32(withTail[riffSizePos:], uint32(len(withTail)-8))
+
+	broken := append([]byte{}, withTail...)
+	binary.LittleEndian.PutUint32(broken[bytesPerSecPos:], 1)
+
+	if fixed := repairBytes(t, broken, false); !bytes.Equal(withTail, fixed) {
+		t.Fatal("expected the chunk after the data chunk kept in place")
+	}
+	if fixed := repairBytes(t, broken, true); !bytes.Equal(valid, fixed) {
+		t.Fatal("expected the chunk after the data chunk stripped")
+	}
+	if fixed := repairBytes(t, withTail, true); !bytes.Equal(valid, fixed) {
+		t.Fatal("expected a stripped file even with nothing to repair")
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+	"github.com/NeowayLabs/waveparser/generate"
+)
+
+func main() {
+	var (
+		output    string
+		signal    string
+		freq      float64
+		to        float64
+		amplitude float64
+		duration  time.Duration
+		seed      int64
+		rate      uint
+		bits      uint
+		channels  uint
+		float     bool
+	)
+
+	flag.StringVar(&output, "o", "", "output wav file")
+	flag.StringVar(&signal, "signal", "sine", "signal to generate: sine, noise, silence or sweep")
+	flag.Float64Var(&freq, "freq", 1000, "sine frequency, or sweep start frequency (Hz)")
+	flag.Float64Var(&to, "to", 20000, "sweep end frequency (Hz)")
+	flag.Float64Var(&amplitude, "amplitude", 0.5, "amplitude relative to full scale (0 to 1)")
+	flag.DurationVar(&duration, "duration", time.Second, "length of the generated audio")
+	flag.Int64Var(&seed, "seed", 1, "noise seed")
+	flag.UintVar(&rate, "rate", 44100, "sample rate")
+	flag.UintVar(&bits, "bits", 16, "bits per sample")
+	flag.UintVar(&channels, "channels", 1, "number of channels")
+	flag.BoolVar(&float, "float", false, "write IEEE float samples instead of PCM")
+	flag.Parse()
+
+	if output == "" {
+		fmt.Printf("usage: %s -o <output wav> [-signal sine|noise|silence|sweep] [options]\n", os.Args[0])
+		flag.PrintDefaults()
+		return
+	}
+
+	audioFormat := waveparser.WaveFormatPCM
+	if float {
+		audioFormat = waveparser.WaveFormatIEEEFloat
+	}
+	format := waveparser.New(audioFormat, uint16(channels), uint32(rate), uint16(bits)).Header.RIFFChunkFmt
+
+	var (
+		wav *waveparser.Wav
+		err error
+	)
+
+	switch signal {
+	case "sine":
+		wav, err = generate.Sine(format, freq, amplitude, duration)
+	case "noise":
+		wav, err = generate.WhiteNoise(format, amplitude, duration, seed)
+	case "silence":
+		wav, err = generate.Silence(format, duration)
+	case "sweep":
+		wav, err = generate.Sweep(format, freq, to, amplitude, duration)
+	default:
+		err = fmt.Errorf("unknown signal[%s]", signal)
+	}
+	abortonerr(err, "generating audio")
+
+	abortonerr(wav.Save(output), "saving [%s]", output)
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+type (
+	// header is the header as encoded by WavHeader.MarshalJSON, the
+	// structure of the .hdr.expected test fixtures, plus the inventory
+	// of every chunk in the file.
+	header struct {
+		waveparser.WavHeader
+		Chunks []chunk
+	}
+
+	chunk struct {
+		ID     string
+		Offset int
+		Size   uint32
+	}
+)
+
+func main() {
+
+	if len(os.Args) < 2 {
+		fmt.Printf("usage: %s <wav file>\n", os.Args[0])
+		return
+	}
+
+	wavpath := os.Args[1]
+	wav, err := waveparser.Load(wavpath)
+	abortonerr(err, "loading [%s]", wavpath)
+
+	raw, err := ioutil.ReadFile(wavpath)
+	abortonerr(err, "reading [%s]", wavpath)
+
+	out, err := json.MarshalIndent(header{wav.Header, inventory(raw)}, "", "    ")
+	abortonerr(err, "encoding [%s] header", wavpath)
+
+	fmt.Println(string(out))
+}
+
+// MarshalJSON adds the chunks to the fields of the header, which
+// otherwise would be the only ones encoded by the promoted
+// WavHeader.MarshalJSON.
+func (h header) MarshalJSON() ([]byte, error) {
+	encoded, err := json.Marshal(h.WavHeader)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	if fields["Chunks"], err = json.Marshal(h.Chunks); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// inventory lists the chunks after the RIFF header, stopping at the
+// first one that doesn't fit in the file.
+func inventory(raw []byte) []chunk {
+	const riffHeaderSize = 12
+
+	chunks := []chunk{}
+	for offset := riffHeaderSize; offset+8 <= len(raw); {
+		size := binary.LittleEndian.Uint32(raw[offset+4:])
+		chunks = append(chunks, chunk{
+			ID:     string(raw[offset : offset+4]),
+			Offset: offset,
+			Size:   size,
+		})
+
+		next := uint64(offset) + 8 + uint64(size) + uint64(size%2)
+		if next > uint64(len(raw)) {
+			break
+		}
+		offset = int(next)
+	}
+	return chunks
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
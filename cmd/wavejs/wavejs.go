@@ -0,0 +1,43 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+// main exposes waveHeader(bytes) to javascript, where bytes is an
+// Uint8Array with (at least) the beginning of a wav file. It returns
+// the parsed header as JSON, or an Error object if parsing fails.
+func main() {
+	js.Global().Set("waveHeader", js.FuncOf(waveHeader))
+	select {}
+}
+
+func waveHeader(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return jserror("usage: waveHeader(Uint8Array)")
+	}
+
+	raw := make([]byte, args[0].Get("length").Int())
+	js.CopyBytesToGo(raw, args[0])
+
+	hdr, err := waveparser.ParseHeader(bytes.NewReader(raw))
+	if err != nil {
+		return jserror(err.Error())
+	}
+
+	encoded, err := json.Marshal(hdr)
+	if err != nil {
+		return jserror(err.Error())
+	}
+	return string(encoded)
+}
+
+func jserror(msg string) interface{} {
+	return js.Global().Get("Error").New(msg)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/NeowayLabs/waveparser"
+	"github.com/NeowayLabs/waveparser/loudness"
+)
+
+func main() {
+	var (
+		mode   string
+		target float64
+		outdir string
+		dryRun bool
+	)
+
+	flag.StringVar(&mode, "mode", "peak", "normalization mode: peak (dBFS) or lufs (integrated loudness)")
+	flag.Float64Var(&target, "target", math.NaN(), "target level, defaults to -1 dBFS for peak and -23 LUFS for lufs")
+	flag.StringVar(&outdir, "outdir", "", "write normalized files into this directory instead of in place")
+	flag.BoolVar(&dryRun, "dry-run", false, "only report current levels and the gain that would be applied")
+	flag.Parse()
+
+	if flag.NArg() == 0 || (mode != "peak" && mode != "lufs") {
+		fmt.Printf("usage: %s [-mode peak|lufs] [-target <level>] [-outdir <dir>] [-dry-run] <wav file>...\n", os.Args[0])
+		return
+	}
+
+	if math.IsNaN(target) {
+		target = -1
+		if mode == "lufs" {
+			target = -23
+		}
+	}
+
+	for _, wavpath := range flag.Args() {
+		wav, err := waveparser.Load(wavpath)
+		abortonerr(err, "loading [%s]", wavpath)
+
+		level, err := measure(wav, mode)
+		abortonerr(err, "measuring [%s]", wavpath)
+
+		if math.IsInf(level, -1) {
+			fmt.Printf("%s: silent, skipping\n", wavpath)
+			continue
+		}
+
+		gain := target - level
+		fmt.Printf("%s: level %.2f, gain %+.2f dB\n", wavpath, level, gain)
+		if dryRun {
+			continue
+		}
+
+		abortonerr(wav.Gain(gain), "normalizing [%s]", wavpath)
+
+		stats, err := wav.Stats()
+		abortonerr(err, "measuring [%s]", wavpath)
+		if stats.Clipped > 0 {
+			fmt.Printf("%s: warning, %d samples clipped\n", wavpath, stats.Clipped)
+		}
+
+		output := wavpath
+		if outdir != "" {
+			output = filepath.Join(outdir, filepath.Base(wavpath))
+		}
+		abortonerr(wav.Save(output), "saving [%s]", output)
+	}
+}
+
+func measure(wav *waveparser.Wav, mode string) (float64, error) {
+	if mode == "lufs" {
+		return loudness.Integrated(wav)
+	}
+	stats, err := wav.Stats()
+	return stats.Peak, err
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+	"github.com/ebitengine/oto/v3"
+)
+
+func main() {
+	var (
+		start    time.Duration
+		duration time.Duration
+	)
+
+	flag.DurationVar(&start, "start", 0, "start playing at this offset (eg: 1m30s)")
+	flag.DurationVar(&duration, "duration", 0, "play only this much audio, 0 plays until the end")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Printf("usage: %s [-start <duration>] [-duration <duration>] <wav file>\n", os.Args[0])
+		return
+	}
+
+	wavpath := flag.Arg(0)
+	wav, err := waveparser.Load(wavpath)
+	abortonerr(err, "loading [%s]", wavpath)
+
+	samples, err := wav.Samples()
+	abortonerr(err, "decoding [%s]", wavpath)
+
+	format := wav.Header.RIFFChunkFmt
+	channels := int(format.NumChannels)
+	samples = window(samples, channels, int(format.SampleRate), start, duration)
+
+	pcm := make([]byte, 4*len(samples))
+	for i, sample := range samples {
+		binary.LittleEndian.PutUint32(pcm[4*i:], math.Float32bits(float32(sample)))
+	}
+
+	ctx, ready, err := oto.NewContext(&oto.NewContextOptions{
+		SampleRate:   int(format.SampleRate),
+		ChannelCount: channels,
+		Format:       oto.FormatFloat32LE,
+	})
+	abortonerr(err, "opening audio device")
+	<-ready
+
+	player := ctx.NewPlayer(bytes.NewReader(pcm))
+	player.Play()
+	for player.IsPlaying() {
+		time.Sleep(10 * time.Millisecond)
+	}
+	abortonerr(player.Close(), "closing player")
+}
+
+// window selects the samples between start and start+duration,
+// keeping whole frames.
+func window(samples []float64, channels, sampleRate int, start, duration time.Duration) []float64 {
+	frames := len(samples) / channels
+	first := int(start.Seconds() * float64(sampleRate))
+	if first > frames {
+		first = frames
+	}
+	last := frames
+	if duration > 0 {
+		last = first + int(duration.Seconds()*float64(sampleRate))
+		if last > frames {
+			last = frames
+		}
+	}
+	return samples[first*channels : last*channels]
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
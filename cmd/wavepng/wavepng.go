@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image/png"
+	"os"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+func main() {
+	var (
+		output string
+		width  int
+		height int
+	)
+
+	flag.StringVar(&output, "o", "", "output png file")
+	flag.IntVar(&width, "width", 800, "image width")
+	flag.IntVar(&height, "height", 120, "image height")
+	flag.Parse()
+
+	if output == "" || flag.NArg() != 1 {
+		fmt.Printf("usage: %s -o <output png> [-width <pixels>] [-height <pixels>] <wav file>\n", os.Args[0])
+		return
+	}
+
+	wavpath := flag.Arg(0)
+	wav, err := waveparser.Load(wavpath)
+	abortonerr(err, "loading [%s]", wavpath)
+
+	img, err := waveparser.RenderWaveform(wav, width, height)
+	abortonerr(err, "rendering [%s]", wavpath)
+
+	out, err := os.Create(output)
+	abortonerr(err, "creating [%s]", output)
+	defer out.Close()
+
+	abortonerr(png.Encode(out, img), "encoding [%s]", output)
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+	"github.com/gordonklaus/portaudio"
+)
+
+const framesPerBuffer = 1024
+
+func main() {
+	var (
+		output   string
+		duration time.Duration
+		rate     uint
+		bits     uint
+		channels uint
+	)
+
+	flag.StringVar(&output, "o", "", "output wav file")
+	flag.DurationVar(&duration, "duration", 0, "stop recording after this long, 0 records until interrupted")
+	flag.UintVar(&rate, "rate", 16000, "sample rate")
+	flag.UintVar(&bits, "bits", 16, "bits per sample (8, 16, 24 or 32)")
+	flag.UintVar(&channels, "channels", 1, "number of channels")
+	flag.Parse()
+
+	if output == "" {
+		fmt.Printf("usage: %s -o <output wav> [-duration <duration>] [-rate <rate>] [-bits <bits>] [-channels <channels>]\n", os.Args[0])
+		return
+	}
+
+	format := waveparser.New(
+		waveparser.WaveFormatPCM,
+		uint16(channels),
+		uint32(rate),
+		uint16(bits),
+	).Header.RIFFChunkFmt
+
+	out, err := os.Create(output)
+	abortonerr(err, "creating [%s]", output)
+	defer out.Close()
+
+	writer, err := waveparser.NewWriter(out, format)
+	abortonerr(err, "writing [%s]", output)
+
+	abortonerr(portaudio.Initialize(), "initializing audio")
+	defer portaudio.Terminate()
+
+	buf := make([]float32, framesPerBuffer*int(channels))
+	stream, err := portaudio.OpenDefaultStream(int(channels), 0, float64(rate), framesPerBuffer, buf)
+	abortonerr(err, "opening default input device")
+	defer stream.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	maxFrames := int(duration.Seconds() * float64(rate))
+	samples := make([]float64, len(buf))
+	frames := 0
+
+	abortonerr(stream.Start(), "starting capture")
+
+record:
+	for maxFrames == 0 || frames < maxFrames {
+		select {
+		case <-interrupt:
+			break record
+		default:
+		}
+
+		abortonerr(stream.Read(), "capturing audio")
+
+		n := framesPerBuffer
+		if maxFrames > 0 && frames+n > maxFrames {
+			n = maxFrames - frames
+		}
+		for i, sample := range buf[:n*int(channels)] {
+			samples[i] = float64(sample)
+		}
+		abortonerr(writer.WriteSamples(samples[:n*int(channels)]), "writing [%s]", output)
+		frames += n
+	}
+
+	abortonerr(stream.Stop(), "stopping capture")
+	abortonerr(writer.Close(), "finishing [%s]", output)
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+var qualities = map[string]waveparser.ResampleQuality{
+	"low":    waveparser.ResampleLinear,
+	"medium": waveparser.ResampleCubic,
+	"high":   waveparser.ResampleSinc,
+}
+
+func main() {
+	var (
+		rate    uint
+		quality string
+		outdir  string
+	)
+
+	flag.UintVar(&rate, "rate", 0, "target sample rate")
+	flag.StringVar(&quality, "quality", "high", "quality preset: low (linear), medium (cubic) or high (windowed sinc)")
+	flag.StringVar(&outdir, "outdir", "", "directory where resampled files are written")
+	flag.Parse()
+
+	q, ok := qualities[quality]
+	if rate == 0 || outdir == "" || !ok || flag.NArg() == 0 {
+		fmt.Printf("usage: %s -rate <rate> -outdir <dir> [-quality low|medium|high] <wav file or glob>...\n", os.Args[0])
+		return
+	}
+
+	var inputs []string
+	for _, pattern := range flag.Args() {
+		matches, err := filepath.Glob(pattern)
+		abortonerr(err, "expanding [%s]", pattern)
+		inputs = append(inputs, matches...)
+	}
+
+	var total time.Duration
+	for _, input := range inputs {
+		wav, err := waveparser.Load(input)
+		abortonerr(err, "loading [%s]", input)
+
+		start := time.Now()
+		resampled, err := waveparser.Resample(wav, uint32(rate), q)
+		abortonerr(err, "resampling [%s]", input)
+		elapsed := time.Since(start)
+		total += elapsed
+
+		output := filepath.Join(outdir, filepath.Base(input))
+		abortonerr(resampled.Save(output), "saving [%s]", output)
+
+		fmt.Printf("%s: %s of audio resampled in %s\n", input, wav.Duration(), elapsed)
+	}
+
+	fmt.Printf("%d files resampled in %s\n", len(inputs), total)
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
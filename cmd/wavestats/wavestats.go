@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+func main() {
+	var csvOutput, md5 bool
+
+	flag.BoolVar(&csvOutput, "csv", false, "write CSV instead of text")
+	flag.BoolVar(&md5, "md5", false, "also print the MD5 of the audio data (as FLAC computes it)")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		fmt.Printf("usage: %s [-csv] [-md5] <wav file>...\n", os.Args[0])
+		return
+	}
+
+	var out *csv.Writer
+	if csvOutput {
+		out = csv.NewWriter(os.Stdout)
+		header := []string{"file", "duration_seconds", "peak_dbfs", "rms_dbfs", "clipped_samples", "silence_percent"}
+		if md5 {
+			header = append(header, "audio_md5")
+		}
+		out.Write(header)
+	}
+
+	for _, wavpath := range flag.Args() {
+		wav, err := waveparser.Load(wavpath)
+		abortonerr(err, "loading [%s]", wavpath)
+
+		stats, err := wav.Stats()
+		abortonerr(err, "analyzing [%s]", wavpath)
+
+		if out != nil {
+			record := []string{
+				wavpath,
+				strconv.FormatFloat(stats.Duration.Seconds(), 'f', 3, 64),
+				strconv.FormatFloat(stats.Peak, 'f', 2, 64),
+				strconv.FormatFloat(stats.RMS, 'f', 2, 64),
+				strconv.Itoa(stats.Clipped),
+				strconv.FormatFloat(stats.Silence, 'f', 2, 64),
+			}
+			if md5 {
+				record = append(record, wav.AudioMD5())
+			}
+			out.Write(record)
+			continue
+		}
+
+		fmt.Printf("=== %s ===\n", wavpath)
+		fmt.Printf("Duration: %s\n", stats.Duration)
+		fmt.Printf("Peak: %.2f dBFS\n", stats.Peak)
+		fmt.Printf("RMS: %.2f dBFS\n", stats.RMS)
+		fmt.Printf("Clipped samples: %d\n", stats.Clipped)
+		fmt.Printf("Silence: %.2f%%\n", stats.Silence)
+		if md5 {
+			fmt.Printf("Audio MD5: %s\n", wav.AudioMD5())
+		}
+	}
+
+	if out != nil {
+		out.Flush()
+		abortonerr(out.Error(), "writing CSV")
+	}
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+type list []string
+
+func (l *list) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *list) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+const bextPrefix = "bext."
+
+var bextFields = map[string]func(b *waveparser.Bext, v string) error{
+	"Description":         func(b *waveparser.Bext, v string) error { b.Description = v; return nil },
+	"Originator":          func(b *waveparser.Bext, v string) error { b.Originator = v; return nil },
+	"OriginatorReference": func(b *waveparser.Bext, v string) error { b.OriginatorReference = v; return nil },
+	"OriginationDate":     func(b *waveparser.Bext, v string) error { b.OriginationDate = v; return nil },
+	"OriginationTime":     func(b *waveparser.Bext, v string) error { b.OriginationTime = v; return nil },
+	"CodingHistory":       func(b *waveparser.Bext, v string) error { b.CodingHistory = v; return nil },
+	"TimeReference": func(b *waveparser.Bext, v string) error {
+		ref, err := strconv.ParseUint(v, 10, 64)
+		b.TimeReference = ref
+		return err
+	},
+	"Version": func(b *waveparser.Bext, v string) error {
+		version, err := strconv.ParseUint(v, 10, 16)
+		b.Version = uint16(version)
+		return err
+	},
+}
+
+func main() {
+	var (
+		output string
+		sets   list
+		dels   list
+	)
+
+	flag.StringVar(&output, "o", "", "output wav file (defaults to editing the input in place)")
+	flag.Var(&sets, "set", "set a field: INFO id (eg: INAM=title) or bext.<Field>=value, repeatable")
+	flag.Var(&dels, "delete", "delete a field: INFO id, bext.<Field> or bext for the whole chunk, repeatable")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Printf("usage: %s [-set <key>=<value>]... [-delete <key>]... [-o <output wav>] <wav file>\n", os.Args[0])
+		return
+	}
+
+	wavpath := flag.Arg(0)
+	wav, err := waveparser.Load(wavpath)
+	abortonerr(err, "loading [%s]", wavpath)
+
+	info, err := wav.Info()
+	abortonerr(err, "reading INFO from [%s]", wavpath)
+
+	bext, err := wav.Bext()
+	abortonerr(err, "reading bext from [%s]", wavpath)
+
+	if len(sets) == 0 && len(dels) == 0 {
+		cart, err := wav.Cart()
+		abortonerr(err, "reading cart from [%s]", wavpath)
+		printTags(info, bext, cart)
+		return
+	}
+
+	for _, del := range dels {
+		switch {
+		case del == "bext":
+			bext = nil
+		case strings.HasPrefix(del, bextPrefix):
+			if bext != nil {
+				field := strings.TrimPrefix(del, bextPrefix)
+				abortonerr(setBext(bext, field, zeroValue(field)), "deleting [%s]", del)
+			}
+		default:
+			delete(info, del)
+		}
+	}
+
+	for _, set := range sets {
+		kv := strings.SplitN(set, "=", 2)
+		if len(kv) != 2 {
+			abortonerr(fmt.Errorf("expected <key>=<value>"), "parsing [%s]", set)
+		}
+		key, value := kv[0], kv[1]
+
+		if strings.HasPrefix(key, bextPrefix) {
+			if bext == nil {
+				bext = &waveparser.Bext{}
+			}
+			abortonerr(setBext(bext, strings.TrimPrefix(key, bextPrefix), value), "setting [%s]", key)
+			continue
+		}
+
+		if len(key) != 4 {
+			abortonerr(fmt.Errorf("INFO ids have four characters"), "setting [%s]", key)
+		}
+		info[key] = value
+	}
+
+	wav.SetInfo(info)
+	wav.SetBext(bext)
+
+	if output == "" {
+		output = wavpath
+	}
+	abortonerr(wav.Save(output), "saving [%s]", output)
+}
+
+func setBext(b *waveparser.Bext, field string, value string) error {
+	set, ok := bextFields[field]
+	if !ok {
+		return fmt.Errorf("unknown bext field[%s]", field)
+	}
+	return set(b, value)
+}
+
+func zeroValue(field string) string {
+	if field == "TimeReference" || field == "Version" {
+		return "0"
+	}
+	return ""
+}
+
+func printTags(info waveparser.Info, bext *waveparser.Bext, cart *waveparser.Cart) {
+	ids := make([]string, 0, len(info))
+	for id := range info {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	fmt.Println("=== INFO ===")
+	for _, id := range ids {
+		fmt.Printf("%s: %s\n", id, info[id])
+	}
+
+	if bext != nil {
+		printBext(bext)
+	}
+	if cart != nil {
+		printCart(cart)
+	}
+}
+
+func printBext(bext *waveparser.Bext) {
+	fmt.Println("=== bext ===")
+	fmt.Printf("Description: %s\n", bext.Description)
+	fmt.Printf("Originator: %s\n", bext.Originator)
+	fmt.Printf("OriginatorReference: %s\n", bext.OriginatorReference)
+	fmt.Printf("OriginationDate: %s\n", bext.OriginationDate)
+	fmt.Printf("OriginationTime: %s\n", bext.OriginationTime)
+	fmt.Printf("TimeReference: %d\n", bext.TimeReference)
+	fmt.Printf("Version: %d\n", bext.Version)
+	fmt.Printf("CodingHistory: %s\n", bext.CodingHistory)
+}
+
+func printCart(cart *waveparser.Cart) {
+	fmt.Println("=== cart ===")
+	fmt.Printf("Version: %s\n", cart.Version)
+	fmt.Printf("Title: %s\n", cart.Title)
+	fmt.Printf("Artist: %s\n", cart.Artist)
+	fmt.Printf("CutID: %s\n", cart.CutID)
+	fmt.Printf("ClientID: %s\n", cart.ClientID)
+	fmt.Printf("Category: %s\n", cart.Category)
+	fmt.Printf("Classification: %s\n", cart.Classification)
+	fmt.Printf("OutCue: %s\n", cart.OutCue)
+	fmt.Printf("Start: %s %s\n", cart.StartDate, cart.StartTime)
+	fmt.Printf("End: %s %s\n", cart.EndDate, cart.EndTime)
+	fmt.Printf("ProducerApp: %s %s\n", cart.ProducerAppID, cart.ProducerAppVersion)
+	fmt.Printf("UserDef: %s\n", cart.UserDef)
+	fmt.Printf("LevelReference: %d\n", cart.LevelReference)
+	for _, timer := range cart.PostTimers {
+		fmt.Printf("Timer %s: %d\n", timer.Usage, timer.Value)
+	}
+	fmt.Printf("URL: %s\n", cart.URL)
+	fmt.Printf("TagText: %s\n", cart.TagText)
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+func main() {
+	var (
+		output   string
+		start    time.Duration
+		end      time.Duration
+		duration time.Duration
+	)
+
+	flag.StringVar(&output, "o", "", "output wav file")
+	flag.DurationVar(&start, "start", 0, "start of the kept range (eg: 1m30s)")
+	flag.DurationVar(&end, "end", 0, "end of the kept range")
+	flag.DurationVar(&duration, "duration", 0, "length of the kept range, alternative to -end")
+	flag.Parse()
+
+	if output == "" || flag.NArg() != 1 || (end == 0 && duration == 0) {
+		fmt.Printf("usage: %s -o <output wav> [-start <duration>] -end <duration>|-duration <duration> <wav file>\n", os.Args[0])
+		return
+	}
+
+	if end == 0 {
+		end = start + duration
+	}
+
+	wavpath := flag.Arg(0)
+	wav, err := waveparser.Load(wavpath)
+	abortonerr(err, "loading [%s]", wavpath)
+
+	trimmed, err := wav.Slice(start, end)
+	abortonerr(err, "trimming [%s]", wavpath)
+
+	abortonerr(trimmed.Save(output), "saving [%s]", output)
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+type entry struct {
+	path     string
+	duration time.Duration
+	format   waveparser.RiffChunkFmt
+	size     int64
+}
+
+func main() {
+	var csvOutput bool
+
+	flag.BoolVar(&csvOutput, "csv", false, "write CSV instead of a table")
+	flag.Usage = func() {
+		fmt.Printf("usage: %s [-csv] [dir or wav file]...\n", os.Args[0])
+	}
+	flag.Parse()
+
+	roots := flag.Args()
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+
+	var entries []entry
+	for _, root := range roots {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.EqualFold(filepath.Ext(path), ".wav") {
+				return nil
+			}
+
+			e, err := probe(path, info.Size())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "skipping [%s]: %s\n", path, err)
+				return nil
+			}
+			entries = append(entries, e)
+			return nil
+		})
+		abortonerr(err, "listing [%s]", root)
+	}
+
+	if csvOutput {
+		out := csv.NewWriter(os.Stdout)
+		out.Write([]string{"file", "duration_seconds", "format", "sample_rate", "channels", "bits_per_sample", "size_bytes"})
+		for _, e := range entries {
+			out.Write([]string{
+				e.path,
+				strconv.FormatFloat(e.duration.Seconds(), 'f', 3, 64),
+				e.format.AudioFormat.Name(),
+				strconv.FormatUint(uint64(e.format.SampleRate), 10),
+				strconv.FormatUint(uint64(e.format.NumChannels), 10),
+				strconv.FormatUint(uint64(e.format.BitsPerSample), 10),
+				strconv.FormatInt(e.size, 10),
+			})
+		}
+		out.Flush()
+		abortonerr(out.Error(), "writing CSV")
+		return
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(table, "FILE\tDURATION\tFORMAT\tRATE\tCHANNELS\tBITS\tSIZE")
+	for _, e := range entries {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%d\t%d\t%d\t%d\n",
+			e.path,
+			e.duration.Round(time.Millisecond),
+			e.format.AudioFormat.Name(),
+			e.format.SampleRate,
+			e.format.NumChannels,
+			e.format.BitsPerSample,
+			e.size,
+		)
+	}
+	abortonerr(table.Flush(), "writing table")
+}
+
+// probe reads only the header of the file at path. The sample length
+// of the fact chunk is preferred for the duration, as ProbeDuration
+// does, since the bytes/second of compressed formats is often an
+// approximation.
+func probe(path string, size int64) (entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return entry{}, err
+	}
+	defer f.Close()
+
+	var fact []byte
+	hdr, err := waveparser.ParseWithVisitor(f, func(id [4]byte, size uint32, chunk io.Reader) error {
+		if string(id[:]) != "fact" {
+			return nil
+		}
+		fact, err = ioutil.ReadAll(chunk)
+		return err
+	})
+	if err != nil {
+		return entry{}, err
+	}
+
+	format := hdr.RIFFChunkFmt
+	var seconds float64
+	switch {
+	case len(fact) >= 4 && format.SampleRate > 0:
+		seconds = float64(binary.LittleEndian.Uint32(fact)) / float64(format.SampleRate)
+	case format.BytesPerSec > 0:
+		seconds = float64(hdr.DataBlockSize) / float64(format.BytesPerSec)
+	}
+	duration := time.Duration(seconds * float64(time.Second))
+	return entry{path: path, duration: duration, format: format, size: size}, nil
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+type report struct {
+	File     string
+	Findings []waveparser.Finding
+}
+
+func main() {
+	var jsonOutput, strict bool
+
+	flag.BoolVar(&jsonOutput, "json", false, "write the findings as JSON")
+	flag.BoolVar(&strict, "strict", false, "fail on warnings too")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		fmt.Printf("usage: %s [-json] [-strict] <wav file or dir>...\n", os.Args[0])
+		return
+	}
+
+	var files []string
+	for _, arg := range flag.Args() {
+		found, err := wavFiles(arg)
+		abortonerr(err, "listing [%s]", arg)
+		files = append(files, found...)
+	}
+
+	failed := false
+	reports := make([]report, 0, len(files))
+	for _, path := range files {
+		data, err := ioutil.ReadFile(path)
+		abortonerr(err, "reading [%s]", path)
+
+		findings := waveparser.Validate(data)
+		if findings == nil {
+			findings = []waveparser.Finding{}
+		}
+		for _, f := range findings {
+			if f.Severity == waveparser.SeverityError || strict {
+				failed = true
+			}
+		}
+		reports = append(reports, report{File: path, Findings: findings})
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		abortonerr(enc.Encode(reports), "writing JSON")
+	} else {
+		for _, r := range reports {
+			if len(r.Findings) == 0 {
+				fmt.Printf("%s: ok\n", r.File)
+			}
+			for _, f := range r.Findings {
+				fmt.Printf("%s:%d: %s %s: %s\n", r.File, f.Offset, f.Severity, f.Code, f.Message)
+			}
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// wavFiles returns path, or the .wav files under it when it is a
+// directory.
+func wavFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.EqualFold(filepath.Ext(file), ".wav") {
+			files = append(files, file)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func abortonerr(err error, f string, args ...interface{}) {
+	if err == nil {
+		return
+	}
+
+	panic(fmt.Sprintf("error: [%s] %s", err, fmt.Sprintf(f, args...)))
+}
@@ -0,0 +1,162 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type (
+	// CompareOptions selects what Compare checks besides the header.
+	CompareOptions struct {
+		Samples   bool    // also compare the decoded samples
+		Tolerance float64 // max absolute difference between samples
+
+		// MaxLag aligns the samples with Align before comparing them,
+		// for recordings shifted by capture latency.
+		MaxLag time.Duration
+	}
+
+	// Difference is a field that differs between two wavs, A and B
+	// are the values on each of them.
+	Difference struct {
+		Field string
+		A     interface{}
+		B     interface{}
+	}
+
+	// DiffReport lists everything that differs between two wavs.
+	DiffReport struct {
+		Differences []Difference
+
+		// Filled when comparing samples, FirstSampleDiff is -1 when
+		// every sample is within the tolerance.
+		DifferentSamples int
+		FirstSampleDiff  int
+		MaxSampleDiff    float64
+
+		// Offset is the shift of B found by aligning the samples.
+		Offset time.Duration
+	}
+)
+
+// Compare compares the headers of a and b and, if asked, their samples.
+func Compare(a, b *Wav, opts CompareOptions) DiffReport {
+	report := DiffReport{FirstSampleDiff: -1}
+	diff := func(field string, va, vb interface{}) {
+		if va != vb {
+			report.Differences = append(report.Differences, Difference{
+				Field: field,
+				A:     va,
+				B:     vb,
+			})
+		}
+	}
+
+	h1, h2 := a.Header, b.Header
+	diff("RIFF Ident", string(h1.RIFFHdr.Ident[:]), string(h2.RIFFHdr.Ident[:]))
+	diff("ChunkSize", h1.RIFFHdr.ChunkSize, h2.RIFFHdr.ChunkSize)
+	diff("FileType", string(h1.RIFFHdr.FileType[:]), string(h2.RIFFHdr.FileType[:]))
+
+	cf1, cf2 := h1.RIFFChunkFmt, h2.RIFFChunkFmt
+	diff("Length Of Header", cf1.LengthOfHeader, cf2.LengthOfHeader)
+	diff("Audio Format", cf1.AudioFormat, cf2.AudioFormat)
+	diff("Number Of Channels", cf1.NumChannels, cf2.NumChannels)
+	diff("Samplerate", cf1.SampleRate, cf2.SampleRate)
+	diff("Bytes Per Sec", cf1.BytesPerSec, cf2.BytesPerSec)
+	diff("Bytes Per Block", cf1.BytesPerBloc, cf2.BytesPerBloc)
+	diff("Bits Per Sample", cf1.BitsPerSample, cf2.BitsPerSample)
+
+	diff("First Sample Position", h1.FirstSamplePos, h2.FirstSamplePos)
+	diff("Data Block Size", h1.DataBlockSize, h2.DataBlockSize)
+
+	if opts.Samples {
+		compareSamples(&report, a, b, opts)
+	}
+	return report
+}
+
+func compareSamples(report *DiffReport, a, b *Wav, opts CompareOptions) {
+	s1, err := a.Samples()
+	if err != nil {
+		report.Differences = append(report.Differences, Difference{Field: "Samples", A: err, B: nil})
+		return
+	}
+	s2, err := b.Samples()
+	if err != nil {
+		report.Differences = append(report.Differences, Difference{Field: "Samples", A: nil, B: err})
+		return
+	}
+
+	if opts.MaxLag > 0 {
+		s1, s2 = alignForCompare(report, a, b, s1, s2, opts.MaxLag)
+	}
+
+	if len(s1) != len(s2) {
+		report.Differences = append(report.Differences, Difference{
+			Field: "Sample Count",
+			A:     len(s1),
+			B:     len(s2),
+		})
+	}
+
+	n := len(s1)
+	if len(s2) < n {
+		n = len(s2)
+	}
+	for i := 0; i < n; i++ {
+		d := math.Abs(s1[i] - s2[i])
+		if d > report.MaxSampleDiff {
+			report.MaxSampleDiff = d
+		}
+		if d > opts.Tolerance {
+			if report.FirstSampleDiff < 0 {
+				report.FirstSampleDiff = i
+			}
+			report.DifferentSamples++
+		}
+	}
+}
+
+// alignForCompare drops the samples of the recording that starts
+// earlier until both line up. Wavs that can't be aligned are compared
+// as they are, the failure listed as a difference.
+func alignForCompare(report *DiffReport, a, b *Wav, s1, s2 []float64, maxLag time.Duration) ([]float64, []float64) {
+	fa, fb := a.Header.RIFFChunkFmt, b.Header.RIFFChunkFmt
+	if fa.SampleRate != fb.SampleRate || fa.SampleRate == 0 {
+		report.Differences = append(report.Differences, Difference{
+			Field: "Alignment",
+			A:     fa.SampleRate,
+			B:     fb.SampleRate,
+		})
+		return s1, s2
+	}
+
+	lag, _, err := alignSamples(s1, s2, int(fa.NumChannels), int(fb.NumChannels), maxLagFrames(maxLag, fa.SampleRate))
+	if err != nil {
+		report.Differences = append(report.Differences, Difference{Field: "Alignment", A: err, B: nil})
+		return s1, s2
+	}
+
+	report.Offset = framesDuration(lag, fa.SampleRate)
+	if lag > 0 {
+		return s1, dropFrames(s2, lag, int(fb.NumChannels))
+	}
+	return dropFrames(s1, -lag, int(fa.NumChannels)), s2
+}
+
+func dropFrames(samples []float64, frames, channels int) []float64 {
+	if frames*channels > len(samples) {
+		return nil
+	}
+	return samples[frames*channels:]
+}
+
+// Equal reports whether nothing differs.
+func (r DiffReport) Equal() bool {
+	return len(r.Differences) == 0 && r.DifferentSamples == 0
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: [%v] != [%v]", d.Field, d.A, d.B)
+}
@@ -0,0 +1,44 @@
+package waveparser
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	a := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, a.SetSamples([]float64{0, 0.5, -0.5, 0.25}))
+
+	b := New(WaveFormatPCM, 1, 16000, 16)
+	assertNoError(t, b.SetSamples([]float64{0, 0.5, -0.25, 0.25}))
+
+	report := Compare(a, a, CompareOptions{Samples: true})
+	if !report.Equal() {
+		t.Fatalf("expected no differences comparing a wav to itself: %+v", report)
+	}
+
+	report = Compare(a, b, CompareOptions{})
+	if report.Equal() {
+		t.Fatal("expected differences")
+	}
+	fields := map[string]bool{}
+	for _, diff := range report.Differences {
+		fields[diff.Field] = true
+	}
+	if len(fields) != 2 || !fields["Samplerate"] || !fields["Bytes Per Sec"] {
+		t.Fatalf("unexpected differences: %v", report.Differences)
+	}
+	if report.DifferentSamples != 0 {
+		t.Fatal("samples must not be compared unless asked")
+	}
+
+	report = Compare(a, b, CompareOptions{Samples: true, Tolerance: 0.1})
+	if report.DifferentSamples != 1 || report.FirstSampleDiff != 2 {
+		t.Fatalf("expected sample[2] to differ: %+v", report)
+	}
+	if report.MaxSampleDiff < 0.24 || report.MaxSampleDiff > 0.26 {
+		t.Fatalf("unexpected max sample difference[%f]", report.MaxSampleDiff)
+	}
+
+	report = Compare(a, b, CompareOptions{Samples: true, Tolerance: 0.3})
+	if report.DifferentSamples != 0 || report.FirstSampleDiff != -1 {
+		t.Fatalf("expected samples within tolerance: %+v", report)
+	}
+}
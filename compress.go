@@ -0,0 +1,85 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// limiterRelease is how fast Limit recovers after reducing the gain.
+const limiterRelease = 50 * time.Millisecond
+
+// Compress reduces the level of the audio above the threshold (dBFS)
+// by ratio: with ratio 4 a level 8 dB above the threshold comes out 2
+// dB above it. The level follows rises over attack and falls over
+// release. Channels are compressed together.
+func (w *Wav) Compress(threshold, ratio float64, attack, release time.Duration) error {
+	if ratio < 1 {
+		return fmt.Errorf("invalid compression ratio[%f]", ratio)
+	}
+
+	return w.dynamics(func(rate float64) func(level float64) float64 {
+		rise, fall := smoothing(attack, rate), smoothing(release, rate)
+		var envelope float64
+		return func(level float64) float64 {
+			if level > envelope {
+				envelope += (level - envelope) * rise
+			} else {
+				envelope += (level - envelope) * fall
+			}
+
+			db := dBFS(envelope)
+			if db <= threshold {
+				return 1
+			}
+			reduction := (db - threshold) * (1 - 1/ratio)
+			return math.Pow(10, -reduction/20)
+		}
+	})
+}
+
+// Limit keeps every sample under the ceiling (dBFS), reducing the gain
+// instantly on peaks and recovering smoothly after them.
+func (w *Wav) Limit(ceiling float64) error {
+	max := math.Pow(10, ceiling/20)
+	return w.dynamics(func(rate float64) func(level float64) float64 {
+		recover := smoothing(limiterRelease, rate)
+		gain := 1.0
+		return func(level float64) float64 {
+			gain += (1 - gain) * recover
+			if level*gain > max {
+				gain = max / level
+			}
+			return gain
+		}
+	})
+}
+
+// dynamics applies to each frame the gain computed from its level by
+// the processor made for the sample rate.
+func (w *Wav) dynamics(processor func(rate float64) func(level float64) float64) error {
+	format := w.Header.RIFFChunkFmt
+	channels := int(format.NumChannels)
+	if channels == 0 || format.SampleRate == 0 {
+		return fmt.Errorf(
+			"can't process dynamics of channels[%d] samplerate[%d]",
+			format.NumChannels,
+			format.SampleRate,
+		)
+	}
+
+	samples, err := w.Samples()
+	if err != nil {
+		return err
+	}
+
+	gain := processor(float64(format.SampleRate))
+	for i := 0; i+channels <= len(samples); i += channels {
+		frame := samples[i : i+channels]
+		g := gain(frameLevel(frame))
+		for c := range frame {
+			frame[c] *= g
+		}
+	}
+	return w.SetSamples(samples)
+}
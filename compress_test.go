@@ -0,0 +1,56 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCompress(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 1, 8000, 64)
+
+	// a second at -20 dBFS then a second at -4 dBFS
+	samples := make([]float64, 2*8000)
+	for i := range samples {
+		amplitude := math.Pow(10, -20.0/20)
+		if i >= 8000 {
+			amplitude = math.Pow(10, -4.0/20)
+		}
+		samples[i] = amplitude * math.Sin(2*math.Pi*100*float64(i)/8000)
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	assertNoError(t, wav.Compress(-12, 4, 5*time.Millisecond, 200*time.Millisecond))
+	compressed, err := wav.Samples()
+	assertNoError(t, err)
+
+	// below the threshold nothing changes, above it 8 dB become 2
+	if p := dBFS(frameLevel(compressed[4000:8000])); math.Abs(p+20) > 0.1 {
+		t.Fatalf("expected quiet part untouched at -20 dBFS, got %f", p)
+	}
+	if p := dBFS(frameLevel(compressed[12000:])); math.Abs(p+10) > 0.5 {
+		t.Fatalf("expected loud part compressed to -10 dBFS, got %f", p)
+	}
+
+	assertError(t, wav.Compress(-12, 0.5, 0, 0))
+}
+
+func TestLimit(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 8000, 16)
+	samples := make([]float64, 2*8000)
+	for i := range samples {
+		samples[i] = 0.99 * math.Sin(2*math.Pi*100*float64(i/2)/8000)
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	assertNoError(t, wav.Limit(-6))
+	limited, err := wav.Samples()
+	assertNoError(t, err)
+
+	if p := dBFS(frameLevel(limited)); p > -6+0.01 {
+		t.Fatalf("expected samples under -6 dBFS, peak %f", p)
+	}
+	if p := dBFS(frameLevel(limited[8000:])); p < -6.5 {
+		t.Fatalf("expected peaks near the ceiling, got %f", p)
+	}
+}
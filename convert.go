@@ -0,0 +1,114 @@
+package waveparser
+
+import "fmt"
+
+// SameFormat reports whether a and b describe the same sample encoding,
+// channel count and sample rate.
+func SameFormat(a, b RiffChunkFmt) bool {
+	return a.AudioFormat == b.AudioFormat &&
+		a.NumChannels == b.NumChannels &&
+		a.SampleRate == b.SampleRate &&
+		a.BitsPerSample == b.BitsPerSample
+}
+
+// Convert returns a copy of w re-encoded with the sample encoding and
+// channel count of the given format, the options (eg: WithDither) are
+// used for encoding. Sample rate conversion is not supported, the rates
+// must match (see Resample). Chunks are kept.
+func Convert(w *Wav, to RiffChunkFmt, opts ...SampleOption) (*Wav, error) {
+	converted, _, err := convert(w, to, opts)
+	return converted, err
+}
+
+// ConvertReport converts like Convert, reporting the error introduced
+// by encoding the audio in the new format.
+func ConvertReport(w *Wav, to RiffChunkFmt, opts ...SampleOption) (*Wav, QuantizationReport, error) {
+	converted, samples, err := convert(w, to, opts)
+	if err != nil {
+		return nil, QuantizationReport{}, err
+	}
+	report, err := converted.quantizationReport(samples)
+	if err != nil {
+		return nil, QuantizationReport{}, err
+	}
+	return converted, report, nil
+}
+
+// convert returns the converted copy of w with the samples it encoded.
+func convert(w *Wav, to RiffChunkFmt, opts []SampleOption) (*Wav, []float64, error) {
+	from := w.Header.RIFFChunkFmt
+	if from.SampleRate != to.SampleRate {
+		return nil, nil, fmt.Errorf(
+			"can't convert sample rate[%d] to [%d]",
+			from.SampleRate,
+			to.SampleRate,
+		)
+	}
+
+	samples, err := w.Samples()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	samples, err = remix(samples, int(from.NumChannels), int(to.NumChannels))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	converted := New(to.AudioFormat, to.NumChannels, to.SampleRate, to.BitsPerSample)
+	if err := converted.SetSamples(samples, opts...); err != nil {
+		return nil, nil, err
+	}
+
+	if err := carryMetadata(converted, w, 0, 1); err != nil {
+		return nil, nil, err
+	}
+	return converted, samples, nil
+}
+
+// convertResampled converts w like Convert, resampling it first with
+// ResampleSinc when the sample rates differ. Resampling is done on 64
+// bits floats so the audio is quantized only once.
+func convertResampled(w *Wav, to RiffChunkFmt) (*Wav, error) {
+	from := w.Header.RIFFChunkFmt
+	if from.SampleRate != to.SampleRate {
+		float, err := Convert(w, New(WaveFormatIEEEFloat, from.NumChannels, from.SampleRate, 64).Header.RIFFChunkFmt)
+		if err != nil {
+			return nil, err
+		}
+		if w, err = Resample(float, to.SampleRate, ResampleSinc); err != nil {
+			return nil, err
+		}
+	}
+	return Convert(w, to)
+}
+
+// remix converts interleaved samples between channel counts, averaging
+// down to mono or duplicating mono to every output channel.
+func remix(samples []float64, from, to int) ([]float64, error) {
+	switch {
+	case from == to:
+		return samples, nil
+	case from <= 0 || to <= 0:
+		return nil, fmt.Errorf("invalid channel count: from[%d] to[%d]", from, to)
+	case to == 1:
+		mono := make([]float64, len(samples)/from)
+		for i := range mono {
+			var sum float64
+			for _, s := range samples[i*from : (i+1)*from] {
+				sum += s
+			}
+			mono[i] = sum / float64(from)
+		}
+		return mono, nil
+	case from == 1:
+		multi := make([]float64, len(samples)*to)
+		for i, s := range samples {
+			for c := 0; c < to; c++ {
+				multi[i*to+c] = s
+			}
+		}
+		return multi, nil
+	}
+	return nil, fmt.Errorf("can't remix [%d] channels into [%d]", from, to)
+}
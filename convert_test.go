@@ -0,0 +1,28 @@
+package waveparser
+
+import "testing"
+
+func TestConvertEncodingAndChannels(t *testing.T) {
+	stereo := New(WaveFormatPCM, 2, 8000, 16)
+	assertNoError(t, stereo.SetSamples([]float64{0.5, 0.25, -0.5, -0.25}))
+
+	mono, err := Convert(stereo, New(WaveFormatIEEEFloat, 1, 8000, 32).Header.RIFFChunkFmt)
+	assertNoError(t, err)
+
+	samples, err := mono.Samples()
+	assertNoError(t, err)
+	assertSamplesClose(t, []float64{0.375, -0.375}, samples, 1e-6)
+
+	back, err := Convert(mono, stereo.Header.RIFFChunkFmt)
+	assertNoError(t, err)
+
+	samples, err = back.Samples()
+	assertNoError(t, err)
+	assertSamplesClose(t, []float64{0.375, 0.375, -0.375, -0.375}, samples, 1e-4)
+}
+
+func TestConvertRejectsSampleRateChange(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	_, err := Convert(wav, New(WaveFormatPCM, 1, 16000, 16).Header.RIFFChunkFmt)
+	assertError(t, err)
+}
@@ -0,0 +1,69 @@
+package waveparser
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type (
+	// CSVOptions configures WriteCSV.
+	CSVOptions struct {
+		Comma     rune // field separator, ',' when unset
+		NoHeader  bool // omit the header row
+		Precision int  // decimal places of the samples, the shortest exact representation when 0
+	}
+)
+
+// WriteCSV writes the audio as CSV, a row per frame with its time in
+// seconds and a column per channel with the samples normalized as in
+// Samples. The header row names the columns time, ch1, ch2...
+func (w *Wav) WriteCSV(out io.Writer, opts CSVOptions) error {
+	format := w.Header.RIFFChunkFmt
+	if format.NumChannels == 0 || format.SampleRate == 0 {
+		return fmt.Errorf(
+			"can't write CSV: channels[%d] samplerate[%d]",
+			format.NumChannels,
+			format.SampleRate,
+		)
+	}
+	samples, err := w.Samples()
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(out)
+	if opts.Comma != 0 {
+		writer.Comma = opts.Comma
+	}
+	precision := opts.Precision
+	if precision == 0 {
+		precision = -1
+	}
+
+	channels := int(format.NumChannels)
+	row := make([]string, channels+1)
+	if !opts.NoHeader {
+		row[0] = "time"
+		for c := 1; c <= channels; c++ {
+			row[c] = "ch" + strconv.Itoa(c)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	for frame := 0; (frame+1)*channels <= len(samples); frame++ {
+		row[0] = strconv.FormatFloat(float64(frame)/float64(format.SampleRate), 'f', 6, 64)
+		for c, s := range samples[frame*channels : (frame+1)*channels] {
+			row[c+1] = strconv.FormatFloat(s, 'f', precision, 64)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
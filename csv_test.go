@@ -0,0 +1,41 @@
+package waveparser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 2, 4, 32)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5, 0.25, -0.25}))
+
+	type tcase struct {
+		name     string
+		opts     CSVOptions
+		expected string
+	}
+
+	tcases := []tcase{
+		{
+			name:     "Default",
+			expected: "time,ch1,ch2\n0.000000,0.5,-0.5\n0.250000,0.25,-0.25\n",
+		},
+		{
+			name:     "Options",
+			opts:     CSVOptions{Comma: ';', NoHeader: true, Precision: 2},
+			expected: "0.000000;0.50;-0.50\n0.250000;0.25;-0.25\n",
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			assertNoError(t, wav.WriteCSV(buf, tc.opts))
+			if buf.String() != tc.expected {
+				t.Fatalf("expected:\n%s\ngot:\n%s", tc.expected, buf.String())
+			}
+		})
+	}
+
+	assertError(t, New(WaveFormatPCM, 0, 8000, 16).WriteCSV(&bytes.Buffer{}, CSVOptions{}))
+}
@@ -0,0 +1,158 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+type (
+	// CuePoint is an entry of the cue chunk, marking a position on the
+	// audio data.
+	CuePoint struct {
+		ID           uint32
+		Position     uint32 // sample position on the play order
+		DataChunkID  [4]byte
+		ChunkStart   uint32
+		BlockStart   uint32
+		SampleOffset uint32 // frame of the cue on the data chunk
+	}
+)
+
+// CuePoints parses the cue chunk, returning nil when the file has none.
+func (w *Wav) CuePoints() ([]CuePoint, error) {
+	chunk := w.Chunk("cue ")
+	if chunk == nil {
+		return nil, nil
+	}
+
+	r := bytes.NewReader(chunk.Data)
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, fmt.Errorf("error parsing cue chunk: %s", err)
+	}
+	if uint64(count)*24 > uint64(r.Len()) {
+		return nil, fmt.Errorf("cue chunk with [%d] points has only [%d] bytes", count, r.Len())
+	}
+
+	points := make([]CuePoint, count)
+	if err := binary.Read(r, binary.LittleEndian, points); err != nil {
+		return nil, fmt.Errorf("error parsing cue points: %s", err)
+	}
+	return points, nil
+}
+
+// SetCuePoints replaces the cue chunk, removing it when there are no
+// points.
+func (w *Wav) SetCuePoints(points []CuePoint) {
+	if len(points) == 0 {
+		w.RemoveChunk("cue ")
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.LittleEndian, uint32(len(points)))
+	binary.Write(buf, binary.LittleEndian, points)
+	w.SetChunk("cue ", buf.Bytes())
+}
+
+// AddCue adds a cue point at the given offset of the audio, labeled
+// when label isn't empty, returning its id.
+func (w *Wav) AddCue(label string, at time.Duration) (uint32, error) {
+	frame, err := w.cueFrame(at)
+	if err != nil {
+		return 0, err
+	}
+	points, err := w.CuePoints()
+	if err != nil {
+		return 0, err
+	}
+	list, err := w.adtl()
+	if err != nil {
+		return 0, err
+	}
+
+	var id uint32
+	for _, point := range points {
+		if point.ID > id {
+			id = point.ID
+		}
+	}
+	id++
+
+	w.SetCuePoints(append(points, CuePoint{
+		ID:           id,
+		Position:     frame,
+		DataChunkID:  chunkID("data"),
+		SampleOffset: frame,
+	}))
+	if label != "" {
+		list.labels[id] = label
+		w.setAdtl(list)
+	}
+	w.Header.RIFFHdr.ChunkSize = riffChunkSize(w.Chunks, w.Header.DataBlockSize) + headerExtraSize(&w.Header)
+	return id, nil
+}
+
+// RemoveCue removes the cue point with the given id with its labels,
+// notes and the smpl loops attached to it.
+func (w *Wav) RemoveCue(id uint32) error {
+	points, err := w.CuePoints()
+	if err != nil {
+		return err
+	}
+	list, err := w.adtl()
+	if err != nil {
+		return err
+	}
+	sampler, err := w.Sampler()
+	if err != nil {
+		return err
+	}
+
+	kept := points[:0]
+	for _, point := range points {
+		if point.ID != id {
+			kept = append(kept, point)
+		}
+	}
+	if len(kept) == len(points) {
+		return fmt.Errorf("cue point[%d] not found", id)
+	}
+
+	w.SetCuePoints(kept)
+	list.remove(id)
+	w.setAdtl(list)
+	if sampler != nil {
+		loops := sampler.Loops[:0]
+		for _, loop := range sampler.Loops {
+			if loop.CuePointID != id {
+				loops = append(loops, loop)
+			}
+		}
+		sampler.Loops = loops
+		w.SetSampler(sampler)
+	}
+	w.Header.RIFFHdr.ChunkSize = riffChunkSize(w.Chunks, w.Header.DataBlockSize) + headerExtraSize(&w.Header)
+	return nil
+}
+
+// cueFrame converts an offset of the audio to the nearest frame.
+func (w *Wav) cueFrame(at time.Duration) (uint32, error) {
+	format := w.Header.RIFFChunkFmt
+	if format.BytesPerBloc == 0 || format.SampleRate == 0 {
+		return 0, fmt.Errorf(
+			"can't compute offsets: bytes/block[%d] samplerate[%d]",
+			format.BytesPerBloc,
+			format.SampleRate,
+		)
+	}
+
+	frame := math.Round(at.Seconds() * float64(format.SampleRate))
+	if frame < 0 || frame > float64(len(w.Data)/int(format.BytesPerBloc)) {
+		return 0, fmt.Errorf("cue offset[%s] outside the audio", at)
+	}
+	return uint32(frame), nil
+}
@@ -0,0 +1,133 @@
+package waveparser
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestCueEditing(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 1000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 1000)))
+
+	marker, err := wav.AddCue("marker", 100*time.Millisecond)
+	assertNoError(t, err)
+	unlabeled, err := wav.AddCue("", 200*time.Millisecond)
+	assertNoError(t, err)
+	if marker != 1 || unlabeled != 2 {
+		t.Fatalf("expected ids 1 and 2, got %d and %d", marker, unlabeled)
+	}
+
+	region, err := wav.SetRegion("take", 300*time.Millisecond, 500*time.Millisecond)
+	assertNoError(t, err)
+	wav.SetSampler(&Sampler{Loops: []SampleLoop{
+		{CuePointID: region, Start: 300, End: 499},
+		{CuePointID: marker, Start: 0, End: 99},
+	}})
+
+	// moving the region moves its loop
+	moved, err := wav.SetRegion("take", 600*time.Millisecond, time.Second)
+	assertNoError(t, err)
+	if moved != region {
+		t.Fatalf("expected region[%d] updated, got new cue[%d]", region, moved)
+	}
+
+	// the chunks are parsed again by a round trip through a file
+	buf := &bytes.Buffer{}
+	_, err = wav.WriteTo(buf)
+	assertNoError(t, err)
+	parsed, err := ParseBytes(buf.Bytes())
+	assertNoError(t, err)
+
+	regions, err := parsed.Regions()
+	assertNoError(t, err)
+	expected := []Region{
+		{ID: 1, Label: "marker", Start: 100 * time.Millisecond, End: 100 * time.Millisecond},
+		{ID: 2, Start: 200 * time.Millisecond, End: 200 * time.Millisecond},
+		{ID: 3, Label: "take", Start: 600 * time.Millisecond, End: time.Second},
+	}
+	if len(regions) != len(expected) {
+		t.Fatalf("expected %+v, got %+v", expected, regions)
+	}
+	for i := range expected {
+		if regions[i] != expected[i] {
+			t.Fatalf("region[%d]: expected %+v, got %+v", i, expected[i], regions[i])
+		}
+	}
+
+	sampler, err := parsed.Sampler()
+	assertNoError(t, err)
+	if sampler.Loops[0].Start != 600 || sampler.Loops[0].End != 999 {
+		t.Fatalf("loop not moved with its region: %+v", sampler.Loops[0])
+	}
+
+	assertNoError(t, parsed.RemoveCue(marker))
+	sampler, err = parsed.Sampler()
+	assertNoError(t, err)
+	if len(sampler.Loops) != 1 {
+		t.Fatalf("expected marker loop removed: %+v", sampler.Loops)
+	}
+	list, err := parsed.adtl()
+	assertNoError(t, err)
+	if _, ok := list.labels[marker]; ok {
+		t.Fatalf("expected marker label removed: %v", list.labels)
+	}
+
+	assertError(t, parsed.RemoveCue(marker))
+	_, err = parsed.AddCue("late", 2*time.Second)
+	assertError(t, err)
+	_, err = parsed.SetRegion("empty", time.Second, time.Second)
+	assertError(t, err)
+}
+
+func TestSliceRetimesRegions(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 1000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 1000)))
+
+	_, err := wav.AddCue("dropped", 100*time.Millisecond)
+	assertNoError(t, err)
+	_, err = wav.SetRegion("kept", 600*time.Millisecond, 700*time.Millisecond)
+	assertNoError(t, err)
+
+	resampled, err := Resample(wav, 2000, ResampleLinear)
+	assertNoError(t, err)
+	sliced, err := resampled.Slice(500*time.Millisecond, time.Second)
+	assertNoError(t, err)
+
+	regions, err := sliced.Regions()
+	assertNoError(t, err)
+	if len(regions) != 1 || regions[0].Label != "kept" ||
+		regions[0].Start != 100*time.Millisecond || regions[0].End != 200*time.Millisecond {
+		t.Fatalf("unexpected regions: %+v", regions)
+	}
+
+	list, err := sliced.adtl()
+	assertNoError(t, err)
+	if len(list.labels) != 1 {
+		t.Fatalf("expected dropped cue label removed: %v", list.labels)
+	}
+}
+
+func TestCueEditingChunkSize(t *testing.T) {
+	wav, err := ParseBytes(extensibleWav(t, WaveFormatPCM, 0x3, 2))
+	assertNoError(t, err)
+	assertNoError(t, wav.SetSamples(make([]float64, 48000*2)))
+
+	checkSize := func() {
+		t.Helper()
+		data, err := wav.Bytes()
+		assertNoError(t, err)
+		if size := uint32(len(data) - 8); wav.Header.RIFFHdr.ChunkSize != size {
+			t.Fatalf("expected RIFF size [%d], got [%d]", size, wav.Header.RIFFHdr.ChunkSize)
+		}
+	}
+
+	marker, err := wav.AddCue("marker", 100*time.Millisecond)
+	assertNoError(t, err)
+	checkSize()
+	_, err = wav.SetRegion("take", 300*time.Millisecond, 500*time.Millisecond)
+	assertNoError(t, err)
+	checkSize()
+	assertNoError(t, wav.RemoveCue(marker))
+	checkSize()
+}
@@ -0,0 +1,84 @@
+package waveparser
+
+import (
+	"io"
+	"sync"
+)
+
+type (
+	// Decoder parses WAVs reusing sample data buffers between calls,
+	// avoiding allocations when handling lots of short files. It is
+	// safe for concurrent use.
+	Decoder struct {
+		opts    []SampleOption
+		data    sync.Pool // *[]byte
+		samples sync.Pool // *[]float64
+	}
+)
+
+// NewDecoder creates a Decoder, the options are used when decoding
+// samples.
+func NewDecoder(opts ...SampleOption) *Decoder {
+	return &Decoder{opts: opts}
+}
+
+// Decode parses a complete WAV stream like Parse, but with Data backed
+// by a pooled buffer. Call Release once done with the returned Wav.
+func (d *Decoder) Decode(r io.ReadSeeker) (*Wav, error) {
+	var buf []byte
+	if pooled, ok := d.data.Get().(*[]byte); ok {
+		buf = *pooled
+	}
+
+	w, err := parseWav(r, buf, parseOptions{})
+	if err != nil {
+		d.putData(buf)
+		return nil, err
+	}
+	return w, nil
+}
+
+// Samples decodes the samples of w like Wav.Samples, but into a
+// pooled slice. Call ReleaseSamples once done with it.
+func (d *Decoder) Samples(w *Wav) ([]float64, error) {
+	var buf []float64
+	if pooled, ok := d.samples.Get().(*[]float64); ok {
+		buf = *pooled
+	}
+
+	samples, err := decodeSamplesInto(
+		buf,
+		w.Header.RIFFChunkFmt,
+		w.Data,
+		newSampleOptions(d.opts).workers,
+	)
+	if err != nil {
+		d.ReleaseSamples(buf)
+		return nil, err
+	}
+	return samples, nil
+}
+
+// Release returns the Data of w to the pool, neither w.Data nor any
+// slice of it may be used afterwards.
+func (d *Decoder) Release(w *Wav) {
+	d.putData(w.Data)
+	w.Data = nil
+}
+
+// ReleaseSamples returns samples obtained from Samples to the pool.
+func (d *Decoder) ReleaseSamples(samples []float64) {
+	if cap(samples) == 0 {
+		return
+	}
+	samples = samples[:0]
+	d.samples.Put(&samples)
+}
+
+func (d *Decoder) putData(data []byte) {
+	if cap(data) == 0 {
+		return
+	}
+	data = data[:0]
+	d.data.Put(&data)
+}
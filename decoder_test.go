@@ -0,0 +1,48 @@
+package waveparser
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestDecoderReusesBuffers(t *testing.T) {
+	dec := NewDecoder()
+
+	for _, audiofile := range []string{
+		"./testdata/r.wav",
+		"./testdata/audios/sint16le.wav",
+		"./testdata/r.wav",
+	} {
+		raw, err := ioutil.ReadFile(audiofile)
+		assertNoError(t, err)
+
+		expected, err := ParseBytes(raw)
+		assertNoError(t, err)
+		expectedSamples, err := expected.Samples()
+		assertNoError(t, err)
+
+		got, err := dec.Decode(bytes.NewReader(raw))
+		assertNoError(t, err)
+		if !reflect.DeepEqual(expected, got) {
+			t.Fatalf("[%s]: Decode differs from Parse", audiofile)
+		}
+
+		samples, err := dec.Samples(got)
+		assertNoError(t, err)
+		assertSamplesClose(t, expectedSamples, samples, 0)
+
+		dec.ReleaseSamples(samples)
+		dec.Release(got)
+		if got.Data != nil {
+			t.Fatal("expected Release to drop the wav data")
+		}
+	}
+}
+
+func TestDecoderInvalidWav(t *testing.T) {
+	dec := NewDecoder()
+	_, err := dec.Decode(bytes.NewReader([]byte("not a wav file")))
+	assertError(t, err)
+}
@@ -0,0 +1,61 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+type (
+	// Display is the DISP chunk, used by Windows tools to show a title
+	// (or an image) for the file. Data is in the clipboard format
+	// given by Type.
+	Display struct {
+		Type uint32
+		Data []byte
+	}
+)
+
+// DisplayText is the clipboard format (CF_TEXT) of DISP titles.
+const DisplayText = 1
+
+// Display parses the DISP chunk, returning nil when the file has none.
+func (w *Wav) Display() (*Display, error) {
+	chunk := w.Chunk("DISP")
+	if chunk == nil {
+		return nil, nil
+	}
+	if len(chunk.Data) < 4 {
+		return nil, fmt.Errorf("DISP chunk too small: %d bytes", len(chunk.Data))
+	}
+	return &Display{
+		Type: binary.LittleEndian.Uint32(chunk.Data),
+		Data: append([]byte(nil), chunk.Data[4:]...),
+	}, nil
+}
+
+// SetDisplay replaces the DISP chunk, removing it when d is nil.
+func (w *Wav) SetDisplay(d *Display) {
+	if d == nil {
+		w.RemoveChunk("DISP")
+		return
+	}
+
+	data := make([]byte, 4, 4+len(d.Data))
+	binary.LittleEndian.PutUint32(data, d.Type)
+	w.SetChunk("DISP", append(data, d.Data...))
+}
+
+// SetDisplayTitle replaces the DISP chunk with a text title.
+func (w *Wav) SetDisplayTitle(title string) {
+	w.SetDisplay(&Display{Type: DisplayText, Data: append([]byte(title), 0)})
+}
+
+// Text returns the title of text DISP chunks, or "" for other
+// clipboard formats.
+func (d *Display) Text() string {
+	if d.Type != DisplayText {
+		return ""
+	}
+	return string(bytes.TrimRight(d.Data, "\x00"))
+}
@@ -0,0 +1,53 @@
+package waveparser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDisplay(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.1, 0.2}))
+
+	d, err := wav.Display()
+	assertNoError(t, err)
+	if d != nil {
+		t.Fatalf("expected no DISP chunk, got %+v", d)
+	}
+
+	wav.SetDisplayTitle("Ding")
+
+	buf := &bytes.Buffer{}
+	_, err = wav.WriteTo(buf)
+	assertNoError(t, err)
+	parsed, err := ParseBytes(buf.Bytes())
+	assertNoError(t, err)
+
+	d, err = parsed.Display()
+	assertNoError(t, err)
+	if d.Type != DisplayText || d.Text() != "Ding" {
+		t.Fatalf("unexpected DISP %+v", d)
+	}
+
+	// the chunk is kept as is by transforms and writes
+	sliced, err := parsed.Slice(0, parsed.Duration())
+	assertNoError(t, err)
+	assertBytesEqual(t, wav.Chunk("DISP").Data, sliced.Chunk("DISP").Data)
+
+	dib := &Display{Type: 8, Data: []byte{1, 2, 3}}
+	wav.SetDisplay(dib)
+	d, err = wav.Display()
+	assertNoError(t, err)
+	if d.Text() != "" {
+		t.Fatalf("expected no text for an image, got %q", d.Text())
+	}
+	assertBytesEqual(t, dib.Data, d.Data)
+
+	wav.SetDisplay(nil)
+	if wav.Chunk("DISP") != nil {
+		t.Fatal("expected DISP chunk removed")
+	}
+	wav.SetChunk("DISP", []byte{1})
+	_, err = wav.Display()
+	assertError(t, err)
+}
@@ -0,0 +1,94 @@
+package waveparser
+
+import "math/rand"
+
+type (
+	// Dither adds noise to samples about to be quantized, trading a
+	// higher noise floor for the removal of quantization distortion.
+	Dither interface {
+		// Dither processes interleaved samples, normalized to [-1, 1],
+		// in place before they are quantized to bits.
+		Dither(samples []float64, channels int, bits uint)
+	}
+
+	rectangularDither struct {
+		rnd *rand.Rand
+	}
+
+	tpdfDither struct {
+		rnd *rand.Rand
+	}
+
+	// noiseShapingDither feeds back the quantization error of the
+	// previous sample of each channel (first order), pushing the
+	// noise to higher frequencies.
+	noiseShapingDither struct {
+		tpdf  tpdfDither
+		error []float64
+	}
+
+	noDither struct{}
+)
+
+// NoDither just quantizes, the default.
+var NoDither Dither = noDither{}
+
+// NewRectangularDither adds uniform noise of one LSB peak to peak.
+func NewRectangularDither(seed int64) Dither {
+	return &rectangularDither{rnd: rand.New(rand.NewSource(seed))}
+}
+
+// NewTPDFDither adds triangular noise of two LSB peak to peak, the
+// usual choice as it makes the noise independent of the signal.
+func NewTPDFDither(seed int64) Dither {
+	return &tpdfDither{rnd: rand.New(rand.NewSource(seed))}
+}
+
+// NewNoiseShapingDither adds TPDF noise and shapes the quantization
+// error with a first order highpass.
+func NewNoiseShapingDither(seed int64) Dither {
+	return &noiseShapingDither{tpdf: tpdfDither{rnd: rand.New(rand.NewSource(seed))}}
+}
+
+func (noDither) Dither(samples []float64, channels int, bits uint) {}
+
+func (d *rectangularDither) Dither(samples []float64, channels int, bits uint) {
+	lsb := lsbSize(bits)
+	for i := range samples {
+		samples[i] += (d.rnd.Float64() - 0.5) * lsb
+	}
+}
+
+func (d *tpdfDither) Dither(samples []float64, channels int, bits uint) {
+	lsb := lsbSize(bits)
+	for i := range samples {
+		samples[i] += d.noise(lsb)
+	}
+}
+
+func (d *tpdfDither) noise(lsb float64) float64 {
+	return (d.rnd.Float64() - d.rnd.Float64()) * lsb
+}
+
+func (d *noiseShapingDither) Dither(samples []float64, channels int, bits uint) {
+	if channels <= 0 {
+		return
+	}
+	if len(d.error) != channels {
+		d.error = make([]float64, channels)
+	}
+
+	lsb := lsbSize(bits)
+	for i, sample := range samples {
+		c := i % channels
+		shaped := sample - d.error[c]
+		quantized := float64(quantize(shaped+d.tpdf.noise(lsb), bits)) * lsb
+		d.error[c] = quantized - shaped
+		samples[i] = quantized
+	}
+}
+
+// lsbSize is the normalized size of the least significant bit.
+func lsbSize(bits uint) float64 {
+	return 1 / float64(int64(1)<<(bits-1))
+}
@@ -0,0 +1,57 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDitherKeepsSignal(t *testing.T) {
+	const bits = 8
+
+	// a constant between two 8 bits steps, quantization alone always
+	// rounds it to the same value
+	samples := make([]float64, 20000)
+	for i := range samples {
+		samples[i] = 0.3 * lsbSize(bits)
+	}
+
+	type tcase struct {
+		name   string
+		dither Dither
+	}
+
+	for _, tcase := range []tcase{
+		{name: "rectangular", dither: NewRectangularDither(1)},
+		{name: "tpdf", dither: NewTPDFDither(1)},
+		{name: "noiseShaping", dither: NewNoiseShapingDither(1)},
+	} {
+		t.Run(tcase.name, func(t *testing.T) {
+			wav := New(WaveFormatPCM, 2, 8000, bits)
+			assertNoError(t, wav.SetSamples(samples, WithDither(tcase.dither)))
+
+			got, err := wav.Samples()
+			assertNoError(t, err)
+
+			var mean float64
+			for _, s := range got {
+				mean += s
+			}
+			mean /= float64(len(got))
+
+			if math.Abs(mean-samples[0]) > 0.05*lsbSize(bits) {
+				t.Fatalf("expected dithered mean[%f], got[%f]", samples[0], mean)
+			}
+			if samples[0] != 0.3*lsbSize(bits) {
+				t.Fatal("dithering must not change the given samples")
+			}
+		})
+	}
+
+	wav := New(WaveFormatPCM, 1, 8000, bits)
+	assertNoError(t, wav.SetSamples(samples))
+	got, err := wav.Samples()
+	assertNoError(t, err)
+	if got[0] != 0 || got[len(got)-1] != 0 {
+		t.Fatal("expected undithered samples to be quantized to zero")
+	}
+}
@@ -0,0 +1,114 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+)
+
+// downmixCoefficients are the ITU-R BS.775 gains of each source
+// speaker on the stereo and mono speakers. Speakers not listed, like
+// the LFE, are dropped.
+var downmixCoefficients = map[Speaker]map[Speaker]float64{
+	SpeakerFrontLeft: {
+		SpeakerFrontLeft:   1,
+		SpeakerFrontCenter: math.Sqrt2 / 2,
+		SpeakerBackLeft:    math.Sqrt2 / 2,
+		SpeakerSideLeft:    math.Sqrt2 / 2,
+		SpeakerBackCenter:  0.5,
+	},
+	SpeakerFrontRight: {
+		SpeakerFrontRight:  1,
+		SpeakerFrontCenter: math.Sqrt2 / 2,
+		SpeakerBackRight:   math.Sqrt2 / 2,
+		SpeakerSideRight:   math.Sqrt2 / 2,
+		SpeakerBackCenter:  0.5,
+	},
+	SpeakerFrontCenter: {
+		SpeakerFrontLeft:   math.Sqrt2 / 2,
+		SpeakerFrontRight:  math.Sqrt2 / 2,
+		SpeakerFrontCenter: 1,
+		SpeakerBackLeft:    0.5,
+		SpeakerBackRight:   0.5,
+		SpeakerSideLeft:    0.5,
+		SpeakerSideRight:   0.5,
+		SpeakerBackCenter:  0.5,
+	},
+}
+
+// Downmix returns a copy of w mixed down to LayoutStereo or
+// LayoutMono with the ITU-R BS.775 coefficients, dropping the LFE.
+// The source layout comes from the channel mask or, without one, is
+// taken as the standard layout for the channel count (mono, stereo,
+// 5.1 or 7.1). The mix isn't normalized, loud surround audio may clip
+// on integer formats.
+func (w *Wav) Downmix(layout ChannelLayout) (*Wav, error) {
+	if !sameLayout(layout, LayoutStereo) && !sameLayout(layout, LayoutMono) {
+		return nil, fmt.Errorf("can't downmix to layout[%s]", layout)
+	}
+
+	format := w.Header.RIFFChunkFmt
+	source, err := w.sourceLayout()
+	if err != nil {
+		return nil, err
+	}
+	for _, speaker := range source {
+		if speaker != SpeakerLowFrequency && downmixCoefficients[SpeakerFrontCenter][speaker] == 0 {
+			return nil, fmt.Errorf("can't downmix speaker[%s]", speaker)
+		}
+	}
+
+	samples, err := w.Samples()
+	if err != nil {
+		return nil, err
+	}
+
+	channels := len(source)
+	frames := len(samples) / channels
+	mixed := make([]float64, frames*len(layout))
+	for i := 0; i < frames; i++ {
+		frame := samples[i*channels : (i+1)*channels]
+		for o, target := range layout {
+			gains := downmixCoefficients[target]
+			var sum float64
+			for c, speaker := range source {
+				sum += gains[speaker] * frame[c]
+			}
+			mixed[i*len(layout)+o] = sum
+		}
+	}
+
+	out := New(format.AudioFormat, uint16(len(layout)), format.SampleRate, format.BitsPerSample)
+	if err := out.SetSamples(mixed); err != nil {
+		return nil, err
+	}
+	if err := carryMetadata(out, w, 0, 1); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *Wav) sourceLayout() (ChannelLayout, error) {
+	if layout := w.ChannelLayout(); layout != nil {
+		return layout, nil
+	}
+
+	channels := w.Header.RIFFChunkFmt.NumChannels
+	for _, layout := range []ChannelLayout{LayoutMono, LayoutStereo, Layout5Point1, Layout7Point1} {
+		if len(layout) == int(channels) {
+			return layout, nil
+		}
+	}
+	return nil, fmt.Errorf("no standard layout for channels[%d]", channels)
+}
+
+func sameLayout(a, b ChannelLayout) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
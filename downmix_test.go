@@ -0,0 +1,69 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDownmix(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 6, 48000, 64)
+	// L, R, C, LFE, Ls, Rs
+	assertNoError(t, wav.SetSamples([]float64{
+		0.1, 0.2, 0.3, 0.9, 0.4, 0.5,
+		0, 0, 0.5, 0, 0, 0,
+	}))
+	wav.SetInfo(Info{InfoTitle: "surround"})
+
+	stereo, err := wav.Downmix(LayoutStereo)
+	assertNoError(t, err)
+
+	k := math.Sqrt2 / 2
+	got, err := stereo.Samples()
+	assertNoError(t, err)
+	assertSamplesClose(t, []float64{
+		0.1 + k*0.3 + k*0.4, 0.2 + k*0.3 + k*0.5,
+		k * 0.5, k * 0.5,
+	}, got, 1e-12)
+
+	if stereo.Header.RIFFChunkFmt.NumChannels != 2 {
+		t.Fatalf("expected stereo, got channels[%d]", stereo.Header.RIFFChunkFmt.NumChannels)
+	}
+	info, err := stereo.Info()
+	assertNoError(t, err)
+	if info[InfoTitle] != "surround" {
+		t.Fatalf("metadata not preserved: %v", info)
+	}
+
+	mono, err := wav.Downmix(LayoutMono)
+	assertNoError(t, err)
+	got, err = mono.Samples()
+	assertNoError(t, err)
+	assertSamplesClose(t, []float64{k*0.1 + k*0.2 + 0.3 + 0.5*0.4 + 0.5*0.5, 0.5}, got, 1e-12)
+
+	_, err = wav.Downmix(Layout5Point1)
+	assertError(t, err)
+
+	_, err = New(WaveFormatPCM, 3, 8000, 16).Downmix(LayoutStereo)
+	assertError(t, err)
+}
+
+func TestDownmixExtensibleLayout(t *testing.T) {
+	mask := uint32(SpeakerFrontLeft | SpeakerFrontRight | SpeakerBackCenter)
+	wav, err := ParseBytes(extensibleWav(t, WaveFormatPCM, mask, 3))
+	assertNoError(t, err)
+
+	samples := []float64{0.5, 0.25, 0.5, -0.5, 0, 0.25}
+	assertNoError(t, wav.SetSamples(samples))
+
+	stereo, err := wav.Downmix(LayoutStereo)
+	assertNoError(t, err)
+	got, err := stereo.Samples()
+	assertNoError(t, err)
+
+	for i := 0; i < len(samples)/3; i++ {
+		l := samples[3*i] + 0.5*samples[3*i+2]
+		if math.Abs(got[2*i]-l) > 1e-4 {
+			t.Fatalf("frame[%d]: expected left[%f] got[%f]", i, l, got[2*i])
+		}
+	}
+}
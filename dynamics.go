@@ -0,0 +1,128 @@
+package waveparser
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+type (
+	// Dynamics summarizes the dynamic range of the audio, in dB.
+	Dynamics struct {
+		Channels []ChannelDynamics
+		// Score is the mean DR of the channels, rounded as the DR
+		// meters display it.
+		Score int
+	}
+
+	// ChannelDynamics holds the levels of one channel. DR compares
+	// the second highest block peak with the RMS of the loudest 20%
+	// of the blocks, as the DR meters used on mastering do.
+	ChannelDynamics struct {
+		Peak        float64
+		RMS         float64
+		CrestFactor float64
+		DR          float64
+	}
+)
+
+const (
+	dynamicsBlock    = 3 * time.Second
+	dynamicsLoudest  = 0.2
+	dynamicsRMSScale = 2 // the DR RMS reads a full scale sine at 0 dB
+)
+
+// DynamicRange computes the crest factor and DR of each channel, over
+// blocks of 3 seconds.
+func (w *Wav) DynamicRange() (Dynamics, error) {
+	samples, err := w.Samples()
+	if err != nil {
+		return Dynamics{}, err
+	}
+
+	format := w.Header.RIFFChunkFmt
+	channels := int(format.NumChannels)
+	if channels == 0 {
+		return Dynamics{}, nil
+	}
+	block := int(dynamicsBlock.Seconds() * float64(format.SampleRate))
+	if block == 0 {
+		block = 1
+	}
+
+	frames := len(samples) / channels
+	dynamics := Dynamics{Channels: make([]ChannelDynamics, channels)}
+
+	var sumDR float64
+	for c := 0; c < channels; c++ {
+		var peak, sum float64
+		var blockPeaks, blockRMS []float64
+
+		for start := 0; start < frames; start += block {
+			end := start + block
+			if end > frames {
+				end = frames
+			}
+
+			var blockPeak, blockSum float64
+			for i := start; i < end; i++ {
+				s := samples[i*channels+c]
+				blockPeak = math.Max(blockPeak, math.Abs(s))
+				blockSum += s * s
+			}
+			peak = math.Max(peak, blockPeak)
+			sum += blockSum
+
+			blockPeaks = append(blockPeaks, blockPeak)
+			blockRMS = append(blockRMS, math.Sqrt(dynamicsRMSScale*blockSum/float64(end-start)))
+		}
+
+		var rms float64
+		if frames > 0 {
+			rms = math.Sqrt(sum / float64(frames))
+		}
+
+		dr := blockDR(blockPeaks, blockRMS)
+		dynamics.Channels[c] = ChannelDynamics{
+			Peak:        dBFS(peak),
+			RMS:         dBFS(rms),
+			CrestFactor: dBFS(peak) - dBFS(rms),
+			DR:          dr,
+		}
+		sumDR += dr
+	}
+
+	dynamics.Score = int(math.Round(sumDR / float64(channels)))
+	return dynamics, nil
+}
+
+// blockDR compares the second highest block peak with the RMS of the
+// loudest blocks. Silence has no dynamic range.
+func blockDR(peaks, rms []float64) float64 {
+	if len(peaks) == 0 {
+		return 0
+	}
+
+	sort.Sort(sort.Reverse(sort.Float64Slice(peaks)))
+	sort.Sort(sort.Reverse(sort.Float64Slice(rms)))
+
+	peak := peaks[0]
+	if len(peaks) > 1 {
+		peak = peaks[1]
+	}
+
+	loudest := int(math.Round(float64(len(rms)) * dynamicsLoudest))
+	if loudest == 0 {
+		loudest = 1
+	}
+	var sum float64
+	for _, r := range rms[:loudest] {
+		sum += r * r
+	}
+	loud := math.Sqrt(sum / float64(loudest))
+
+	if peak == 0 || loud == 0 {
+		return 0
+	}
+	return dBFS(peak) - dBFS(loud)
+}
@@ -0,0 +1,64 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDynamicRange(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 2, 1000, 32)
+
+	// a steady sine on the left, a sine with a loud burst on the right
+	frames := 30 * 1000
+	samples := make([]float64, 2*frames)
+	for i := 0; i < frames; i++ {
+		s := math.Sin(2 * math.Pi * 50 * float64(i) / 1000)
+		samples[2*i] = 0.5 * s
+		samples[2*i+1] = 0.1 * s
+		if i < 6000 {
+			samples[2*i+1] = s
+		}
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	dynamics, err := wav.DynamicRange()
+	assertNoError(t, err)
+
+	left := dynamics.Channels[0]
+	if math.Abs(left.CrestFactor-dBFS(math.Sqrt2)) > 0.01 {
+		t.Fatalf("expected sine crest factor of 3 dB, got %f", left.CrestFactor)
+	}
+	if math.Abs(left.DR) > 0.01 {
+		t.Fatalf("expected DR 0 for a steady sine, got %f", left.DR)
+	}
+
+	right := dynamics.Channels[1]
+	if right.CrestFactor <= left.CrestFactor {
+		t.Fatalf("expected burst to raise the crest factor: %f", right.CrestFactor)
+	}
+	if math.Abs(right.DR) > 0.01 {
+		t.Fatalf("expected DR 0 when the loudest blocks peak with the burst, got %f", right.DR)
+	}
+	if dynamics.Score != 0 {
+		t.Fatalf("expected score 0, got %d", dynamics.Score)
+	}
+}
+
+func TestDynamicRangeOfPeaks(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 1, 1000, 32)
+
+	// quiet blocks with a few peaks read as a wide dynamic range
+	samples := make([]float64, 30*1000)
+	for i := range samples {
+		samples[i] = 0.1 * math.Sin(2*math.Pi*50*float64(i)/1000)
+	}
+	samples[100] = 1
+	samples[4000] = 1
+	assertNoError(t, wav.SetSamples(samples))
+
+	dynamics, err := wav.DynamicRange()
+	assertNoError(t, err)
+	if dynamics.Score != 20 {
+		t.Fatalf("expected DR20, got %d (%+v)", dynamics.Score, dynamics.Channels)
+	}
+}
@@ -0,0 +1,62 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Envelope returns the RMS energy of the audio, all channels mixed
+// down, over frames of the given length starting every hop.
+func (w *Wav) Envelope(frame, hop time.Duration) ([]float64, error) {
+	return w.framewise(frame, hop, func(x []float64) float64 {
+		var sum float64
+		for _, s := range x {
+			sum += s * s
+		}
+		return math.Sqrt(sum / float64(len(x)))
+	})
+}
+
+// ZCR returns the zero crossing rate of the audio, all channels mixed
+// down, as the fraction of consecutive samples changing sign on
+// frames of the given length starting every hop.
+func (w *Wav) ZCR(frame, hop time.Duration) ([]float64, error) {
+	return w.framewise(frame, hop, func(x []float64) float64 {
+		crossings := 0
+		for i := 1; i < len(x); i++ {
+			if (x[i-1] >= 0) != (x[i] >= 0) {
+				crossings++
+			}
+		}
+		if len(x) < 2 {
+			return 0
+		}
+		return float64(crossings) / float64(len(x)-1)
+	})
+}
+
+// framewise applies measure to each whole frame of the mono mix.
+func (w *Wav) framewise(frame, hop time.Duration, measure func([]float64) float64) ([]float64, error) {
+	format := w.Header.RIFFChunkFmt
+	size := int(frame.Seconds() * float64(format.SampleRate))
+	step := int(hop.Seconds() * float64(format.SampleRate))
+	if size <= 0 || step <= 0 {
+		return nil, fmt.Errorf("invalid framing: frame[%s] hop[%s] samplerate[%d]", frame, hop, format.SampleRate)
+	}
+
+	samples, err := w.Samples()
+	if err != nil {
+		return nil, err
+	}
+	mono, err := remix(samples, int(format.NumChannels), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []float64{}
+	for start := 0; start+size <= len(mono); start += step {
+		values = append(values, measure(mono[start:start+size]))
+	}
+	return values, nil
+}
@@ -0,0 +1,38 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEnvelope(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 2, 1000, 32)
+
+	// 100ms of a full scale square wave followed by 100ms of silence
+	samples := make([]float64, 2*200)
+	for i := 0; i < 100; i++ {
+		v := 1.0
+		if i%2 == 1 {
+			v = -1
+		}
+		samples[2*i], samples[2*i+1] = v, v
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	envelope, err := wav.Envelope(50*time.Millisecond, 50*time.Millisecond)
+	assertNoError(t, err)
+	assertSamplesClose(t, []float64{1, 1, 0, 0}, envelope, 1e-9)
+
+	zcr, err := wav.ZCR(100*time.Millisecond, 50*time.Millisecond)
+	assertNoError(t, err)
+	if len(zcr) != 3 || zcr[0] != 1 || zcr[2] != 0 {
+		t.Fatalf("unexpected zero crossing rate: %v", zcr)
+	}
+	if math.Abs(zcr[1]-50.0/99) > 1e-9 {
+		t.Fatalf("expected half the frame crossing, got %f", zcr[1])
+	}
+
+	_, err = wav.Envelope(0, time.Millisecond)
+	assertError(t, err)
+}
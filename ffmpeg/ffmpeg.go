@@ -0,0 +1,94 @@
+// Package ffmpeg decodes compressed audio (MP3, FLAC, OGG...) into Wavs
+// by running the ffmpeg command line tool.
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+type (
+	// Decoder configures how ffmpeg is run, the zero value is ready
+	// to be used.
+	Decoder struct {
+		Path  string // ffmpeg binary, defaults to "ffmpeg" on the PATH
+		Codec string // output PCM codec, defaults to pcm_s16le
+	}
+)
+
+const (
+	defaultPath  = "ffmpeg"
+	defaultCodec = "pcm_s16le"
+)
+
+// Decode decodes r with the default Decoder.
+func Decode(ctx context.Context, r io.Reader) (*waveparser.Wav, error) {
+	return Decoder{}.Decode(ctx, r)
+}
+
+// DecodeFile decodes the file at path with the default Decoder.
+func DecodeFile(ctx context.Context, path string) (*waveparser.Wav, error) {
+	return Decoder{}.DecodeFile(ctx, path)
+}
+
+// Decode decodes any audio ffmpeg understands read from r.
+func (d Decoder) Decode(ctx context.Context, r io.Reader) (*waveparser.Wav, error) {
+	return d.run(ctx, "pipe:0", r)
+}
+
+// DecodeFile decodes the audio file at path.
+func (d Decoder) DecodeFile(ctx context.Context, path string) (*waveparser.Wav, error) {
+	return d.run(ctx, path, nil)
+}
+
+func (d Decoder) run(ctx context.Context, input string, stdin io.Reader) (*waveparser.Wav, error) {
+	path := d.Path
+	if path == "" {
+		path = defaultPath
+	}
+	codec := d.Codec
+	if codec == "" {
+		codec = defaultCodec
+	}
+
+	// ffmpeg can only write consistent sizes on a seekable output
+	dir, err := ioutil.TempDir("", "waveparser-ffmpeg")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	output := filepath.Join(dir, "decoded.wav")
+	stderr := &bytes.Buffer{}
+
+	args := []string{"-hide_banner", "-loglevel", "error"}
+	if stdin == nil {
+		args = append(args, "-nostdin")
+	}
+	args = append(args,
+		"-i", input,
+		"-vn", "-map_metadata", "-1",
+		"-c:a", codec,
+		"-f", "wav",
+		"-y", output,
+	)
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = stdin
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running ffmpeg: %s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return waveparser.Load(output)
+}
@@ -0,0 +1,48 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os/exec"
+	"testing"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+func TestDecodeWav(t *testing.T) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		t.Skip("ffmpeg not found on PATH")
+	}
+
+	raw, err := ioutil.ReadFile("../testdata/audios/sint16le.wav")
+	assertNoError(t, err)
+
+	expected, err := waveparser.Load("../testdata/audios/sint16le.wav")
+	assertNoError(t, err)
+
+	got, err := Decode(context.Background(), bytes.NewReader(raw))
+	assertNoError(t, err)
+
+	if !waveparser.SameFormat(expected.Header.RIFFChunkFmt, got.Header.RIFFChunkFmt) {
+		t.Fatalf("expected format %+v, got %+v", expected.Header.RIFFChunkFmt, got.Header.RIFFChunkFmt)
+	}
+	if !bytes.Equal(expected.Data, got.Data) {
+		t.Fatalf("decoded data differs: expected %d bytes, got %d", len(expected.Data), len(got.Data))
+	}
+}
+
+func TestDecodeMissingBinary(t *testing.T) {
+	dec := Decoder{Path: "/nonexistent/ffmpeg"}
+	_, err := dec.DecodeFile(context.Background(), "../testdata/audios/sint16le.wav")
+	if err == nil {
+		t.Fatal("expected error running a missing ffmpeg")
+	}
+}
+
+func assertNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
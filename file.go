@@ -0,0 +1,85 @@
+package waveparser
+
+import "os"
+
+// Load loads the WAV file at the given path.
+func Load(audiofile string, opts ...ParseOption) (*Wav, error) {
+	f, err := os.Open(audiofile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return Parse(f, opts...)
+}
+
+// Open parses only the header of the WAV file at the given path,
+// keeping the file open to read the audio data on demand. The
+// LazyWav must be closed when done.
+func Open(audiofile string) (*LazyWav, error) {
+	f, err := os.Open(audiofile)
+	if err != nil {
+		return nil, err
+	}
+
+	lazy, err := newLazyWav(f, f, f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return lazy, nil
+}
+
+// LoadAIFF loads an AIFF or AIFF-C file, converting it to a Wav with
+// the equivalent (little endian) encoding.
+func LoadAIFF(audiofile string) (*Wav, error) {
+	f, err := os.Open(audiofile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ParseAIFF(f)
+}
+
+// LoadCAF loads an Apple Core Audio Format file, converting it to a
+// Wav with the equivalent (little endian) encoding.
+func LoadCAF(audiofile string) (*Wav, error) {
+	f, err := os.Open(audiofile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ParseCAF(f)
+}
+
+// LoadAU loads a Sun AU file, converting it to a Wav with the
+// equivalent (little endian) encoding.
+func LoadAU(audiofile string) (*Wav, error) {
+	f, err := os.Open(audiofile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ParseAU(f)
+}
+
+// Save writes w as a WAV file at the given path.
+func (w *Wav) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
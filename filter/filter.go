@@ -0,0 +1,165 @@
+// Package filter implements biquad filters (RBJ audio EQ cookbook) for
+// basic cleanup of audio, like high passing speech at 80 Hz.
+package filter
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+type (
+	// Biquad holds the coefficients of a second order IIR section,
+	// normalized so a0 is 1.
+	Biquad struct {
+		B0, B1, B2 float64
+		A1, A2     float64
+	}
+
+	// Stream filters interleaved samples through a chain of biquads,
+	// keeping the filter state between calls so audio can be
+	// processed in blocks of any size.
+	Stream struct {
+		sections []Biquad
+		channels int
+		channel  int       // channel of the next sample
+		state    [][]state // per channel, per section
+	}
+
+	// state is the direct form I memory of a section.
+	state struct {
+		x1, x2, y1, y2 float64
+	}
+)
+
+// LowPass passes frequencies below freq (Hz), q is 1/√2 for a
+// Butterworth response.
+func LowPass(sampleRate, freq, q float64) Biquad {
+	w, alpha := params(sampleRate, freq, q)
+	cos := math.Cos(w)
+	return normalize(
+		(1-cos)/2, 1-cos, (1-cos)/2,
+		1+alpha, -2*cos, 1-alpha,
+	)
+}
+
+// HighPass passes frequencies above freq (Hz).
+func HighPass(sampleRate, freq, q float64) Biquad {
+	w, alpha := params(sampleRate, freq, q)
+	cos := math.Cos(w)
+	return normalize(
+		(1+cos)/2, -(1 + cos), (1+cos)/2,
+		1+alpha, -2*cos, 1-alpha,
+	)
+}
+
+// BandPass passes a band centered on freq (Hz), with unity gain at
+// the center, narrower as q grows.
+func BandPass(sampleRate, freq, q float64) Biquad {
+	w, alpha := params(sampleRate, freq, q)
+	cos := math.Cos(w)
+	return normalize(
+		alpha, 0, -alpha,
+		1+alpha, -2*cos, 1-alpha,
+	)
+}
+
+// LowShelf changes the level of the frequencies below freq (Hz) by
+// gain dB.
+func LowShelf(sampleRate, freq, q, gain float64) Biquad {
+	w, alpha := params(sampleRate, freq, q)
+	cos := math.Cos(w)
+	a := math.Pow(10, gain/40)
+	k := 2 * math.Sqrt(a) * alpha
+	return normalize(
+		a*((a+1)-(a-1)*cos+k), 2*a*((a-1)-(a+1)*cos), a*((a+1)-(a-1)*cos-k),
+		(a+1)+(a-1)*cos+k, -2*((a-1)+(a+1)*cos), (a+1)+(a-1)*cos-k,
+	)
+}
+
+// HighShelf changes the level of the frequencies above freq (Hz) by
+// gain dB.
+func HighShelf(sampleRate, freq, q, gain float64) Biquad {
+	w, alpha := params(sampleRate, freq, q)
+	cos := math.Cos(w)
+	a := math.Pow(10, gain/40)
+	k := 2 * math.Sqrt(a) * alpha
+	return normalize(
+		a*((a+1)+(a-1)*cos+k), -2*a*((a-1)+(a+1)*cos), a*((a+1)+(a-1)*cos-k),
+		(a+1)-(a-1)*cos+k, 2*((a-1)-(a+1)*cos), (a+1)-(a-1)*cos-k,
+	)
+}
+
+// Response returns the gain of the section at freq (Hz).
+func (b Biquad) Response(sampleRate, freq float64) float64 {
+	w := 2 * math.Pi * freq / sampleRate
+	z1 := complex(math.Cos(w), -math.Sin(w))
+	z2 := z1 * z1
+	num := complex(b.B0, 0) + complex(b.B1, 0)*z1 + complex(b.B2, 0)*z2
+	den := 1 + complex(b.A1, 0)*z1 + complex(b.A2, 0)*z2
+	return abs(num / den)
+}
+
+// NewStream creates a Stream for audio with the given channel count.
+func NewStream(channels int, sections ...Biquad) *Stream {
+	s := &Stream{
+		sections: sections,
+		channels: channels,
+		state:    make([][]state, channels),
+	}
+	for c := range s.state {
+		s.state[c] = make([]state, len(sections))
+	}
+	return s
+}
+
+// Process filters the interleaved samples in place.
+func (s *Stream) Process(samples []float64) {
+	for i, x := range samples {
+		states := s.state[s.channel]
+		for j, b := range s.sections {
+			st := &states[j]
+			y := b.B0*x + b.B1*st.x1 + b.B2*st.x2 - b.A1*st.y1 - b.A2*st.y2
+			st.x2, st.x1 = st.x1, x
+			st.y2, st.y1 = st.y1, y
+			x = y
+		}
+		samples[i] = x
+		s.channel = (s.channel + 1) % s.channels
+	}
+}
+
+// Apply filters the audio of w in place through the sections.
+func Apply(w *waveparser.Wav, sections ...Biquad) error {
+	channels := int(w.Header.RIFFChunkFmt.NumChannels)
+	if channels == 0 {
+		return fmt.Errorf("invalid number of channels[%d]", channels)
+	}
+
+	samples, err := w.Samples()
+	if err != nil {
+		return err
+	}
+	NewStream(channels, sections...).Process(samples)
+	return w.SetSamples(samples)
+}
+
+func params(sampleRate, freq, q float64) (float64, float64) {
+	w := 2 * math.Pi * freq / sampleRate
+	return w, math.Sin(w) / (2 * q)
+}
+
+func normalize(b0, b1, b2, a0, a1, a2 float64) Biquad {
+	return Biquad{
+		B0: b0 / a0,
+		B1: b1 / a0,
+		B2: b2 / a0,
+		A1: a1 / a0,
+		A2: a2 / a0,
+	}
+}
+
+func abs(c complex128) float64 {
+	return math.Hypot(real(c), imag(c))
+}
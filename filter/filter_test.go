@@ -0,0 +1,106 @@
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+func TestResponse(t *testing.T) {
+	const rate = 16000
+	butterworth := 1 / math.Sqrt2
+
+	type tcase struct {
+		name     string
+		filter   Biquad
+		freq     float64
+		expected float64 // dB
+	}
+
+	tcases := []tcase{
+		{name: "lowpass passband", filter: LowPass(rate, 1000, butterworth), freq: 50, expected: 0},
+		{name: "lowpass cutoff", filter: LowPass(rate, 1000, butterworth), freq: 1000, expected: -3.01},
+		{name: "lowpass stopband", filter: LowPass(rate, 1000, butterworth), freq: 4000, expected: -28.06},
+		{name: "highpass cutoff", filter: HighPass(rate, 80, butterworth), freq: 80, expected: -3.01},
+		{name: "highpass passband", filter: HighPass(rate, 80, butterworth), freq: 2000, expected: 0},
+		{name: "bandpass center", filter: BandPass(rate, 1000, 2), freq: 1000, expected: 0},
+		{name: "lowshelf", filter: LowShelf(rate, 200, butterworth, 6), freq: 20, expected: 6},
+		{name: "lowshelf above", filter: LowShelf(rate, 200, butterworth, 6), freq: 5000, expected: 0},
+		{name: "highshelf", filter: HighShelf(rate, 2000, butterworth, -6), freq: 7900, expected: -6},
+	}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			got := 20 * math.Log10(tcase.filter.Response(rate, tcase.freq))
+			if math.Abs(got-tcase.expected) > 0.1 {
+				t.Fatalf("expected %f dB, got %f", tcase.expected, got)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	const rate = 8000
+	wav := waveparser.New(waveparser.WaveFormatIEEEFloat, 2, rate, 64)
+
+	// 50 Hz rumble on the left, 1 kHz on the right
+	samples := make([]float64, 2*rate)
+	for i := 0; i < rate; i++ {
+		samples[2*i] = 0.5 * math.Sin(2*math.Pi*50*float64(i)/rate)
+		samples[2*i+1] = 0.5 * math.Sin(2*math.Pi*1000*float64(i)/rate)
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	highpass := HighPass(rate, 300, 1/math.Sqrt2)
+	assertNoError(t, Apply(wav, highpass, highpass))
+
+	filtered, err := wav.Samples()
+	assertNoError(t, err)
+
+	var left, right float64
+	for i := rate / 2; i < rate; i++ {
+		left = math.Max(left, math.Abs(filtered[2*i]))
+		right = math.Max(right, math.Abs(filtered[2*i+1]))
+	}
+	if left > 0.01 {
+		t.Fatalf("expected rumble removed, peak %f", left)
+	}
+	if math.Abs(right-0.5) > 0.02 {
+		t.Fatalf("expected 1 kHz kept, peak %f", right)
+	}
+}
+
+func TestStreamBlocks(t *testing.T) {
+	samples := make([]float64, 301)
+	for i := range samples {
+		samples[i] = math.Sin(float64(i))
+	}
+	lowpass := LowPass(8000, 500, 1)
+
+	whole := append([]float64(nil), samples...)
+	NewStream(1, lowpass).Process(whole)
+
+	stream := NewStream(1, lowpass)
+	blocks := append([]float64(nil), samples...)
+	for start := 0; start < len(blocks); start += 7 {
+		end := start + 7
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+		stream.Process(blocks[start:end])
+	}
+
+	for i := range whole {
+		if whole[i] != blocks[i] {
+			t.Fatalf("sample[%d]: expected %f, got %f", i, whole[i], blocks[i])
+		}
+	}
+}
+
+func assertNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,44 @@
+package filter
+
+import "math"
+
+type (
+	// Hum describes mains hum to be notched out: the mains frequency
+	// (50 or 60 Hz) and how many of its harmonics. Higher Q notches
+	// narrower bands, 30 keeps most of the audio around the notches.
+	Hum struct {
+		Frequency float64
+		Q         float64
+		Harmonics int // multiples of Frequency notched besides it
+	}
+)
+
+// Notch removes a narrow band centered on freq (Hz), narrower as q
+// grows.
+func Notch(sampleRate, freq, q float64) Biquad {
+	w, alpha := params(sampleRate, freq, q)
+	cos := math.Cos(w)
+	return normalize(
+		1, -2*cos, 1,
+		1+alpha, -2*cos, 1-alpha,
+	)
+}
+
+// Notches returns a notch for the hum fundamental and each harmonic
+// below the Nyquist frequency.
+func (h Hum) Notches(sampleRate float64) []Biquad {
+	var notches []Biquad
+	for n := 1; n <= h.Harmonics+1; n++ {
+		freq := h.Frequency * float64(n)
+		if freq >= sampleRate/2 {
+			break
+		}
+		notches = append(notches, Notch(sampleRate, freq, h.Q))
+	}
+	return notches
+}
+
+// Remove filters the hum out of the interleaved samples, in place.
+func (h Hum) Remove(samples []float64, channels int, sampleRate float64) {
+	NewStream(channels, h.Notches(sampleRate)...).Process(samples)
+}
@@ -0,0 +1,40 @@
+package filter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHumRemove(t *testing.T) {
+	const rate = 8000
+	hum := Hum{Frequency: 60, Q: 30, Harmonics: 2}
+
+	if n := len(hum.Notches(rate)); n != 3 {
+		t.Fatalf("expected 3 notches, got %d", n)
+	}
+	if n := len(Hum{Frequency: 50, Q: 30, Harmonics: 100}.Notches(rate)); n != 79 {
+		t.Fatalf("expected notches below nyquist only, got %d", n)
+	}
+
+	// 60 Hz hum and its 3rd harmonic over a 1 kHz tone
+	samples := make([]float64, 2*rate)
+	for i := range samples {
+		x := float64(i) / rate
+		samples[i] = 0.3*math.Sin(2*math.Pi*60*x) + 0.1*math.Sin(2*math.Pi*180*x) + 0.3*math.Sin(2*math.Pi*1000*x)
+	}
+	hum.Remove(samples, 1, rate)
+
+	var residual float64
+	for i := rate; i < 2*rate; i++ {
+		d := samples[i] - 0.3*math.Sin(2*math.Pi*1000*float64(i)/rate)
+		residual = math.Max(residual, math.Abs(d))
+	}
+	if residual > 0.01 {
+		t.Fatalf("expected hum removed, residual %f", residual)
+	}
+
+	notch := Notch(rate, 50, 30)
+	if g := notch.Response(rate, 50); g > 1e-6 {
+		t.Fatalf("expected no gain at the notch, got %f", g)
+	}
+}
@@ -0,0 +1,201 @@
+// Package fingerprint computes perceptual hashes of audio, robust to
+// re-encoding, resampling and level changes, to find near identical
+// recordings.
+//
+// Each hash of a Fingerprint describes about 93ms of audio: its 32 bits
+// are the signs of the energy differences between adjacent frequency
+// bands (300 Hz to 2 kHz, log spaced) and between consecutive frames,
+// as in the Haitsma-Kalker robust hash.
+package fingerprint
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+const (
+	sampleRate = 11025
+	frameSize  = 4096
+	hopSize    = 1024
+	minFreq    = 300.0
+	maxFreq    = 2000.0
+	bands      = 33
+
+	// maxShift is how many hashes apart the fingerprints are searched
+	// for the best match by Similarity.
+	maxShift = 16
+)
+
+// Fingerprint is a sequence of 32 bits hashes, one per frame.
+type Fingerprint []uint32
+
+// Compute fingerprints the audio of w, all channels mixed down.
+func Compute(w *waveparser.Wav) (Fingerprint, error) {
+	rate := w.Header.RIFFChunkFmt.SampleRate
+	format := waveparser.New(waveparser.WaveFormatIEEEFloat, 1, rate, 64).Header.RIFFChunkFmt
+	mono, err := waveparser.Convert(w, format)
+	if err != nil {
+		return nil, err
+	}
+	if rate != sampleRate {
+		if mono, err = waveparser.Resample(mono, sampleRate, waveparser.ResampleSinc); err != nil {
+			return nil, err
+		}
+	}
+	samples, err := mono.Samples()
+	if err != nil {
+		return nil, err
+	}
+
+	edges := bandEdges()
+	window := make([]float64, frameSize)
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/frameSize)
+	}
+
+	var (
+		fp       Fingerprint
+		previous []float64
+		spectrum = make([]complex128, frameSize)
+	)
+	for start := 0; start+frameSize <= len(samples); start += hopSize {
+		for i := range spectrum {
+			spectrum[i] = complex(samples[start+i]*window[i], 0)
+		}
+		fft(spectrum)
+
+		energies := make([]float64, bands)
+		for b := range energies {
+			for k := edges[b]; k < edges[b+1]; k++ {
+				re, im := real(spectrum[k]), imag(spectrum[k])
+				energies[b] += re*re + im*im
+			}
+		}
+
+		if previous != nil {
+			fp = append(fp, hash(energies, previous))
+		}
+		previous = energies
+	}
+	return fp, nil
+}
+
+// Similarity returns the fraction of bits matching on the best
+// alignment of a and b, about 0.5 for unrelated audio and 1 for the
+// same audio. Fingerprints without enough overlap have 0 similarity.
+func Similarity(a, b Fingerprint) float64 {
+	best := 0.0
+	for shift := -maxShift; shift <= maxShift; shift++ {
+		x, y := a, b
+		if shift > 0 {
+			x = shifted(a, shift)
+		} else {
+			y = shifted(b, -shift)
+		}
+
+		n := len(x)
+		if len(y) < n {
+			n = len(y)
+		}
+		// at least half of the shortest fingerprint must overlap
+		if n == 0 || 2*n < len(a) && 2*n < len(b) {
+			continue
+		}
+
+		errors := 0
+		for i := 0; i < n; i++ {
+			errors += bits.OnesCount32(x[i] ^ y[i])
+		}
+		best = math.Max(best, 1-float64(errors)/float64(32*n))
+	}
+	return best
+}
+
+// String encodes the fingerprint as base64 of its little endian hashes.
+func (f Fingerprint) String() string {
+	buf := make([]byte, 4*len(f))
+	for i, h := range f {
+		binary.LittleEndian.PutUint32(buf[4*i:], h)
+	}
+	return base64.StdEncoding.EncodeToString(buf)
+}
+
+// Parse decodes a fingerprint encoded by String.
+func Parse(s string) (Fingerprint, error) {
+	buf, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf)%4 != 0 {
+		return nil, fmt.Errorf("invalid fingerprint size[%d]", len(buf))
+	}
+
+	fp := make(Fingerprint, len(buf)/4)
+	for i := range fp {
+		fp[i] = binary.LittleEndian.Uint32(buf[4*i:])
+	}
+	return fp, nil
+}
+
+func shifted(f Fingerprint, n int) Fingerprint {
+	if n > len(f) {
+		return nil
+	}
+	return f[n:]
+}
+
+func hash(energies, previous []float64) uint32 {
+	var h uint32
+	for b := 0; b < bands-1; b++ {
+		d := (energies[b] - energies[b+1]) - (previous[b] - previous[b+1])
+		if d > 0 {
+			h |= 1 << uint(b)
+		}
+	}
+	return h
+}
+
+// bandEdges returns the FFT bins delimiting the bands.
+func bandEdges() []int {
+	edges := make([]int, bands+1)
+	for i := range edges {
+		freq := minFreq * math.Pow(maxFreq/minFreq, float64(i)/bands)
+		edges[i] = int(math.Round(freq * frameSize / sampleRate))
+	}
+	return edges
+}
+
+// fft is an in place radix-2 Cooley-Tukey transform, len(x) must be a
+// power of two.
+func fft(x []complex128) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		angle := -2 * math.Pi / float64(size)
+		step := complex(math.Cos(angle), math.Sin(angle))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				even, odd := x[start+k], w*x[start+k+size/2]
+				x[start+k] = even + odd
+				x[start+k+size/2] = even - odd
+				w *= step
+			}
+		}
+	}
+}
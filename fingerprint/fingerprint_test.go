@@ -0,0 +1,65 @@
+package fingerprint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+	"github.com/NeowayLabs/waveparser/generate"
+)
+
+func TestSimilarity(t *testing.T) {
+	hifi := waveparser.New(waveparser.WaveFormatPCM, 2, 44100, 16).Header.RIFFChunkFmt
+	original, err := generate.WhiteNoise(hifi, 0.5, 3*time.Second, 1)
+	assertNoError(t, err)
+
+	// the same audio as a quieter telephony recording
+	reencoded, err := waveparser.ToTelephony(original)
+	assertNoError(t, err)
+	assertNoError(t, reencoded.Gain(-6))
+
+	other, err := generate.WhiteNoise(hifi, 0.5, 3*time.Second, 2)
+	assertNoError(t, err)
+
+	fp1, err := Compute(original)
+	assertNoError(t, err)
+	fp2, err := Compute(reencoded)
+	assertNoError(t, err)
+	fp3, err := Compute(other)
+	assertNoError(t, err)
+
+	if len(fp1) != 28 {
+		t.Fatalf("expected 28 hashes, got %d", len(fp1))
+	}
+	if s := Similarity(fp1, fp1); s != 1 {
+		t.Fatalf("expected identical fingerprints, got %f", s)
+	}
+	if s := Similarity(fp1, fp2); s < 0.85 {
+		t.Fatalf("expected re-encoded audio to match, got %f", s)
+	}
+	if s := Similarity(fp1, fp3); s > 0.65 {
+		t.Fatalf("expected different audio not to match, got %f", s)
+	}
+}
+
+func TestParse(t *testing.T) {
+	fp := Fingerprint{1, 0xdeadbeef, 42}
+
+	parsed, err := Parse(fp.String())
+	assertNoError(t, err)
+	if len(parsed) != 3 || parsed[1] != 0xdeadbeef {
+		t.Fatalf("expected %v, got %v", fp, parsed)
+	}
+
+	_, err = Parse("AAA=")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func assertNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
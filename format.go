@@ -0,0 +1,54 @@
+package waveparser
+
+import "fmt"
+
+type (
+	// AudioFormat is the format code of the fmt chunk.
+	AudioFormat uint16
+)
+
+const (
+	WaveFormatPCM        AudioFormat = 0x0001
+	WaveFormatIEEEFloat  AudioFormat = 0x0003
+	WaveFormatALAW       AudioFormat = 0x0006
+	WaveFormatMULAW      AudioFormat = 0x0007
+	WaveFormatIMAADPCM   AudioFormat = 0x0011
+	WaveFormatG726ITU    AudioFormat = 0x0045 // codewords packed from the most significant bit
+	WaveFormatG726ADPCM  AudioFormat = 0x0064 // codewords packed from the least significant bit
+	WaveFormatExtensible AudioFormat = 0xFFFE
+)
+
+// formatNames holds the identifier (String) and the human readable name
+// (Name) of the formats defined here.
+var formatNames = map[AudioFormat]struct{ id, name string }{
+	WaveFormatPCM:        {"PCM", "PCM"},
+	WaveFormatIEEEFloat:  {"IEEE_FLOAT", "IEEE float"},
+	WaveFormatALAW:       {"ALAW", "A-law"},
+	WaveFormatMULAW:      {"MULAW", "µ-law"},
+	WaveFormatIMAADPCM:   {"IMA_ADPCM", "IMA ADPCM"},
+	WaveFormatG726ITU:    {"G726_ITU", "G.726 ADPCM"},
+	WaveFormatG726ADPCM:  {"G726_ADPCM", "G.726 ADPCM"},
+	WaveFormatExtensible: {"EXTENSIBLE", "extensible"},
+}
+
+func (f AudioFormat) String() string {
+	if names, ok := formatNames[f]; ok {
+		return names.id
+	}
+	return fmt.Sprintf("AudioFormat(%#04x)", uint16(f))
+}
+
+// Name is the human readable name of the format, "unknown" for the
+// ones not defined here.
+func (f AudioFormat) Name() string {
+	if names, ok := formatNames[f]; ok {
+		return names.name
+	}
+	return "unknown"
+}
+
+// Known reports whether f is one of the formats defined here.
+func (f AudioFormat) Known() bool {
+	_, ok := formatNames[f]
+	return ok
+}
@@ -0,0 +1,31 @@
+package waveparser
+
+import "testing"
+
+func TestAudioFormatString(t *testing.T) {
+	type tcase struct {
+		format AudioFormat
+		str    string
+		name   string
+		known  bool
+	}
+
+	for _, tcase := range []tcase{
+		{format: WaveFormatPCM, str: "PCM", name: "PCM", known: true},
+		{format: WaveFormatIEEEFloat, str: "IEEE_FLOAT", name: "IEEE float", known: true},
+		{format: WaveFormatALAW, str: "ALAW", name: "A-law", known: true},
+		{format: WaveFormatMULAW, str: "MULAW", name: "µ-law", known: true},
+		{format: WaveFormatExtensible, str: "EXTENSIBLE", name: "extensible", known: true},
+		{format: 0x55, str: "AudioFormat(0x0055)", name: "unknown", known: false},
+	} {
+		if tcase.format.String() != tcase.str {
+			t.Errorf("expected [%s], got [%s]", tcase.str, tcase.format)
+		}
+		if tcase.format.Name() != tcase.name {
+			t.Errorf("[%s]: expected name [%s], got [%s]", tcase.str, tcase.name, tcase.format.Name())
+		}
+		if tcase.format.Known() != tcase.known {
+			t.Errorf("[%s]: expected known[%t]", tcase.str, tcase.known)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package waveparser
+
+// G.711 companding, ported from the Sun Microsystems reference
+// implementation (g711.c).
+
+var (
+	alawSegEnd = [8]int16{0x1F, 0x3F, 0x7F, 0xFF, 0x1FF, 0x3FF, 0x7FF, 0xFFF}
+	ulawSegEnd = [8]int16{0x3F, 0x7F, 0xFF, 0x1FF, 0x3FF, 0x7FF, 0xFFF, 0x1FFF}
+)
+
+const (
+	ulawBias = 0x84
+	ulawClip = 8159
+)
+
+func segment(val int16, table [8]int16) int {
+	for i, end := range table {
+		if val <= end {
+			return i
+		}
+	}
+	return len(table)
+}
+
+func alawToLinear(a byte) int16 {
+	a ^= 0x55
+	t := int16(a&0x0f) << 4
+	seg := (a & 0x70) >> 4
+	switch seg {
+	case 0:
+		t += 8
+	case 1:
+		t += 0x108
+	default:
+		t += 0x108
+		t <<= seg - 1
+	}
+	if a&0x80 != 0 {
+		return t
+	}
+	return -t
+}
+
+func linearToAlaw(pcm int16) byte {
+	var mask byte
+	pcm >>= 3
+	if pcm >= 0 {
+		mask = 0xD5
+	} else {
+		mask = 0x55
+		pcm = -pcm - 1
+	}
+
+	seg := segment(pcm, alawSegEnd)
+	if seg >= 8 {
+		return 0x7F ^ mask
+	}
+
+	aval := byte(seg) << 4
+	if seg < 2 {
+		aval |= byte(pcm>>1) & 0x0f
+	} else {
+		aval |= byte(pcm>>uint(seg)) & 0x0f
+	}
+	return aval ^ mask
+}
+
+func ulawToLinear(u byte) int16 {
+	u = ^u
+	t := (int16(u&0x0f) << 3) + ulawBias
+	t <<= (u & 0x70) >> 4
+	if u&0x80 != 0 {
+		return ulawBias - t
+	}
+	return t - ulawBias
+}
+
+func linearToUlaw(pcm int16) byte {
+	var mask byte
+	pcm >>= 2
+	if pcm < 0 {
+		pcm = -pcm
+		mask = 0x7F
+	} else {
+		mask = 0xFF
+	}
+	if pcm > ulawClip {
+		pcm = ulawClip
+	}
+	pcm += ulawBias >> 2
+
+	seg := segment(pcm, ulawSegEnd)
+	if seg >= 8 {
+		return 0x7F ^ mask
+	}
+
+	uval := byte(seg)<<4 | byte(pcm>>uint(seg+1))&0x0f
+	return uval ^ mask
+}
@@ -0,0 +1,561 @@
+package waveparser
+
+import "fmt"
+
+// G.726 ADPCM, ported from the Sun Microsystems reference
+// implementation (g72x.c, g721.c, g723_24.c, g723_40.c) plus the
+// 16 kbit/s tables of the 2-bit coder.
+
+type (
+	g726State struct {
+		yl  int      // locked or steady state step size multiplier
+		yu  int16    // unlocked or non-steady state step size multiplier
+		dms int16    // short term energy estimate
+		dml int16    // long term energy estimate
+		ap  int16    // linear weighting coefficient of yl and yu
+		a   [2]int16 // coefficients of the pole portion of the predictor
+		b   [6]int16 // coefficients of the zero portion of the predictor
+		pk  [2]int16 // signs of the last two partially reconstructed samples
+		dq  [6]int16 // last quantized differences, internal float format
+		sr  [2]int16 // last reconstructed samples, internal float format
+		td  int16    // delayed tone detect
+	}
+
+	// g726Rate has the tables of one of the G.726 bit rates.
+	g726Rate struct {
+		bits    uint
+		qtab    []int
+		dqlntab []int
+		witab   []int
+		fitab   []int
+		dqMask  int
+	}
+)
+
+var g726Power2 = []int{
+	1, 2, 4, 8, 0x10, 0x20, 0x40, 0x80,
+	0x100, 0x200, 0x400, 0x800, 0x1000, 0x2000, 0x4000,
+}
+
+var g726Rates = map[uint16]*g726Rate{
+	2: {
+		bits:    2,
+		qtab:    []int{261},
+		dqlntab: []int{116, 365, 365, 116},
+		witab:   []int{-704, 14048, 14048, -704},
+		fitab:   []int{0, 0xE00, 0xE00, 0},
+		dqMask:  0x3FFF,
+	},
+	3: {
+		bits:    3,
+		qtab:    []int{8, 218, 331},
+		dqlntab: []int{-2048, 135, 273, 373, 373, 273, 135, -2048},
+		witab:   []int{-128, 960, 4384, 18624, 18624, 4384, 960, -128},
+		fitab:   []int{0, 0x200, 0x400, 0xE00, 0xE00, 0x400, 0x200, 0},
+		dqMask:  0x3FFF,
+	},
+	4: {
+		bits:    4,
+		qtab:    []int{-124, 80, 178, 246, 300, 349, 400},
+		dqlntab: []int{-2048, 4, 135, 213, 273, 323, 373, 425, 425, 373, 323, 273, 213, 135, 4, -2048},
+		// g721.c tables scaled by 32, as the other rates
+		witab: []int{
+			-384, 576, 1312, 2048, 3584, 6336, 11360, 35904,
+			35904, 11360, 6336, 3584, 2048, 1312, 576, -384,
+		},
+		fitab:  []int{0, 0, 0, 0x200, 0x200, 0x200, 0x600, 0xE00, 0xE00, 0x600, 0x200, 0x200, 0x200, 0, 0, 0},
+		dqMask: 0x3FFF,
+	},
+	5: {
+		bits: 5,
+		qtab: []int{-122, -16, 68, 139, 198, 250, 298, 339, 378, 413, 445, 475, 502, 528, 553},
+		dqlntab: []int{
+			-2048, -66, 28, 104, 169, 224, 274, 318, 358, 395, 429, 459, 488, 514, 539, 566,
+			566, 539, 514, 488, 459, 429, 395, 358, 318, 274, 224, 169, 104, 28, -66, -2048,
+		},
+		witab: []int{
+			448, 448, 768, 1248, 1280, 1312, 1856, 3200, 4512, 5728, 7008, 8960, 11456, 14080, 16928, 22272,
+			22272, 16928, 14080, 11456, 8960, 7008, 5728, 4512, 3200, 1856, 1312, 1280, 1248, 768, 448, 448,
+		},
+		fitab: []int{
+			0, 0, 0, 0, 0, 0x200, 0x200, 0x200, 0x200, 0x200, 0x400, 0x600, 0x800, 0xA00, 0xC00, 0xC00,
+			0xC00, 0xC00, 0xA00, 0x800, 0x600, 0x400, 0x200, 0x200, 0x200, 0x200, 0x200, 0, 0, 0, 0, 0,
+		},
+		dqMask: 0x7FFF,
+	},
+}
+
+func isG726(f AudioFormat) bool {
+	return f == WaveFormatG726ITU || f == WaveFormatG726ADPCM
+}
+
+func newG726State() *g726State {
+	return &g726State{
+		yl: 34816,
+		yu: 544,
+		sr: [2]int16{32, 32},
+		dq: [6]int16{32, 32, 32, 32, 32, 32},
+	}
+}
+
+func g726RateOf(f RiffChunkFmt) (*g726Rate, error) {
+	rate, ok := g726Rates[f.BitsPerSample]
+	if !ok {
+		return nil, fmt.Errorf("unsupported G.726 bits per sample[%d]", f.BitsPerSample)
+	}
+	if f.NumChannels == 0 {
+		return nil, fmt.Errorf("invalid number of channels[%d]", f.NumChannels)
+	}
+	return rate, nil
+}
+
+// decodeG726 decodes packed G.726 codewords, interleaved by channel.
+func decodeG726(f RiffChunkFmt, data []byte) ([]float64, error) {
+	rate, err := g726RateOf(f)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := int(f.NumChannels)
+	states := make([]*g726State, channels)
+	for i := range states {
+		states[i] = newG726State()
+	}
+
+	codes := unpackCodewords(data, rate.bits, f.AudioFormat == WaveFormatG726ITU)
+	codes = codes[:len(codes)-len(codes)%channels]
+
+	samples := make([]float64, len(codes))
+	for i, code := range codes {
+		samples[i] = float64(states[i%channels].decode(rate, code)) / (1 << 15)
+	}
+	return samples, nil
+}
+
+// encodeG726 encodes interleaved samples into packed G.726 codewords.
+func encodeG726(f RiffChunkFmt, samples []float64) ([]byte, error) {
+	rate, err := g726RateOf(f)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := int(f.NumChannels)
+	states := make([]*g726State, channels)
+	for i := range states {
+		states[i] = newG726State()
+	}
+
+	codes := make([]int, len(samples))
+	for i, sample := range samples {
+		codes[i] = states[i%channels].encode(rate, int(quantize(sample, 16)))
+	}
+	return packCodewords(codes, rate.bits, f.AudioFormat == WaveFormatG726ITU), nil
+}
+
+// unpackCodewords splits data into codewords of the given width. The
+// first codeword is on the least significant bits of each byte
+// (RFC 3551) unless msbFirst.
+func unpackCodewords(data []byte, bits uint, msbFirst bool) []int {
+	codes := make([]int, 0, len(data)*8/int(bits))
+	mask := 1<<bits - 1
+
+	var acc, accBits uint
+	for _, b := range data {
+		if msbFirst {
+			acc = acc<<8 | uint(b)
+		} else {
+			acc |= uint(b) << accBits
+		}
+		accBits += 8
+
+		for accBits >= bits {
+			accBits -= bits
+			if msbFirst {
+				codes = append(codes, int(acc>>accBits)&mask)
+			} else {
+				codes = append(codes, int(acc)&mask)
+				acc >>= bits
+			}
+		}
+		if msbFirst {
+			acc &= 1<<accBits - 1
+		}
+	}
+	return codes
+}
+
+// packCodewords is the inverse of unpackCodewords, a trailing partial
+// byte is padded with zeros.
+func packCodewords(codes []int, bits uint, msbFirst bool) []byte {
+	data := make([]byte, 0, (len(codes)*int(bits)+7)/8)
+
+	var acc, accBits uint
+	for _, code := range codes {
+		if msbFirst {
+			acc = acc<<bits | uint(code)
+		} else {
+			acc |= uint(code) << accBits
+		}
+		accBits += bits
+
+		for accBits >= 8 {
+			accBits -= 8
+			if msbFirst {
+				data = append(data, byte(acc>>accBits))
+			} else {
+				data = append(data, byte(acc))
+				acc >>= 8
+			}
+		}
+		if msbFirst {
+			acc &= 1<<accBits - 1
+		}
+	}
+	if accBits > 0 {
+		if msbFirst {
+			data = append(data, byte(acc<<(8-accBits)))
+		} else {
+			data = append(data, byte(acc))
+		}
+	}
+	return data
+}
+
+// decode decodes one codeword into a 16 bits linear sample.
+func (s *g726State) decode(rate *g726Rate, code int) int {
+	code &= 1<<rate.bits - 1
+	sign := code & (1 << (rate.bits - 1))
+
+	sezi := s.predictorZero()
+	sez := sezi >> 1
+	se := (sezi + s.predictorPole()) >> 1
+	y := s.stepSize()
+
+	dq := g726Reconstruct(sign, rate.dqlntab[code], y)
+	sr := se + dq
+	if dq < 0 {
+		sr = se - (dq & rate.dqMask)
+	}
+	dqsez := sr - se + sez
+
+	s.update(rate.bits, y, rate.witab[code], rate.fitab[code], dq, sr, dqsez)
+
+	// sr has 14 bits of dynamic range
+	linear := sr << 2
+	if linear > 32767 {
+		linear = 32767
+	} else if linear < -32768 {
+		linear = -32768
+	}
+	return linear
+}
+
+// encode encodes a 16 bits linear sample into a codeword.
+func (s *g726State) encode(rate *g726Rate, sl int) int {
+	sl >>= 2
+
+	sezi := s.predictorZero()
+	sez := sezi >> 1
+	se := (sezi + s.predictorPole()) >> 1
+	d := sl - se
+	y := s.stepSize()
+
+	code := g726Quantize(d, y, rate.qtab)
+	// the 2 bits quantizer has a single level per sign, the zero
+	// region code is only valid for negative differences
+	if rate.bits == 2 && code == 3 && d&0x8000 == 0 {
+		code = 0
+	}
+	sign := code & (1 << (rate.bits - 1))
+
+	dq := g726Reconstruct(sign, rate.dqlntab[code], y)
+	sr := se + dq
+	if dq < 0 {
+		sr = se - (dq & rate.dqMask)
+	}
+	dqsez := sr + sez - se
+
+	s.update(rate.bits, y, rate.witab[code], rate.fitab[code], dq, sr, dqsez)
+	return code
+}
+
+func g726Quan(val int, table []int) int {
+	i := 0
+	for ; i < len(table); i++ {
+		if val < table[i] {
+			break
+		}
+	}
+	return i
+}
+
+func g726Fmult(an, srn int) int {
+	anmag := an
+	if an <= 0 {
+		anmag = (-an) & 0x1FFF
+	}
+	anexp := g726Quan(anmag, g726Power2) - 6
+
+	var anmant int
+	switch {
+	case anmag == 0:
+		anmant = 32
+	case anexp >= 0:
+		anmant = anmag >> uint(anexp)
+	default:
+		anmant = anmag << uint(-anexp)
+	}
+
+	wanexp := anexp + ((srn >> 6) & 0xF) - 13
+	wanmant := (anmant*(srn&0x3F) + 0x30) >> 4
+
+	var retval int
+	if wanexp >= 0 {
+		retval = (wanmant << uint(wanexp)) & 0x7FFF
+	} else {
+		retval = wanmant >> uint(-wanexp)
+	}
+
+	if (an ^ srn) < 0 {
+		return -retval
+	}
+	return retval
+}
+
+func (s *g726State) predictorZero() int {
+	sezi := 0
+	for i := range s.b {
+		sezi += g726Fmult(int(s.b[i])>>2, int(s.dq[i]))
+	}
+	return sezi
+}
+
+func (s *g726State) predictorPole() int {
+	return g726Fmult(int(s.a[1])>>2, int(s.sr[1])) +
+		g726Fmult(int(s.a[0])>>2, int(s.sr[0]))
+}
+
+func (s *g726State) stepSize() int {
+	if s.ap >= 256 {
+		return int(s.yu)
+	}
+
+	y := s.yl >> 6
+	dif := int(s.yu) - y
+	al := int(s.ap) >> 2
+	if dif > 0 {
+		y += (dif * al) >> 6
+	} else if dif < 0 {
+		y += (dif*al + 0x3F) >> 6
+	}
+	return y
+}
+
+func g726Quantize(d, y int, table []int) int {
+	dqm := d
+	if dqm < 0 {
+		dqm = -dqm
+	}
+	exp := g726Quan(dqm>>1, g726Power2)
+	mant := ((dqm << 7) >> uint(exp)) & 0x7F
+	dl := (exp << 7) + mant
+	dln := dl - (y >> 2)
+
+	size := len(table)
+	i := g726Quan(dln, table)
+	switch {
+	case d < 0:
+		return (size << 1) + 1 - i
+	case i == 0:
+		return (size << 1) + 1
+	}
+	return i
+}
+
+func g726Reconstruct(sign, dqln, y int) int {
+	dql := dqln + (y >> 2)
+	if dql < 0 {
+		if sign != 0 {
+			return -0x8000
+		}
+		return 0
+	}
+
+	dex := (dql >> 7) & 15
+	dqt := 128 + (dql & 127)
+	dq := (dqt << 7) >> uint(14-dex)
+	if sign != 0 {
+		return dq - 0x8000
+	}
+	return dq
+}
+
+// g726Float converts a magnitude into the 4 bits exponent, 6 bits
+// mantissa format of the predictor, negative values are offset.
+func g726Float(mag int, negative bool) int16 {
+	exp := g726Quan(mag, g726Power2)
+	v := (exp << 6) + ((mag << 6) >> uint(exp))
+	if negative {
+		v -= 0x400
+	}
+	return int16(v)
+}
+
+func (s *g726State) update(codeSize uint, y, wi, fi, dq, sr, dqsez int) {
+	pk0 := int16(0)
+	if dqsez < 0 {
+		pk0 = 1
+	}
+
+	mag := dq & 0x7FFF
+
+	// TRANS
+	ylint := uint(s.yl >> 15)
+	ylfrac := (s.yl >> 10) & 0x1F
+	thr1 := (32 + ylfrac) << ylint
+	thr2 := thr1
+	if ylint > 9 {
+		thr2 = 31 << 10
+	}
+	dqthr := (thr2 + (thr2 >> 1)) >> 1
+	tr := s.td != 0 && mag > dqthr
+
+	// quantizer scale factor adaptation
+	yu := y + ((wi - y) >> 5)
+	if yu < 544 {
+		yu = 544
+	} else if yu > 5120 {
+		yu = 5120
+	}
+	s.yu = int16(yu)
+	s.yl += yu + ((-s.yl) >> 6)
+
+	// adaptive predictor coefficients
+	a2p := 0
+	if tr {
+		s.a = [2]int16{}
+		s.b = [6]int16{}
+	} else {
+		pks1 := pk0 ^ s.pk[0]
+
+		a2p = int(s.a[1]) - (int(s.a[1]) >> 7)
+		if dqsez != 0 {
+			fa1 := -int(s.a[0])
+			if pks1 != 0 {
+				fa1 = int(s.a[0])
+			}
+			if fa1 < -8191 {
+				a2p -= 0x100
+			} else if fa1 > 8191 {
+				a2p += 0xFF
+			} else {
+				a2p += fa1 >> 5
+			}
+
+			if pk0^s.pk[1] != 0 {
+				if a2p <= -12160 {
+					a2p = -12288
+				} else if a2p >= 12416 {
+					a2p = 12288
+				} else {
+					a2p -= 0x80
+				}
+			} else if a2p <= -12416 {
+				a2p = -12288
+			} else if a2p >= 12160 {
+				a2p = 12288
+			} else {
+				a2p += 0x80
+			}
+		}
+		s.a[1] = int16(a2p)
+
+		a0 := int(s.a[0])
+		a0 -= a0 >> 8
+		if dqsez != 0 {
+			if pks1 == 0 {
+				a0 += 192
+			} else {
+				a0 -= 192
+			}
+		}
+		a1ul := 15360 - a2p
+		if a0 < -a1ul {
+			a0 = -a1ul
+		} else if a0 > a1ul {
+			a0 = a1ul
+		}
+		s.a[0] = int16(a0)
+
+		for i := range s.b {
+			b := int(s.b[i])
+			if codeSize == 5 {
+				b -= b >> 9
+			} else {
+				b -= b >> 8
+			}
+			if dq&0x7FFF != 0 {
+				if (dq ^ int(s.dq[i])) >= 0 {
+					b += 128
+				} else {
+					b -= 128
+				}
+			}
+			s.b[i] = int16(b)
+		}
+	}
+
+	copy(s.dq[1:], s.dq[:5])
+	if mag == 0 {
+		if dq >= 0 {
+			s.dq[0] = 0x20
+		} else {
+			s.dq[0] = -992 // 0xFC20
+		}
+	} else {
+		s.dq[0] = g726Float(mag, dq < 0)
+	}
+
+	s.sr[1] = s.sr[0]
+	switch {
+	case sr == 0:
+		s.sr[0] = 0x20
+	case sr > 0:
+		s.sr[0] = g726Float(sr, false)
+	case sr > -32768:
+		s.sr[0] = g726Float(-sr, true)
+	default:
+		s.sr[0] = -992 // 0xFC20
+	}
+
+	s.pk[1] = s.pk[0]
+	s.pk[0] = pk0
+
+	// TONE
+	if tr {
+		s.td = 0
+	} else if a2p < -11776 {
+		s.td = 1
+	} else {
+		s.td = 0
+	}
+
+	// adaptation speed control
+	s.dms += int16((fi - int(s.dms)) >> 5)
+	s.dml += int16(((fi << 2) - int(s.dml)) >> 7)
+
+	ap := int(s.ap)
+	diff := (int(s.dms) << 2) - int(s.dml)
+	if diff < 0 {
+		diff = -diff
+	}
+	switch {
+	case tr:
+		ap = 256
+	case y < 1536, s.td == 1, diff >= int(s.dml)>>3:
+		ap += (0x200 - ap) >> 4
+	default:
+		ap += (-ap) >> 4
+	}
+	s.ap = int16(ap)
+}
@@ -0,0 +1,81 @@
+package waveparser
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestG726RoundTrip(t *testing.T) {
+	const rate = 8000
+
+	samples := make([]float64, rate)
+	for i := range samples {
+		samples[i] = 0.5 * math.Sin(2*math.Pi*400*float64(i)/rate)
+	}
+
+	type tcase struct {
+		bits   uint16
+		minSNR float64
+	}
+
+	for _, format := range []AudioFormat{WaveFormatG726ADPCM, WaveFormatG726ITU} {
+		for _, tcase := range []tcase{
+			{bits: 2, minSNR: 15},
+			{bits: 3, minSNR: 25},
+			{bits: 4, minSNR: 30},
+			{bits: 5, minSNR: 35},
+		} {
+			wav := New(format, 1, rate, tcase.bits)
+			assertNoError(t, wav.SetSamples(samples))
+
+			expectedSize := len(samples) * int(tcase.bits) / 8
+			if len(wav.Data) != expectedSize {
+				t.Fatalf("[%s/%d]: expected [%d] bytes, got [%d]", format, tcase.bits, expectedSize, len(wav.Data))
+			}
+
+			buf := &bytes.Buffer{}
+			_, err := wav.WriteTo(buf)
+			assertNoError(t, err)
+
+			parsed, err := ParseBytes(buf.Bytes())
+			assertNoError(t, err)
+
+			got, err := parsed.Samples()
+			assertNoError(t, err)
+			if len(got) != len(samples) {
+				t.Fatalf("[%s/%d]: expected [%d] samples, got [%d]", format, tcase.bits, len(samples), len(got))
+			}
+
+			// skip the adaptation at the start
+			var signal, noise float64
+			for i := 200; i < len(samples); i++ {
+				signal += samples[i] * samples[i]
+				noise += (samples[i] - got[i]) * (samples[i] - got[i])
+			}
+			snr := 10 * math.Log10(signal/noise)
+			if snr < tcase.minSNR {
+				t.Fatalf("[%s/%d]: expected SNR >= [%f], got [%f]", format, tcase.bits, tcase.minSNR, snr)
+			}
+		}
+	}
+}
+
+func TestCodewordPacking(t *testing.T) {
+	codes := []int{1, 5, 7, 0, 3, 2, 6, 4}
+
+	for _, msbFirst := range []bool{false, true} {
+		packed := packCodewords(codes, 3, msbFirst)
+		if len(packed) != 3 {
+			t.Fatalf("expected [3] bytes, got [%d]", len(packed))
+		}
+		if got := unpackCodewords(packed, 3, msbFirst); !reflect.DeepEqual(got, codes) {
+			t.Fatalf("msbFirst[%t]: expected %v, got %v", msbFirst, codes, got)
+		}
+	}
+
+	// RFC 3551 packing puts the first codeword on the low bits
+	assertBytesEqual(t, []byte{0x21}, packCodewords([]int{1, 2}, 4, false))
+	assertBytesEqual(t, []byte{0x12}, packCodewords([]int{1, 2}, 4, true))
+}
@@ -0,0 +1,35 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+)
+
+// Gain amplifies (or attenuates, for negative values) the audio by the
+// given amount of dB. Samples pushed beyond full scale are clipped on
+// integer formats.
+func (w *Wav) Gain(db float64) error {
+	samples, err := w.Samples()
+	if err != nil {
+		return err
+	}
+
+	factor := math.Pow(10, db/20)
+	for i := range samples {
+		samples[i] *= factor
+	}
+	return w.SetSamples(samples)
+}
+
+// NormalizePeak applies the gain that brings the sample peak to
+// the target level, in dBFS.
+func (w *Wav) NormalizePeak(target float64) error {
+	stats, err := w.Stats()
+	if err != nil {
+		return err
+	}
+	if math.IsInf(stats.Peak, -1) {
+		return fmt.Errorf("can't normalize digital silence")
+	}
+	return w.Gain(target - stats.Peak)
+}
@@ -0,0 +1,34 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGain(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 1, 8000, 32)
+	assertNoError(t, wav.SetSamples([]float64{0.1, -0.2, 0.4}))
+
+	assertNoError(t, wav.Gain(20*math.Log10(2)))
+
+	samples, err := wav.Samples()
+	assertNoError(t, err)
+	assertSamplesClose(t, []float64{0.2, -0.4, 0.8}, samples, 1e-6)
+}
+
+func TestNormalizePeak(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.1, -0.25, 0.2}))
+
+	assertNoError(t, wav.NormalizePeak(-6))
+
+	stats, err := wav.Stats()
+	assertNoError(t, err)
+	if math.Abs(stats.Peak+6) > 0.01 {
+		t.Fatalf("expected peak at -6 dBFS, got %f", stats.Peak)
+	}
+
+	silence := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, silence.SetSamples(make([]float64, 10)))
+	assertError(t, silence.NormalizePeak(-6))
+}
@@ -0,0 +1,65 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// NoiseGate silences the audio whose level stays below the threshold
+// (dBFS), like background noise between utterances. The gate opens
+// over attack once the level crosses the threshold and closes over
+// release after it falls below it. Channels are gated together.
+func (w *Wav) NoiseGate(thresholdDB float64, attack, release time.Duration) error {
+	format := w.Header.RIFFChunkFmt
+	channels := int(format.NumChannels)
+	if channels == 0 || format.SampleRate == 0 {
+		return fmt.Errorf("can't gate channels[%d] samplerate[%d]", format.NumChannels, format.SampleRate)
+	}
+
+	samples, err := w.Samples()
+	if err != nil {
+		return err
+	}
+
+	threshold := math.Pow(10, thresholdDB/20)
+	rate := float64(format.SampleRate)
+	open, close := smoothing(attack, rate), smoothing(release, rate)
+
+	// the envelope decays over release, holding the gate open between
+	// the peaks of the waveform
+	decay := 1 - close
+	var envelope, gain float64
+	for i := 0; i+channels <= len(samples); i += channels {
+		frame := samples[i : i+channels]
+		envelope = math.Max(frameLevel(frame), envelope*decay)
+
+		if envelope >= threshold {
+			gain += (1 - gain) * open
+		} else {
+			gain -= gain * close
+		}
+		for c := range frame {
+			frame[c] *= gain
+		}
+	}
+	return w.SetSamples(samples)
+}
+
+// smoothing returns the per sample coefficient of a one pole filter
+// reaching about 63% of a step after d. Zero durations are instant.
+func smoothing(d time.Duration, rate float64) float64 {
+	if d <= 0 {
+		return 1
+	}
+	return 1 - math.Exp(-1/(d.Seconds()*rate))
+}
+
+// frameLevel is the highest magnitude among the samples of a frame.
+func frameLevel(frame []float64) float64 {
+	var level float64
+	for _, s := range frame {
+		level = math.Max(level, math.Abs(s))
+	}
+	return level
+}
@@ -0,0 +1,42 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNoiseGate(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 2, 8000, 64)
+
+	// noise at -50 dBFS, a tone at -6 dBFS in the middle second
+	samples := make([]float64, 2*3*8000)
+	for i := 0; i < len(samples)/2; i++ {
+		s := 0.003 * math.Sin(float64(i)*2.1)
+		if i >= 8000 && i < 16000 {
+			s = 0.5 * math.Sin(2*math.Pi*200*float64(i)/8000)
+		}
+		samples[2*i], samples[2*i+1] = s, s
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	assertNoError(t, wav.NoiseGate(-40, time.Millisecond, 50*time.Millisecond))
+	gated, err := wav.Samples()
+	assertNoError(t, err)
+
+	peak := func(from, to int) float64 {
+		return frameLevel(gated[2*from : 2*to])
+	}
+	if p := peak(0, 8000); p > 1e-6 {
+		t.Fatalf("expected noise before the tone gated, peak %f", p)
+	}
+	if p := peak(8100, 16000); math.Abs(p-0.5) > 1e-3 {
+		t.Fatalf("expected tone kept, peak %f", p)
+	}
+	// the envelope falls under the threshold ~200ms after the tone
+	if p := peak(16000+4000, 24000); p > 1e-4 {
+		t.Fatalf("expected noise after the release gated, peak %f", p)
+	}
+
+	assertError(t, New(WaveFormatPCM, 0, 8000, 16).NoiseGate(-40, 0, 0))
+}
@@ -0,0 +1,87 @@
+// Package generate synthesizes deterministic test signals as Wavs.
+package generate
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+// Silence returns d of digital silence.
+func Silence(format waveparser.RiffChunkFmt, d time.Duration) (*waveparser.Wav, error) {
+	return render(format, d, func(int) float64 {
+		return 0
+	})
+}
+
+// Sine returns a sine tone of the given frequency (Hz) and
+// amplitude (0 to 1, relative to full scale).
+func Sine(format waveparser.RiffChunkFmt, freq, amplitude float64, d time.Duration) (*waveparser.Wav, error) {
+	rate := float64(format.SampleRate)
+	return render(format, d, func(i int) float64 {
+		return amplitude * math.Sin(2*math.Pi*freq*float64(i)/rate)
+	})
+}
+
+// WhiteNoise returns uniformly distributed noise. The same seed always
+// produces the same audio.
+func WhiteNoise(format waveparser.RiffChunkFmt, amplitude float64, d time.Duration, seed int64) (*waveparser.Wav, error) {
+	rnd := rand.New(rand.NewSource(seed))
+	return render(format, d, func(int) float64 {
+		return amplitude * (2*rnd.Float64() - 1)
+	})
+}
+
+// Sweep returns an exponential sine sweep going from one frequency (Hz)
+// to the other over d.
+func Sweep(format waveparser.RiffChunkFmt, from, to, amplitude float64, d time.Duration) (*waveparser.Wav, error) {
+	if from <= 0 || to <= 0 {
+		return nil, fmt.Errorf("invalid sweep range: from[%f] to[%f]", from, to)
+	}
+
+	rate := float64(format.SampleRate)
+	length := d.Seconds()
+	k := math.Log(to / from)
+
+	return render(format, d, func(i int) float64 {
+		t := float64(i) / rate
+		var phase float64
+		if k == 0 {
+			phase = 2 * math.Pi * from * t
+		} else {
+			phase = 2 * math.Pi * from * length / k * (math.Exp(t/length*k) - 1)
+		}
+		return amplitude * math.Sin(phase)
+	})
+}
+
+// render builds a Wav with the same signal on every channel.
+func render(format waveparser.RiffChunkFmt, d time.Duration, signal func(i int) float64) (*waveparser.Wav, error) {
+	if format.NumChannels == 0 || format.SampleRate == 0 {
+		return nil, fmt.Errorf(
+			"invalid format: channels[%d] samplerate[%d]",
+			format.NumChannels,
+			format.SampleRate,
+		)
+	}
+
+	channels := int(format.NumChannels)
+	frames := int(d.Seconds() * float64(format.SampleRate))
+
+	samples := make([]float64, frames*channels)
+	for i := 0; i < frames; i++ {
+		v := signal(i)
+		for c := 0; c < channels; c++ {
+			samples[i*channels+c] = v
+		}
+	}
+
+	wav := waveparser.New(format.AudioFormat, format.NumChannels, format.SampleRate, format.BitsPerSample)
+	if err := wav.SetSamples(samples); err != nil {
+		return nil, err
+	}
+	return wav, nil
+}
@@ -0,0 +1,85 @@
+package generate
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+var float32Mono = waveparser.New(waveparser.WaveFormatIEEEFloat, 1, 8000, 32).Header.RIFFChunkFmt
+
+func TestSine(t *testing.T) {
+	wav, err := Sine(float32Mono, 100, 0.5, time.Second)
+	assertNoError(t, err)
+
+	samples, err := wav.Samples()
+	assertNoError(t, err)
+
+	if len(samples) != 8000 {
+		t.Fatalf("expected 8000 samples, got %d", len(samples))
+	}
+
+	var peak float64
+	crossings := 0
+	for i, s := range samples {
+		peak = math.Max(peak, math.Abs(s))
+		if i > 0 && samples[i-1] < 0 && s >= 0 {
+			crossings++
+		}
+	}
+
+	if math.Abs(peak-0.5) > 0.001 {
+		t.Fatalf("expected peak[0.5], got[%f]", peak)
+	}
+	if crossings < 99 || crossings > 100 {
+		t.Fatalf("expected ~100 cycles, got %d", crossings)
+	}
+}
+
+func TestWhiteNoiseIsDeterministic(t *testing.T) {
+	a, err := WhiteNoise(float32Mono, 1, 100*time.Millisecond, 42)
+	assertNoError(t, err)
+	b, err := WhiteNoise(float32Mono, 1, 100*time.Millisecond, 42)
+	assertNoError(t, err)
+	c, err := WhiteNoise(float32Mono, 1, 100*time.Millisecond, 43)
+	assertNoError(t, err)
+
+	if !bytes.Equal(a.Data, b.Data) {
+		t.Fatal("same seed produced different noise")
+	}
+	if bytes.Equal(a.Data, c.Data) {
+		t.Fatal("different seeds produced the same noise")
+	}
+}
+
+func TestSilenceAndChannels(t *testing.T) {
+	stereo := waveparser.New(waveparser.WaveFormatPCM, 2, 8000, 16).Header.RIFFChunkFmt
+	wav, err := Silence(stereo, 10*time.Millisecond)
+	assertNoError(t, err)
+
+	if len(wav.Data) != 80*2*2 {
+		t.Fatalf("unexpected data size[%d]", len(wav.Data))
+	}
+	for i, b := range wav.Data {
+		if b != 0 {
+			t.Fatalf("byte[%d] isn't silent: %d", i, b)
+		}
+	}
+}
+
+func TestSweepRejectsInvalidRange(t *testing.T) {
+	_, err := Sweep(float32Mono, 0, 1000, 1, time.Second)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func assertNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,36 @@
+package waveparser
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+type (
+	// GlobFile is a file matched by GlobSeq, with the Wav loaded from
+	// it or the error loading it.
+	GlobFile struct {
+		Path string
+		Wav  *Wav
+		Err  error
+	}
+)
+
+// LoadGlob loads every WAV file matching the pattern (see
+// filepath.Match), in lexical order. It fails on the first file that
+// can't be loaded.
+func LoadGlob(pattern string, opts ...ParseOption) ([]*Wav, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	wavs := make([]*Wav, 0, len(paths))
+	for _, path := range paths {
+		wav, err := Load(path, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("error loading [%s]: %s", path, err)
+		}
+		wavs = append(wavs, wav)
+	}
+	return wavs, nil
+}
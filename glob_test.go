@@ -0,0 +1,29 @@
+package waveparser
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadGlob(t *testing.T) {
+	paths, err := filepath.Glob("./testdata/audios/*int16*.wav")
+	assertNoError(t, err)
+
+	wavs, err := LoadGlob("./testdata/audios/*int16*.wav")
+	assertNoError(t, err)
+	if len(paths) == 0 || len(wavs) != len(paths) {
+		t.Fatalf("expected %d wavs, got %d", len(paths), len(wavs))
+	}
+
+	wavs, err = LoadGlob("./testdata/nothing*.wav")
+	assertNoError(t, err)
+	if len(wavs) != 0 {
+		t.Fatalf("expected no wavs, got %d", len(wavs))
+	}
+
+	_, err = LoadGlob("./testdata/*.hdr.expected")
+	assertError(t, err)
+
+	_, err = LoadGlob("[")
+	assertError(t, err)
+}
@@ -0,0 +1,20 @@
+module github.com/NeowayLabs/waveparser
+
+go 1.25.0
+
+require (
+	github.com/ebitengine/oto/v3 v3.5.1
+	github.com/go-audio/audio v1.0.0
+	github.com/gordonklaus/portaudio v0.0.0-20260203164431-765aa7dfa631
+	golang.org/x/net v0.57.0
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	github.com/ebitengine/purego v0.11.0 // indirect
+	github.com/jfreymuth/pulse v0.1.3 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800 // indirect
+)
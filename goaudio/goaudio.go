@@ -0,0 +1,103 @@
+// Package goaudio converts between Wavs and the github.com/go-audio/audio
+// buffers, so parsed audio can be used with the go-audio transforms.
+package goaudio
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/NeowayLabs/waveparser"
+	"github.com/go-audio/audio"
+)
+
+const defaultBitDepth = 16
+
+// ToIntBuffer returns the samples of w as signed integers. PCM audio
+// keeps its bit depth, other encodings are converted to 16 bits.
+func ToIntBuffer(w *waveparser.Wav) (*audio.IntBuffer, error) {
+	samples, err := w.Samples()
+	if err != nil {
+		return nil, err
+	}
+
+	format := w.Header.RIFFChunkFmt
+	bitDepth := defaultBitDepth
+	if format.AudioFormat == waveparser.WaveFormatPCM {
+		bitDepth = int(format.BitsPerSample)
+	}
+
+	scale := float64(int64(1) << uint(bitDepth-1))
+	data := make([]int, len(samples))
+	for i, s := range samples {
+		data[i] = int(math.Max(-scale, math.Min(scale-1, math.Round(s*scale))))
+	}
+
+	return &audio.IntBuffer{
+		Format:         audioFormat(format),
+		Data:           data,
+		SourceBitDepth: bitDepth,
+	}, nil
+}
+
+// ToFloatBuffer returns the samples of w normalized to [-1, 1].
+func ToFloatBuffer(w *waveparser.Wav) (*audio.FloatBuffer, error) {
+	samples, err := w.Samples()
+	if err != nil {
+		return nil, err
+	}
+	return &audio.FloatBuffer{
+		Format: audioFormat(w.Header.RIFFChunkFmt),
+		Data:   samples,
+	}, nil
+}
+
+// FromIntBuffer builds a PCM Wav with the bit depth of the buffer
+// (16 bits when it isn't set).
+func FromIntBuffer(buf *audio.IntBuffer) (*waveparser.Wav, error) {
+	if err := validate(buf.Format); err != nil {
+		return nil, err
+	}
+
+	bitDepth := buf.SourceBitDepth
+	if bitDepth == 0 {
+		bitDepth = defaultBitDepth
+	}
+
+	scale := float64(int64(1) << uint(bitDepth-1))
+	samples := make([]float64, len(buf.Data))
+	for i, v := range buf.Data {
+		samples[i] = float64(v) / scale
+	}
+
+	return newWav(buf.Format, waveparser.WaveFormatPCM, uint16(bitDepth), samples)
+}
+
+// FromFloatBuffer builds a 32 bits IEEE float Wav.
+func FromFloatBuffer(buf *audio.FloatBuffer) (*waveparser.Wav, error) {
+	if err := validate(buf.Format); err != nil {
+		return nil, err
+	}
+	return newWav(buf.Format, waveparser.WaveFormatIEEEFloat, 32, buf.Data)
+}
+
+func audioFormat(f waveparser.RiffChunkFmt) *audio.Format {
+	return &audio.Format{
+		NumChannels: int(f.NumChannels),
+		SampleRate:  int(f.SampleRate),
+	}
+}
+
+func validate(f *audio.Format) error {
+	if f == nil || f.NumChannels <= 0 || f.SampleRate <= 0 {
+		return fmt.Errorf("buffer has an invalid format: %+v", f)
+	}
+	return nil
+}
+
+func newWav(f *audio.Format, audioFormat waveparser.AudioFormat, bits uint16, samples []float64) (*waveparser.Wav, error) {
+	wav := waveparser.New(audioFormat, uint16(f.NumChannels), uint32(f.SampleRate), bits)
+	if err := wav.SetSamples(samples); err != nil {
+		return nil, err
+	}
+	return wav, nil
+}
@@ -0,0 +1,62 @@
+package goaudio
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/NeowayLabs/waveparser"
+	"github.com/go-audio/audio"
+)
+
+func TestIntBufferRoundTrip(t *testing.T) {
+	wav := waveparser.New(waveparser.WaveFormatPCM, 2, 8000, 24)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5, 0.25, -1}))
+
+	buf, err := ToIntBuffer(wav)
+	assertNoError(t, err)
+
+	expected := []int{1 << 22, -(1 << 22), 1 << 21, -(1 << 23)}
+	if !reflect.DeepEqual(buf.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, buf.Data)
+	}
+	if buf.SourceBitDepth != 24 || buf.Format.NumChannels != 2 || buf.Format.SampleRate != 8000 {
+		t.Fatalf("unexpected buffer format: %+v depth[%d]", buf.Format, buf.SourceBitDepth)
+	}
+
+	back, err := FromIntBuffer(buf)
+	assertNoError(t, err)
+	if !bytes.Equal(back.Data, wav.Data) || back.Header.RIFFChunkFmt != wav.Header.RIFFChunkFmt {
+		t.Fatal("round trip changed the audio")
+	}
+}
+
+func TestFloatBufferRoundTrip(t *testing.T) {
+	buf := &audio.FloatBuffer{
+		Format: &audio.Format{NumChannels: 1, SampleRate: 16000},
+		Data:   []float64{0, 0.5, -0.75},
+	}
+
+	wav, err := FromFloatBuffer(buf)
+	assertNoError(t, err)
+
+	back, err := ToFloatBuffer(wav)
+	assertNoError(t, err)
+	if !reflect.DeepEqual(back, buf) {
+		t.Fatalf("expected %+v, got %+v", buf, back)
+	}
+}
+
+func TestInvalidFormat(t *testing.T) {
+	_, err := FromFloatBuffer(&audio.FloatBuffer{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func assertNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
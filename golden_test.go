@@ -0,0 +1,11 @@
+package waveparser_test
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/waveparser/wavtest"
+)
+
+func TestParseWAVHeaders(t *testing.T) {
+	wavtest.RunHeaderGolden(t, "testdata")
+}
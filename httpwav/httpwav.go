@@ -0,0 +1,73 @@
+// Package httpwav serves wave audio over HTTP, with byte range support
+// so browsers can seek within it.
+package httpwav
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+// Serve writes wav as a complete WAV file, honoring Range requests.
+func Serve(w http.ResponseWriter, r *http.Request, wav *waveparser.Wav) {
+	buf := &bytes.Buffer{}
+	if _, err := wav.WriteTo(buf); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", waveparser.ContentType(wav.Header))
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(buf.Bytes()))
+}
+
+// ServeFile serves the WAV file at path, honoring Range requests.
+// Files that aren't RIFF/WAVE are rejected.
+func ServeFile(w http.ResponseWriter, r *http.Request, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !waveparser.IsWav(f) {
+		http.Error(w, "not a WAV file", http.StatusUnsupportedMediaType)
+		return
+	}
+	hdr, err := waveparser.ParseHeader(f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", waveparser.ContentType(hdr))
+	http.ServeContent(w, r, filepath.Base(path), info.ModTime(), f)
+}
+
+// Handler returns a handler serving wav.
+func Handler(wav *waveparser.Wav) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Serve(w, r, wav)
+	})
+}
+
+// FileHandler returns a handler serving the WAV file at path.
+func FileHandler(path string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeFile(w, r, path)
+	})
+}
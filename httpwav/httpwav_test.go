@@ -0,0 +1,78 @@
+package httpwav
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+func TestServeRange(t *testing.T) {
+	wav := waveparser.New(waveparser.WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.1, 0.2, 0.3, 0.4}))
+
+	full := &bytes.Buffer{}
+	_, err := wav.WriteTo(full)
+	assertNoError(t, err)
+
+	server := httptest.NewServer(Handler(wav))
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	assertNoError(t, err)
+	req.Header.Set("Range", "bytes=44-47")
+
+	res, err := http.DefaultClient.Do(req)
+	assertNoError(t, err)
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	assertNoError(t, err)
+
+	if res.StatusCode != http.StatusPartialContent {
+		t.Fatalf("expected 206, got %d", res.StatusCode)
+	}
+	if res.Header.Get("Content-Type") != waveparser.ContentType(wav.Header) {
+		t.Fatalf("unexpected content type %s", res.Header.Get("Content-Type"))
+	}
+	if !bytes.Equal(body, full.Bytes()[44:48]) {
+		t.Fatalf("unexpected range contents %v", body)
+	}
+}
+
+func TestServeFile(t *testing.T) {
+	server := httptest.NewServer(FileHandler("../testdata/r.wav"))
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	assertNoError(t, err)
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK || res.ContentLength != 7496 {
+		t.Fatalf("unexpected response: status[%d] length[%d]", res.StatusCode, res.ContentLength)
+	}
+	if res.Header.Get("Content-Type") != "audio/wav; codecs=1" {
+		t.Fatalf("unexpected content type %s", res.Header.Get("Content-Type"))
+	}
+
+	notWav := httptest.NewServer(FileHandler("httpwav.go"))
+	defer notWav.Close()
+
+	res, err = http.Get(notWav.URL)
+	assertNoError(t, err)
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415, got %d", res.StatusCode)
+	}
+}
+
+func assertNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
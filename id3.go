@@ -0,0 +1,235 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// ID3 returns the raw ID3v2 tag embedded by some encoders as an
+// "id3 " (or "ID3 ") chunk, or nil if the file has none.
+func (w *Wav) ID3() []byte {
+	for _, id := range []string{"id3 ", "ID3 "} {
+		if chunk := w.Chunk(id); chunk != nil {
+			return chunk.Data
+		}
+	}
+	return nil
+}
+
+// splitID3 separates an id3 chunk that ended up at the end of the audio
+// data, which happens when the data chunk size claims more than the
+// file has and the data is read until the end of the stream.
+func splitID3(data []byte) ([]byte, *Chunk) {
+	for _, id := range []string{"id3 ", "ID3 "} {
+		pos := bytes.LastIndex(data, []byte(id))
+		if pos < 0 || len(data)-pos < chunkHeaderSize {
+			continue
+		}
+
+		size := int64(binary.LittleEndian.Uint32(data[pos+4:]))
+		end := int64(pos) + chunkHeaderSize + size
+		if end != int64(len(data)) && end+size%2 != int64(len(data)) {
+			continue
+		}
+		tag := data[pos+chunkHeaderSize : pos+chunkHeaderSize+int(size)]
+		if !bytes.HasPrefix(tag, []byte("ID3")) {
+			continue
+		}
+
+		chunk := &Chunk{ID: chunkID(id), Data: append([]byte(nil), tag...)}
+		return data[:pos], chunk
+	}
+	return data, nil
+}
+
+// id3Info maps ID3v2 text frames to their INFO counterparts.
+var id3Info = map[string]string{
+	"TIT2": InfoTitle,
+	"TPE1": InfoArtist,
+	"TALB": InfoProduct,
+	"TCON": InfoGenre,
+	"TRCK": InfoTrack,
+	"TCOP": InfoCopyright,
+	"TSSE": InfoSoftware,
+	"TYER": InfoCreationDate,
+	"TDRC": InfoCreationDate,
+	"COMM": InfoComment,
+	// ID3v2.2 three character ids
+	"TT2": InfoTitle,
+	"TP1": InfoArtist,
+	"TAL": InfoProduct,
+	"TCO": InfoGenre,
+	"TRK": InfoTrack,
+	"TCR": InfoCopyright,
+	"TSS": InfoSoftware,
+	"TYE": InfoCreationDate,
+	"COM": InfoComment,
+}
+
+// Metadata merges the LIST/INFO entries with the common frames of the
+// ID3 tag (title, artist, album...), mapped to the equivalent INFO ids
+// (the album goes to InfoProduct). INFO entries win when both have the
+// same field.
+func (w *Wav) Metadata() (Info, error) {
+	info, err := w.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	tag := w.ID3()
+	if tag == nil {
+		return info, nil
+	}
+	tags, err := parseID3(tag)
+	if err != nil {
+		return nil, err
+	}
+	for id, value := range tags {
+		if _, ok := info[id]; !ok {
+			info[id] = value
+		}
+	}
+	return info, nil
+}
+
+// parseID3 parses the text frames of an ID3v2 tag, keyed by their
+// INFO ids. Frames without an INFO counterpart are ignored.
+func parseID3(tag []byte) (Info, error) {
+	const headerSize = 10
+	if len(tag) < headerSize || string(tag[:3]) != "ID3" {
+		return nil, fmt.Errorf("invalid ID3 tag header")
+	}
+
+	version := tag[3]
+	flags := tag[5]
+	if version < 2 || version > 4 {
+		return nil, fmt.Errorf("unsupported ID3 version[2.%d]", version)
+	}
+
+	size := int(synchsafe(tag[6:10]))
+	frames := tag[headerSize:]
+	if size < len(frames) {
+		frames = frames[:size]
+	}
+	if flags&0x80 != 0 {
+		frames = bytes.Replace(frames, []byte{0xff, 0x00}, []byte{0xff}, -1)
+	}
+	if flags&0x40 != 0 && version > 2 {
+		if len(frames) < 4 {
+			return nil, fmt.Errorf("truncated ID3 extended header")
+		}
+		skip := int(binary.BigEndian.Uint32(frames)) + 4
+		if version == 4 {
+			skip = int(synchsafe(frames[:4]))
+		}
+		if skip > len(frames) {
+			return nil, fmt.Errorf("ID3 extended header size[%d] exceeds the tag", skip)
+		}
+		frames = frames[skip:]
+	}
+
+	idSize, frameHeaderSize := 4, 10
+	if version == 2 {
+		idSize, frameHeaderSize = 3, 6
+	}
+
+	info := Info{}
+	for len(frames) >= frameHeaderSize && frames[0] != 0 {
+		id := string(frames[:idSize])
+
+		var size int
+		switch version {
+		case 2:
+			size = int(frames[3])<<16 | int(frames[4])<<8 | int(frames[5])
+		case 3:
+			size = int(binary.BigEndian.Uint32(frames[4:]))
+		case 4:
+			size = int(synchsafe(frames[4:8]))
+		}
+		frames = frames[frameHeaderSize:]
+		if size > len(frames) {
+			return nil, fmt.Errorf("ID3 frame[%s] size[%d] exceeds the tag", id, size)
+		}
+		body := frames[:size]
+		frames = frames[size:]
+
+		field, ok := id3Info[id]
+		if !ok || len(body) == 0 {
+			continue
+		}
+		if id == "COMM" || id == "COM" {
+			body = id3Comment(body)
+		}
+		if text := id3Text(body); text != "" {
+			info[field] = text
+		}
+	}
+	return info, nil
+}
+
+// id3Comment drops the language and short description of a comment
+// frame, keeping the encoding byte and the comment text.
+func id3Comment(body []byte) []byte {
+	if len(body) < 4 {
+		return nil
+	}
+	enc, rest := body[0], body[4:]
+	terminator := []byte{0}
+	if enc == 1 || enc == 2 {
+		terminator = []byte{0, 0}
+	}
+	for i := 0; i+len(terminator) <= len(rest); i += len(terminator) {
+		if bytes.Equal(rest[i:i+len(terminator)], terminator) {
+			return append([]byte{enc}, rest[i+len(terminator):]...)
+		}
+	}
+	return nil
+}
+
+// id3Text decodes the text of a frame given its leading encoding byte.
+// Multiple values (v2.4) are joined with "/".
+func id3Text(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var text string
+	switch enc, data := body[0], body[1:]; enc {
+	case 0: // ISO-8859-1
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		text = string(runes)
+	case 1, 2: // UTF-16 with BOM, UTF-16BE
+		order := binary.ByteOrder(binary.BigEndian)
+		if enc == 1 && len(data) >= 2 {
+			if data[0] == 0xff && data[1] == 0xfe {
+				order = binary.LittleEndian
+			}
+			if (data[0] == 0xff && data[1] == 0xfe) || (data[0] == 0xfe && data[1] == 0xff) {
+				data = data[2:]
+			}
+		}
+		units := make([]uint16, len(data)/2)
+		for i := range units {
+			units[i] = order.Uint16(data[2*i:])
+		}
+		text = string(utf16.Decode(units))
+	case 3: // UTF-8
+		text = string(data)
+	default:
+		return ""
+	}
+
+	text = strings.Replace(text, "\ufeff", "", -1)
+	text = strings.TrimRight(text, "\x00")
+	return strings.Replace(text, "\x00", "/", -1)
+}
+
+func synchsafe(b []byte) uint32 {
+	return uint32(b[0]&0x7f)<<21 | uint32(b[1]&0x7f)<<14 | uint32(b[2]&0x7f)<<7 | uint32(b[3]&0x7f)
+}
@@ -0,0 +1,127 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestID3Chunk(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.1, 0.2, 0.3, 0.4}))
+	audio := append([]byte(nil), wav.Data...)
+
+	tag := []byte("ID3\x03\x00\x00\x00\x00\x00\x03abc")
+
+	var buf bytes.Buffer
+	_, err := wav.WriteTo(&buf)
+	assertNoError(t, err)
+	buf.WriteString("id3 ")
+	binary.Write(&buf, binary.LittleEndian, uint32(len(tag)))
+	buf.Write(tag)
+	buf.WriteByte(0)
+	raw := buf.Bytes()
+
+	type tcase struct {
+		name     string
+		dataSize uint32
+	}
+
+	tcases := []tcase{
+		{name: "consistent", dataSize: uint32(len(audio))},
+		{name: "unbounded", dataSize: 0xffffffff},
+		{name: "oversized", dataSize: uint32(len(raw))},
+	}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			file := append([]byte(nil), raw...)
+			binary.LittleEndian.PutUint32(file[wav.Header.FirstSamplePos-4:], tcase.dataSize)
+
+			got, err := ParseBytes(file)
+			assertNoError(t, err)
+			assertBytesEqual(t, audio, got.Data)
+			assertBytesEqual(t, tag, got.ID3())
+		})
+	}
+
+	if New(WaveFormatPCM, 1, 8000, 16).ID3() != nil {
+		t.Fatal("expected no id3 tag")
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	frame := func(id string, body []byte) []byte {
+		var buf bytes.Buffer
+		buf.WriteString(id)
+		binary.Write(&buf, binary.BigEndian, uint32(len(body)))
+		buf.Write([]byte{0, 0})
+		buf.Write(body)
+		return buf.Bytes()
+	}
+
+	var frames []byte
+	frames = append(frames, frame("TIT2", []byte("\x00Caf\xe9"))...)
+	// UTF-16 with a little endian BOM
+	frames = append(frames, frame("TPE1", []byte("\x01\xff\xfeA\x00r\x00t\x00"))...)
+	frames = append(frames, frame("TALB", []byte("\x03Album\x00"))...)
+	frames = append(frames, frame("COMM", []byte("\x00engdesc\x00A comment"))...)
+	frames = append(frames, frame("TXXX", []byte("\x00ignored"))...)
+	frames = append(frames, make([]byte, 16)...) // padding
+
+	size := len(frames)
+	tag := append([]byte{
+		'I', 'D', '3', 3, 0, 0,
+		byte(size >> 21 & 0x7f), byte(size >> 14 & 0x7f), byte(size >> 7 & 0x7f), byte(size & 0x7f),
+	}, frames...)
+
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	wav.SetChunk("id3 ", tag)
+	wav.SetInfo(Info{InfoTitle: "Native title", InfoEngineer: "Someone"})
+
+	got, err := wav.Metadata()
+	assertNoError(t, err)
+
+	expected := Info{
+		InfoTitle:    "Native title",
+		InfoEngineer: "Someone",
+		InfoArtist:   "Art",
+		InfoProduct:  "Album",
+		InfoComment:  "A comment",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for id, value := range expected {
+		if got[id] != value {
+			t.Fatalf("%s: expected %q, got %q", id, value, got[id])
+		}
+	}
+
+	wav.SetInfo(nil)
+	got, err = wav.Metadata()
+	assertNoError(t, err)
+	if got[InfoTitle] != "Café" {
+		t.Fatalf("expected ID3 title, got %q", got[InfoTitle])
+	}
+
+	wav.SetChunk("id3 ", []byte("ID3\x07\x00\x00\x00\x00\x00\x00"))
+	_, err = wav.Metadata()
+	assertError(t, err)
+}
+
+func TestParseID3v24(t *testing.T) {
+	body := []byte("\x03Title\x00Subtitle")
+	frames := append([]byte("TIT2\x00\x00\x00"), byte(len(body)), 0, 0)
+	frames = append(frames, body...)
+	tag := append([]byte{'I', 'D', '3', 4, 0, 0, 0, 0, 0, byte(len(frames))}, frames...)
+
+	info, err := parseID3(tag)
+	assertNoError(t, err)
+	if info[InfoTitle] != "Title/Subtitle" {
+		t.Fatalf("unexpected title %q", info[InfoTitle])
+	}
+
+	_, err = parseID3(tag[:len(tag)-2])
+	assertError(t, err)
+}
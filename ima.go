@@ -0,0 +1,268 @@
+package waveparser
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// IMA ADPCM (DVI ADPCM) as laid out on WAV files: blocks of
+// BytesPerBloc bytes starting with a 4 bytes header per channel (the
+// first sample and the step index) followed by 4 bits codes,
+// interleaved by channel every 8 codes (4 bytes).
+
+var imaStepTable = [89]int{
+	7, 8, 9, 10, 11, 12, 13, 14, 16, 17,
+	19, 21, 23, 25, 28, 31, 34, 37, 41, 45,
+	50, 55, 60, 66, 73, 80, 88, 97, 107, 118,
+	130, 143, 157, 173, 190, 209, 230, 253, 279, 307,
+	337, 371, 408, 449, 494, 544, 598, 658, 724, 796,
+	876, 963, 1060, 1166, 1282, 1411, 1552, 1707, 1878, 2066,
+	2272, 2499, 2749, 3024, 3327, 3660, 4026, 4428, 4871, 5358,
+	5894, 6484, 7132, 7845, 8630, 9493, 10442, 11487, 12635, 13899,
+	15289, 16818, 18500, 20350, 22385, 24623, 27086, 29794, 32767,
+}
+
+var imaIndexTable = [8]int{-1, -1, -1, -1, 2, 4, 6, 8}
+
+// imaFmtExtraSize is the size of the cbSize and wSamplesPerBlock
+// fields IMA ADPCM adds to the fmt chunk.
+const imaFmtExtraSize = 4
+
+type (
+	imaState struct {
+		predictor int
+		index     int
+	}
+)
+
+func isIMA(f AudioFormat) bool {
+	return f == WaveFormatIMAADPCM
+}
+
+// imaBlockSize is the customary block size for the rate: 256 bytes per
+// channel up to 11 kHz, doubling with the rate.
+func imaBlockSize(channels uint16, sampleRate uint32) uint16 {
+	if channels == 0 {
+		return 0
+	}
+	size := 256 * int(channels)
+	if sampleRate > 11025 {
+		size *= int(sampleRate / 11025)
+	}
+	if size > 0xffff-0xffff%(4*int(channels)) {
+		size = 0xffff - 0xffff%(4*int(channels))
+	}
+	return uint16(size)
+}
+
+// imaSamplesPerBlock is the number of frames of a block.
+func imaSamplesPerBlock(f RiffChunkFmt) int {
+	channels := int(f.NumChannels)
+	return (int(f.BytesPerBloc)-4*channels)*2/channels + 1
+}
+
+func imaCheckFormat(f RiffChunkFmt) error {
+	channels := int(f.NumChannels)
+	if channels == 0 {
+		return fmt.Errorf("invalid number of channels[%d]", f.NumChannels)
+	}
+	if f.BitsPerSample != 4 {
+		return fmt.Errorf("unsupported IMA ADPCM bits per sample[%d]", f.BitsPerSample)
+	}
+	block := int(f.BytesPerBloc)
+	if block <= 4*channels || (block-4*channels)%(4*channels) != 0 {
+		return fmt.Errorf("invalid IMA ADPCM block size[%d] for channels[%d]", block, channels)
+	}
+	return nil
+}
+
+// decodeIMA decodes whole IMA ADPCM blocks, a trailing incomplete block
+// is dropped.
+func decodeIMA(f RiffChunkFmt, data []byte) ([]float64, error) {
+	if err := imaCheckFormat(f); err != nil {
+		return nil, err
+	}
+
+	channels := int(f.NumChannels)
+	block := int(f.BytesPerBloc)
+	frames := imaSamplesPerBlock(f)
+	blocks := len(data) / block
+
+	samples := make([]float64, 0, blocks*frames*channels)
+	decoded := make([]int16, frames*channels)
+	for b := 0; b < blocks; b++ {
+		raw := data[b*block : (b+1)*block]
+		states := make([]imaState, channels)
+		for c := range states {
+			sample := int16(binary.LittleEndian.Uint16(raw[4*c:]))
+			states[c] = imaState{predictor: int(sample), index: clampIndex(int(raw[4*c+2]))}
+			decoded[c] = sample
+		}
+
+		codes := raw[4*channels:]
+		for group := 0; group < len(codes)/(4*channels); group++ {
+			for c := 0; c < channels; c++ {
+				chunk := codes[(group*channels+c)*4:]
+				for i := 0; i < 8; i++ {
+					code := chunk[i/2] >> (uint(i%2) * 4) & 0xf
+					frame := 1 + group*8 + i
+					decoded[frame*channels+c] = states[c].decode(code)
+				}
+			}
+		}
+
+		for _, s := range decoded {
+			samples = append(samples, float64(s)/(1<<15))
+		}
+	}
+	return samples, nil
+}
+
+// encodeIMA encodes interleaved samples into IMA ADPCM blocks, the last
+// block is padded with silence.
+func encodeIMA(f RiffChunkFmt, samples []float64) ([]byte, error) {
+	if err := imaCheckFormat(f); err != nil {
+		return nil, err
+	}
+
+	channels := int(f.NumChannels)
+	block := int(f.BytesPerBloc)
+	frames := imaSamplesPerBlock(f)
+	total := len(samples) / channels
+	blocks := (total + frames - 1) / frames
+
+	pcm := func(frame, c int) int {
+		if frame >= total {
+			return 0
+		}
+		return int(quantize(samples[frame*channels+c], 16))
+	}
+
+	data := make([]byte, blocks*block)
+	states := make([]imaState, channels)
+	for c := range states {
+		// starting with a step fit for the first change avoids a
+		// distorted ramp up from the smallest step
+		states[c].index = imaIndexFor(pcm(1, c) - pcm(0, c))
+	}
+	for b := 0; b < blocks; b++ {
+		raw := data[b*block : (b+1)*block]
+		first := b * frames
+		for c := range states {
+			// the first sample of a block is stored verbatim
+			states[c].predictor = pcm(first, c)
+			binary.LittleEndian.PutUint16(raw[4*c:], uint16(int16(states[c].predictor)))
+			raw[4*c+2] = byte(states[c].index)
+		}
+
+		codes := raw[4*channels:]
+		for group := 0; group < len(codes)/(4*channels); group++ {
+			for c := 0; c < channels; c++ {
+				chunk := codes[(group*channels+c)*4:]
+				for i := 0; i < 8; i++ {
+					code := states[c].encode(pcm(first+1+group*8+i, c))
+					chunk[i/2] |= code << (uint(i%2) * 4)
+				}
+			}
+		}
+	}
+	return data, nil
+}
+
+func (s *imaState) decode(code byte) int16 {
+	step := imaStepTable[s.index]
+	diff := step >> 3
+	if code&1 != 0 {
+		diff += step >> 2
+	}
+	if code&2 != 0 {
+		diff += step >> 1
+	}
+	if code&4 != 0 {
+		diff += step
+	}
+	if code&8 != 0 {
+		diff = -diff
+	}
+
+	s.predictor += diff
+	if s.predictor > 32767 {
+		s.predictor = 32767
+	}
+	if s.predictor < -32768 {
+		s.predictor = -32768
+	}
+	s.index = clampIndex(s.index + imaIndexTable[code&7])
+	return int16(s.predictor)
+}
+
+func (s *imaState) encode(sample int) byte {
+	step := imaStepTable[s.index]
+	diff := sample - s.predictor
+
+	var code byte
+	if diff < 0 {
+		code = 8
+		diff = -diff
+	}
+	if diff >= step {
+		code |= 4
+		diff -= step
+	}
+	if diff >= step>>1 {
+		code |= 2
+		diff -= step >> 1
+	}
+	if diff >= step>>2 {
+		code |= 1
+	}
+
+	// keeps the encoder predictor in sync with the decoder
+	s.decode(code)
+	return code
+}
+
+// imaIndexFor is the smallest step index able to code diff at once.
+func imaIndexFor(diff int) int {
+	if diff < 0 {
+		diff = -diff
+	}
+	for index, step := range imaStepTable {
+		if step+step>>1+step>>2 >= diff {
+			return index
+		}
+	}
+	return len(imaStepTable) - 1
+}
+
+func clampIndex(index int) int {
+	if index < 0 {
+		return 0
+	}
+	if index > len(imaStepTable)-1 {
+		return len(imaStepTable) - 1
+	}
+	return index
+}
+
+// setFact records the number of frames on the fact chunk, which
+// compressed formats must have.
+func (w *Wav) setFact(frames uint32) {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, frames)
+	w.SetChunk("fact", data)
+}
+
+// trimIMA drops the silence padding the last block, beyond the number
+// of frames of the fact chunk.
+func (w *Wav) trimIMA(samples []float64) []float64 {
+	fact := w.Chunk("fact")
+	if fact == nil || len(fact.Data) < 4 {
+		return samples
+	}
+	n := uint64(binary.LittleEndian.Uint32(fact.Data)) * uint64(w.Header.RIFFChunkFmt.NumChannels)
+	if n < uint64(len(samples)) {
+		samples = samples[:n]
+	}
+	return samples
+}
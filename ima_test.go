@@ -0,0 +1,109 @@
+package waveparser
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestIMADecode(t *testing.T) {
+	format := New(WaveFormatIMAADPCM, 1, 8000, 4).Header.RIFFChunkFmt
+	format.BytesPerBloc = 8 // 9 frames
+
+	// first sample 100, step index 0, then codes 7 (+11) and 15 (-diff)
+	block := []byte{100, 0, 0, 0, 0xf7, 0, 0, 0}
+
+	samples, err := decodeIMA(format, block)
+	assertNoError(t, err)
+	if len(samples) != 9 {
+		t.Fatalf("expected 9 samples, got %d", len(samples))
+	}
+
+	// step 7 with every bit set adds 7 + 3 + 1 + 0, the index moves to
+	// 8 (step 16) and the negative code subtracts 16 + 8 + 4 + 2
+	expected := []float64{100, 111, 81}
+	for i, e := range expected {
+		if got := samples[i] * (1 << 15); got != e {
+			t.Fatalf("sample[%d]: expected %f, got %f", i, e, got)
+		}
+	}
+}
+
+func TestIMARoundTrip(t *testing.T) {
+	for _, channels := range []uint16{1, 2} {
+		wav := New(WaveFormatIMAADPCM, channels, 8000, 4)
+		if wav.Header.RIFFChunkFmt.BytesPerBloc != 256*channels {
+			t.Fatalf("unexpected block size[%d]", wav.Header.RIFFChunkFmt.BytesPerBloc)
+		}
+
+		frames := 1234
+		samples := make([]float64, frames*int(channels))
+		for i := range samples {
+			frame := i / int(channels)
+			samples[i] = 0.1 * math.Sin(2*math.Pi*100*float64(frame)/8000+float64(i%int(channels)))
+		}
+		assertNoError(t, wav.SetSamples(samples))
+
+		// 505 frames per block
+		if len(wav.Data) != 3*256*int(channels) {
+			t.Fatalf("expected 3 blocks, got %d bytes", len(wav.Data))
+		}
+
+		buf := &bytes.Buffer{}
+		_, err := wav.WriteTo(buf)
+		assertNoError(t, err)
+		parsed, err := ParseBytes(buf.Bytes())
+		assertNoError(t, err)
+		if parsed.Header.RIFFChunkFmt != wav.Header.RIFFChunkFmt ||
+			parsed.Header.RIFFHdr != wav.Header.RIFFHdr {
+			t.Fatalf("header differs:\n%#v\n!=\n%#v", parsed.Header, wav.Header)
+		}
+
+		got, err := parsed.Samples()
+		assertNoError(t, err)
+		assertSamplesClose(t, samples, got, 0.01)
+
+		duration, err := probeDuration(bytes.NewReader(buf.Bytes()))
+		assertNoError(t, err)
+		if expected := time.Duration(frames) * time.Second / 8000; duration != expected {
+			t.Fatalf("expected duration %s, got %s", expected, duration)
+		}
+	}
+}
+
+func TestIMAInvalidFormat(t *testing.T) {
+	format := New(WaveFormatIMAADPCM, 2, 8000, 4).Header.RIFFChunkFmt
+	format.BytesPerBloc = 10
+	_, err := decodeIMA(format, make([]byte, 10))
+	assertError(t, err)
+	_, err = encodeIMA(format, make([]float64, 10))
+	assertError(t, err)
+}
+
+func TestConvertToIMA(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 16000, 16)
+	samples := make([]float64, 16000)
+	for i := range samples {
+		samples[i] = 0.1 * math.Sin(2*math.Pi*100*float64(i)/16000)
+	}
+	assertNoError(t, wav.SetSamples(samples))
+	_, err := wav.AddCue("middle", 500*time.Millisecond)
+	assertNoError(t, err)
+
+	to := New(WaveFormatIMAADPCM, 1, 8000, 4).Header.RIFFChunkFmt
+	converted, err := convertResampled(wav, to)
+	assertNoError(t, err)
+
+	got, err := converted.Samples()
+	assertNoError(t, err)
+	if len(got) != 8000 {
+		t.Fatalf("expected 8000 samples, got %d", len(got))
+	}
+
+	points, err := converted.CuePoints()
+	assertNoError(t, err)
+	if len(points) != 1 || points[0].SampleOffset != 4000 {
+		t.Fatalf("expected cue moved to frame 4000, got %+v", points)
+	}
+}
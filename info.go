@@ -0,0 +1,102 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+type (
+	// Info holds the LIST/INFO entries, keyed by their four
+	// character id (see the Info* constants).
+	Info map[string]string
+)
+
+const (
+	InfoTitle        = "INAM"
+	InfoArtist       = "IART"
+	InfoProduct      = "IPRD"
+	InfoComment      = "ICMT"
+	InfoCopyright    = "ICOP"
+	InfoCreationDate = "ICRD"
+	InfoEngineer     = "IENG"
+	InfoGenre        = "IGNR"
+	InfoKeywords     = "IKEY"
+	InfoSoftware     = "ISFT"
+	InfoSubject      = "ISBJ"
+	InfoTrack        = "ITRK"
+)
+
+// Info parses the LIST/INFO chunk, returning an empty Info when the
+// file has none.
+func (w *Wav) Info() (Info, error) {
+	info := Info{}
+	chunk := w.listChunk("INFO")
+	if chunk == nil {
+		return info, nil
+	}
+
+	err := parseSubchunks(chunk.Data[4:], func(id [4]byte, data []byte) error {
+		info[string(id[:])] = string(bytes.TrimRight(data, "\x00"))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing LIST/INFO: %s", err)
+	}
+	return info, nil
+}
+
+// SetInfo replaces the LIST/INFO chunk, removing it when info is empty.
+func (w *Wav) SetInfo(info Info) {
+	if len(info) == 0 {
+		w.removeListChunk("INFO")
+		return
+	}
+
+	ids := make([]string, 0, len(info))
+	for id := range info {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("INFO")
+	for _, id := range ids {
+		writeSubchunk(buf, chunkID(id), append([]byte(info[id]), 0))
+	}
+	w.setListChunk("INFO", buf.Bytes())
+}
+
+// parseSubchunks walks the chunks nested inside a LIST chunk.
+func parseSubchunks(data []byte, visit func(id [4]byte, data []byte) error) error {
+	for len(data) >= chunkHeaderSize {
+		var id [4]byte
+		copy(id[:], data)
+		size := binary.LittleEndian.Uint32(data[4:])
+		data = data[chunkHeaderSize:]
+
+		if uint64(size) > uint64(len(data)) {
+			return fmt.Errorf("subchunk[%s] size[%d] exceeds its parent", string(id[:]), size)
+		}
+		if err := visit(id, data[:size]); err != nil {
+			return err
+		}
+
+		next := int(size + size%2)
+		if next > len(data) {
+			next = len(data)
+		}
+		data = data[next:]
+	}
+	return nil
+}
+
+func writeSubchunk(buf *bytes.Buffer, id [4]byte, data []byte) {
+	buf.Write(id[:])
+	binary.Write(buf, binary.LittleEndian, uint32(len(data)))
+	buf.Write(data)
+	if len(data)%2 != 0 {
+		buf.WriteByte(0)
+	}
+}
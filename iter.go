@@ -0,0 +1,94 @@
+//go:build go1.23
+
+package waveparser
+
+import (
+	"iter"
+	"path/filepath"
+)
+
+// SampleSeq yields the interleaved samples normalized to [-1, 1],
+// decoding them as they are consumed. Nothing is yielded when the
+// encoding is not supported (Samples reports why).
+func (w *Wav) SampleSeq() iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		size, decode, ok := w.sampleCodec()
+		if !ok {
+			return
+		}
+		// a trailing incomplete frame is dropped
+		end := len(w.Data) - len(w.Data)%(size*int(w.Header.RIFFChunkFmt.NumChannels))
+		for i := 0; i+size <= end; i += size {
+			if !yield(decode(w.Data[i:])) {
+				return
+			}
+		}
+	}
+}
+
+// Int16Seq yields the interleaved samples converted to 16 bits PCM,
+// whatever the encoding of the file.
+func (w *Wav) Int16Seq() iter.Seq[int16] {
+	return func(yield func(int16) bool) {
+		for sample := range w.SampleSeq() {
+			if !yield(int16(quantize(sample, 16))) {
+				return
+			}
+		}
+	}
+}
+
+// FrameSeq yields the index and samples of each frame, one sample per
+// channel. The slice is reused between iterations, copy it to keep it.
+func (w *Wav) FrameSeq() iter.Seq2[int, []float64] {
+	return func(yield func(int, []float64) bool) {
+		size, decode, ok := w.sampleCodec()
+		channels := int(w.Header.RIFFChunkFmt.NumChannels)
+		if !ok || channels == 0 {
+			return
+		}
+
+		frame := make([]float64, channels)
+		frameSize := size * channels
+		for i := 0; (i+1)*frameSize <= len(w.Data); i++ {
+			for c := range frame {
+				frame[c] = decode(w.Data[i*frameSize+c*size:])
+			}
+			if !yield(i, frame) {
+				return
+			}
+		}
+	}
+}
+
+func (w *Wav) sampleCodec() (int, sampleDecodeFunc, bool) {
+	frame, err := frameSize(w.Header.RIFFChunkFmt)
+	if err != nil {
+		return 0, nil, false
+	}
+	decode, err := sampleDecoder(w.Header.RIFFChunkFmt)
+	if err != nil {
+		return 0, nil, false
+	}
+	return frame / int(w.Header.RIFFChunkFmt.NumChannels), decode, true
+}
+
+// GlobSeq loads the WAV files matching the pattern (see filepath.Match)
+// as they are consumed, one at a time. Files that fail to load are
+// yielded with their error, a bad pattern is yielded as a GlobFile
+// without a path.
+func GlobSeq(pattern string, opts ...ParseOption) iter.Seq[GlobFile] {
+	return func(yield func(GlobFile) bool) {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			yield(GlobFile{Err: err})
+			return
+		}
+		for _, path := range paths {
+			wav, err := Load(path, opts...)
+			if !yield(GlobFile{Path: path, Wav: wav, Err: err}) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+//go:build go1.23
+
+package waveparser
+
+import "testing"
+
+func TestSequences(t *testing.T) {
+	wav, err := Load("./testdata/audios/sint16le.wav")
+	assertNoError(t, err)
+
+	expected, err := wav.Samples()
+	assertNoError(t, err)
+
+	var samples []float64
+	for sample := range wav.SampleSeq() {
+		samples = append(samples, sample)
+	}
+	assertSamplesClose(t, expected, samples, 0)
+
+	raw, err := wav.Int16LESamples()
+	assertNoError(t, err)
+
+	i := 0
+	for sample := range wav.Int16Seq() {
+		if sample != raw[i] {
+			t.Fatalf("sample[%d]: expected[%d] got[%d]", i, raw[i], sample)
+		}
+		i++
+	}
+	if i != len(raw) {
+		t.Fatalf("expected [%d] samples, got [%d]", len(raw), i)
+	}
+}
+
+func TestFrameSeq(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 2, 8000, 32)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5, 0.25, -0.25, 1, -1}))
+
+	var frames [][]float64
+	for i, frame := range wav.FrameSeq() {
+		if i != len(frames) {
+			t.Fatalf("unexpected frame index[%d]", i)
+		}
+		frames = append(frames, append([]float64{}, frame...))
+		if i == 1 {
+			break
+		}
+	}
+
+	if len(frames) != 2 {
+		t.Fatalf("expected to stop after [2] frames, got [%d]", len(frames))
+	}
+	assertSamplesClose(t, []float64{0.25, -0.25}, frames[1], 0)
+}
+
+func TestGlobSeq(t *testing.T) {
+	var loaded, failed int
+	for file := range GlobSeq("./testdata/audios/*.wav") {
+		if file.Err != nil {
+			failed++
+			continue
+		}
+		if file.Wav == nil || file.Path == "" {
+			t.Fatalf("unexpected file %+v", file)
+		}
+		loaded++
+	}
+	if loaded == 0 {
+		t.Fatal("expected files loaded")
+	}
+
+	for file := range GlobSeq("[") {
+		if file.Err == nil {
+			t.Fatal("expected bad pattern error")
+		}
+	}
+}
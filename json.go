@@ -0,0 +1,87 @@
+package waveparser
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	riffHeaderJSON struct {
+		Ident     string
+		ChunkSize uint32
+		FileType  string
+	}
+
+	wavHeaderJSON struct {
+		RIFFHeader     RiffHeader
+		RIFFChunkFmt   RiffChunkFmt
+		Extension      *FmtExtension `json:",omitempty"`
+		FirstSamplePos uint32
+		DataBlockSize  uint32
+		Duration       float64 // seconds, derived from the data size
+	}
+)
+
+// MarshalJSON encodes the header with the identifiers as strings.
+func (hdr RiffHeader) MarshalJSON() ([]byte, error) {
+	return json.Marshal(riffHeaderJSON{
+		Ident:     string(hdr.Ident[:]),
+		ChunkSize: hdr.ChunkSize,
+		FileType:  string(hdr.FileType[:]),
+	})
+}
+
+// UnmarshalJSON decodes a header encoded by MarshalJSON.
+func (hdr *RiffHeader) UnmarshalJSON(data []byte) error {
+	var decoded riffHeaderJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if len(decoded.Ident) != 4 || len(decoded.FileType) != 4 {
+		return fmt.Errorf(
+			"invalid RIFF identification[%s] or file type[%s]",
+			decoded.Ident,
+			decoded.FileType,
+		)
+	}
+
+	copy(hdr.Ident[:], decoded.Ident)
+	hdr.ChunkSize = decoded.ChunkSize
+	copy(hdr.FileType[:], decoded.FileType)
+	return nil
+}
+
+// MarshalJSON encodes the header including its duration in seconds,
+// which is ignored when unmarshaling.
+func (hdr WavHeader) MarshalJSON() ([]byte, error) {
+	encoded := wavHeaderJSON{
+		RIFFHeader:     hdr.RIFFHdr,
+		RIFFChunkFmt:   hdr.RIFFChunkFmt,
+		FirstSamplePos: hdr.FirstSamplePos,
+		DataBlockSize:  hdr.DataBlockSize,
+		Duration:       hdr.duration().Seconds(),
+	}
+	if hdr.IsExtensible() {
+		encoded.Extension = &hdr.Extension
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes a header encoded by MarshalJSON.
+func (hdr *WavHeader) UnmarshalJSON(data []byte) error {
+	var decoded wavHeaderJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*hdr = WavHeader{
+		RIFFHdr:        decoded.RIFFHeader,
+		RIFFChunkFmt:   decoded.RIFFChunkFmt,
+		FirstSamplePos: decoded.FirstSamplePos,
+		DataBlockSize:  decoded.DataBlockSize,
+	}
+	if decoded.Extension != nil {
+		hdr.Extension = *decoded.Extension
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package waveparser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHeaderJSONRoundTrip(t *testing.T) {
+	wav, err := Load("./testdata/r.wav")
+	assertNoError(t, err)
+
+	encoded, err := json.Marshal(wav.Header)
+	assertNoError(t, err)
+
+	for _, field := range []string{`"Ident":"RIFF"`, `"FileType":"WAVE"`, `"Duration":0.463625`} {
+		if !strings.Contains(string(encoded), field) {
+			t.Fatalf("expected %s on %s", field, encoded)
+		}
+	}
+
+	var decoded WavHeader
+	assertNoError(t, json.Unmarshal(encoded, &decoded))
+	if decoded != wav.Header {
+		t.Fatalf("expected header %+v, got %+v", wav.Header, decoded)
+	}
+}
+
+func TestRiffHeaderJSONInvalidIdent(t *testing.T) {
+	var hdr RiffHeader
+	assertError(t, json.Unmarshal([]byte(`{"Ident":"RIFFF","FileType":"WAVE"}`), &hdr))
+}
@@ -0,0 +1,92 @@
+package waveparser
+
+import "io"
+
+type (
+	// LazyWav is a WAV file of which only the header was parsed, the
+	// audio data is read on demand.
+	LazyWav struct {
+		Header WavHeader
+
+		r      io.ReaderAt
+		closer io.Closer
+		data   *io.SectionReader
+		buf    []byte
+	}
+)
+
+func newLazyWav(r io.ReadSeeker, at io.ReaderAt, closer io.Closer) (*LazyWav, error) {
+	hdr, err := parseHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyWav{
+		Header: hdr,
+		r:      at,
+		closer: closer,
+		data:   io.NewSectionReader(at, int64(hdr.FirstSamplePos), int64(hdr.DataBlockSize)),
+	}, nil
+}
+
+// Data reads the whole audio data. It doesn't affect ReadSamples.
+func (l *LazyWav) Data() ([]byte, error) {
+	size := int64(l.Header.DataBlockSize)
+	return readData(nil, io.NewSectionReader(l.r, int64(l.Header.FirstSamplePos), size))
+}
+
+// ReadSamples decodes the next frames of the audio data into samples,
+// normalized as in Wav.Samples, returning how many samples were read.
+// Only whole frames are read, so samples must fit at least one. At the
+// end of the data it returns 0 and io.EOF.
+func (l *LazyWav) ReadSamples(samples []float64) (int, error) {
+	format := l.Header.RIFFChunkFmt
+	frame, err := frameSize(format)
+	if err != nil {
+		return 0, err
+	}
+	decode, err := sampleDecoder(format)
+	if err != nil {
+		return 0, err
+	}
+
+	channels := int(format.NumChannels)
+	frames := len(samples) / channels
+	if frames == 0 && len(samples) > 0 {
+		return 0, io.ErrShortBuffer
+	}
+
+	if cap(l.buf) < frames*frame {
+		l.buf = make([]byte, frames*frame)
+	}
+	buf := l.buf[:frames*frame]
+
+	n, err := io.ReadFull(l.data, buf)
+	if err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	// a trailing incomplete frame is dropped
+	size := frame / channels
+	count := n / frame * channels
+	for i := 0; i < count; i++ {
+		samples[i] = decode(buf[i*size:])
+	}
+	if count == 0 && err == nil && len(samples) > 0 {
+		err = io.EOF
+	}
+	return count, err
+}
+
+// Load reads the audio data, returning a complete Wav. Chunks other
+// than fmt and data are not loaded.
+func (l *LazyWav) Load() (*Wav, error) {
+	data, err := l.Data()
+	if err != nil {
+		return nil, err
+	}
+	return &Wav{Header: l.Header, Data: data}, nil
+}
+
+// Close closes the underlying file.
+func (l *LazyWav) Close() error {
+	return l.closer.Close()
+}
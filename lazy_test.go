@@ -0,0 +1,44 @@
+package waveparser
+
+import (
+	"io"
+	"testing"
+)
+
+func TestOpenReadsDataOnDemand(t *testing.T) {
+	const audiofile = "./testdata/audios/sint16le.wav"
+
+	expected, err := Load(audiofile)
+	assertNoError(t, err)
+	expectedSamples, err := expected.Samples()
+	assertNoError(t, err)
+
+	lazy, err := Open(audiofile)
+	assertNoError(t, err)
+	defer lazy.Close()
+
+	if lazy.Header != expected.Header {
+		t.Fatalf("expected header %+v, got %+v", expected.Header, lazy.Header)
+	}
+
+	var samples []float64
+	buf := make([]float64, 1000)
+	for {
+		n, err := lazy.ReadSamples(buf)
+		samples = append(samples, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		assertNoError(t, err)
+	}
+	assertSamplesClose(t, expectedSamples, samples, 0)
+
+	data, err := lazy.Data()
+	assertNoError(t, err)
+	assertBytesEqual(t, expected.Data, data)
+}
+
+func TestOpenInvalidWav(t *testing.T) {
+	_, err := Open("./testdata/audios/sint16le.raw")
+	assertError(t, err)
+}
@@ -0,0 +1,243 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+type (
+	// PeakEnvelope is the BWF levl chunk (EBU Tech 3285 supplement 3),
+	// a precomputed peak envelope editors draw the waveform from.
+	PeakEnvelope struct {
+		Format         uint32 // EnvelopeFormat8 or EnvelopeFormat16
+		PointsPerValue uint32 // 1 for positive peaks only, 2 for both
+		BlockSize      uint32 // frames per point
+		Channels       uint32
+		PeakOfPeaks    uint32          // frame of the highest peak, NoPeakOfPeaks if unknown
+		Timestamp      string          // yyyy:mm:dd:hh:mm:ss:uuu
+		Points         []EnvelopePoint // interleaved by channel
+	}
+
+	// EnvelopePoint has the peak magnitudes of a block of a channel,
+	// normalized to [0, 1]. Negative is equal to Positive when the
+	// envelope has only positive peaks.
+	EnvelopePoint struct {
+		Positive float64
+		Negative float64
+	}
+
+	levlHeader struct {
+		Version        uint32
+		Format         uint32
+		PointsPerValue uint32
+		BlockSize      uint32
+		PeakChannels   uint32
+		NumPeakFrames  uint32
+		PosPeakOfPeaks uint32
+		OffsetToPeaks  uint32 // from the start of the chunk header
+		Timestamp      [28]byte
+		Reserved       [60]byte
+	}
+)
+
+const (
+	// EnvelopeFormat8 stores points as 8 bits magnitudes.
+	EnvelopeFormat8 = 1
+	// EnvelopeFormat16 stores points as 16 bits magnitudes.
+	EnvelopeFormat16 = 2
+
+	// NoPeakOfPeaks marks the position of the highest peak as unknown.
+	NoPeakOfPeaks = 0xffffffff
+
+	levlHeaderSize = 120
+)
+
+// PeakEnvelope parses the levl chunk, returning nil when the file has
+// none.
+func (w *Wav) PeakEnvelope() (*PeakEnvelope, error) {
+	chunk := w.Chunk("levl")
+	if chunk == nil {
+		return nil, nil
+	}
+
+	var hdr levlHeader
+	if err := binary.Read(bytes.NewReader(chunk.Data), binary.LittleEndian, &hdr); err != nil {
+		return nil, fmt.Errorf("error parsing levl chunk: %s", err)
+	}
+
+	size, err := envelopePointSize(hdr.Format)
+	if err != nil {
+		return nil, err
+	}
+	if hdr.PointsPerValue != 1 && hdr.PointsPerValue != 2 {
+		return nil, fmt.Errorf("invalid levl points per value[%d]", hdr.PointsPerValue)
+	}
+
+	start := uint64(levlHeaderSize)
+	if hdr.OffsetToPeaks >= chunkHeaderSize+levlHeaderSize {
+		start = uint64(hdr.OffsetToPeaks) - chunkHeaderSize
+	}
+	count := uint64(hdr.NumPeakFrames) * uint64(hdr.PeakChannels)
+	if start+count*uint64(hdr.PointsPerValue)*uint64(size) > uint64(len(chunk.Data)) {
+		return nil, fmt.Errorf(
+			"levl chunk with peak frames[%d] channels[%d] has only [%d] bytes",
+			hdr.NumPeakFrames,
+			hdr.PeakChannels,
+			len(chunk.Data),
+		)
+	}
+
+	scale := envelopeScale(hdr.Format)
+	data := chunk.Data[start:]
+	value := func() float64 {
+		var v uint16
+		if size == 1 {
+			v = uint16(data[0])
+		} else {
+			v = binary.LittleEndian.Uint16(data)
+		}
+		data = data[size:]
+		return float64(v) / scale
+	}
+
+	points := make([]EnvelopePoint, count)
+	for i := range points {
+		points[i].Positive = value()
+		points[i].Negative = points[i].Positive
+		if hdr.PointsPerValue == 2 {
+			points[i].Negative = value()
+		}
+	}
+
+	return &PeakEnvelope{
+		Format:         hdr.Format,
+		PointsPerValue: hdr.PointsPerValue,
+		BlockSize:      hdr.BlockSize,
+		Channels:       hdr.PeakChannels,
+		PeakOfPeaks:    hdr.PosPeakOfPeaks,
+		Timestamp:      cstring(hdr.Timestamp[:]),
+		Points:         points,
+	}, nil
+}
+
+// SetPeakEnvelope replaces the levl chunk, removing it when e is nil.
+// Points are clipped to [0, 1].
+func (w *Wav) SetPeakEnvelope(e *PeakEnvelope) error {
+	if e == nil {
+		w.RemoveChunk("levl")
+		return nil
+	}
+
+	size, err := envelopePointSize(e.Format)
+	if err != nil {
+		return err
+	}
+	if e.PointsPerValue != 1 && e.PointsPerValue != 2 {
+		return fmt.Errorf("invalid levl points per value[%d]", e.PointsPerValue)
+	}
+	if e.Channels == 0 || len(e.Points)%int(e.Channels) != 0 {
+		return fmt.Errorf("points[%d] aren't whole frames of channels[%d]", len(e.Points), e.Channels)
+	}
+
+	hdr := levlHeader{
+		Format:         e.Format,
+		PointsPerValue: e.PointsPerValue,
+		BlockSize:      e.BlockSize,
+		PeakChannels:   e.Channels,
+		NumPeakFrames:  uint32(len(e.Points) / int(e.Channels)),
+		PosPeakOfPeaks: e.PeakOfPeaks,
+		OffsetToPeaks:  chunkHeaderSize + levlHeaderSize,
+	}
+	copy(hdr.Timestamp[:], e.Timestamp)
+
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.LittleEndian, hdr)
+
+	scale := envelopeScale(e.Format)
+	write := func(v float64) {
+		q := uint16(math.Round(math.Max(0, math.Min(1, v)) * scale))
+		if size == 1 {
+			buf.WriteByte(byte(q))
+		} else {
+			binary.Write(buf, binary.LittleEndian, q)
+		}
+	}
+	for _, point := range e.Points {
+		write(point.Positive)
+		if e.PointsPerValue == 2 {
+			write(point.Negative)
+		}
+	}
+	w.SetChunk("levl", buf.Bytes())
+	return nil
+}
+
+// UpdatePeakEnvelope computes a 16 bits, positive and negative, peak
+// envelope of the audio data with a point every blockSize frames
+// (256 is customary), replacing the levl chunk with it.
+func (w *Wav) UpdatePeakEnvelope(blockSize uint32) error {
+	if blockSize == 0 {
+		return fmt.Errorf("invalid block size[%d]", blockSize)
+	}
+	samples, err := w.Samples()
+	if err != nil {
+		return err
+	}
+	channels := int(w.Header.RIFFChunkFmt.NumChannels)
+	if channels == 0 {
+		return fmt.Errorf("invalid number of channels[%d]", channels)
+	}
+
+	frames := len(samples) / channels
+	blocks := (frames + int(blockSize) - 1) / int(blockSize)
+	points := make([]EnvelopePoint, blocks*channels)
+
+	var highest float64
+	peakOfPeaks := uint32(NoPeakOfPeaks)
+	for i, sample := range samples[:frames*channels] {
+		frame := i / channels
+		point := &points[frame/int(blockSize)*channels+i%channels]
+		if sample > point.Positive {
+			point.Positive = sample
+		}
+		if -sample > point.Negative {
+			point.Negative = -sample
+		}
+		if math.Abs(sample) > highest {
+			highest = math.Abs(sample)
+			peakOfPeaks = uint32(frame)
+		}
+	}
+
+	return w.SetPeakEnvelope(&PeakEnvelope{
+		Format:         EnvelopeFormat16,
+		PointsPerValue: 2,
+		BlockSize:      blockSize,
+		Channels:       uint32(channels),
+		PeakOfPeaks:    peakOfPeaks,
+		Timestamp:      time.Now().Format("2006:01:02:15:04:05:000"),
+		Points:         points,
+	})
+}
+
+func envelopePointSize(format uint32) (int, error) {
+	switch format {
+	case EnvelopeFormat8:
+		return 1, nil
+	case EnvelopeFormat16:
+		return 2, nil
+	}
+	return 0, fmt.Errorf("invalid levl format[%d]", format)
+}
+
+// envelopeScale is the full scale of the points, stored as absolute
+// sample values of 8 or 16 bits.
+func envelopeScale(format uint32) float64 {
+	if format == EnvelopeFormat8 {
+		return math.MaxInt8
+	}
+	return math.MaxInt16
+}
@@ -0,0 +1,96 @@
+package waveparser
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestPeakEnvelope(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 8000, 16)
+	samples := make([]float64, 2*1000)
+	for i := range samples {
+		samples[i] = 0.1
+	}
+	samples[2*300] = 0.5     // left, block 1
+	samples[2*300+1] = -0.25 // right, block 1
+	samples[2*999+1] = -0.75 // right, last (partial) block
+	assertNoError(t, wav.SetSamples(samples))
+
+	e, err := wav.PeakEnvelope()
+	assertNoError(t, err)
+	if e != nil {
+		t.Fatalf("expected no levl chunk, got %+v", e)
+	}
+
+	assertNoError(t, wav.UpdatePeakEnvelope(256))
+
+	buf := &bytes.Buffer{}
+	_, err = wav.WriteTo(buf)
+	assertNoError(t, err)
+	parsed, err := ParseBytes(buf.Bytes())
+	assertNoError(t, err)
+
+	e, err = parsed.PeakEnvelope()
+	assertNoError(t, err)
+	if e.Format != EnvelopeFormat16 || e.PointsPerValue != 2 || e.BlockSize != 256 || e.Channels != 2 {
+		t.Fatalf("unexpected envelope header %+v", e)
+	}
+	if e.PeakOfPeaks != 999 || len(e.Timestamp) != 23 {
+		t.Fatalf("unexpected envelope header %+v", e)
+	}
+	if len(e.Points) != 4*2 {
+		t.Fatalf("expected 8 points, got %d", len(e.Points))
+	}
+
+	const tolerance = 1.0 / math.MaxInt16
+	expected := map[int]EnvelopePoint{
+		0: {Positive: 0.1, Negative: 0},
+		2: {Positive: 0.5, Negative: 0},
+		3: {Positive: 0.1, Negative: 0.25},
+		7: {Positive: 0.1, Negative: 0.75},
+	}
+	for i, want := range expected {
+		got := e.Points[i]
+		if math.Abs(got.Positive-want.Positive) > 2*tolerance || math.Abs(got.Negative-want.Negative) > 2*tolerance {
+			t.Fatalf("point[%d]: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	assertNoError(t, wav.SetPeakEnvelope(nil))
+	if wav.Chunk("levl") != nil {
+		t.Fatal("expected levl chunk removed")
+	}
+}
+
+func TestPeakEnvelope8BitsPositive(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetPeakEnvelope(&PeakEnvelope{
+		Format:         EnvelopeFormat8,
+		PointsPerValue: 1,
+		BlockSize:      256,
+		Channels:       1,
+		PeakOfPeaks:    NoPeakOfPeaks,
+		Points:         []EnvelopePoint{{Positive: 1}, {Positive: 0.5}, {Positive: 2}},
+	}))
+	if len(wav.Chunk("levl").Data) != levlHeaderSize+3 {
+		t.Fatalf("unexpected levl size[%d]", len(wav.Chunk("levl").Data))
+	}
+
+	e, err := wav.PeakEnvelope()
+	assertNoError(t, err)
+	expected := []float64{1, 64.0 / 127, 1}
+	for i, v := range expected {
+		if e.Points[i].Positive != v || e.Points[i].Negative != v {
+			t.Fatalf("point[%d]: expected %f, got %+v", i, v, e.Points[i])
+		}
+	}
+
+	assertError(t, wav.SetPeakEnvelope(&PeakEnvelope{Format: 3, PointsPerValue: 1, Channels: 1}))
+	assertError(t, wav.SetPeakEnvelope(&PeakEnvelope{Format: EnvelopeFormat8, PointsPerValue: 1, Channels: 2, Points: make([]EnvelopePoint, 3)}))
+	assertError(t, wav.UpdatePeakEnvelope(0))
+
+	wav.SetChunk("levl", wav.Chunk("levl").Data[:levlHeaderSize+1])
+	_, err = wav.PeakEnvelope()
+	assertError(t, err)
+}
@@ -0,0 +1,121 @@
+package waveparser
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ExpandLoops returns a copy of w with the smpl loops unrolled: the
+// body of each loop is played PlayCount times, or repeats times for
+// loops playing forever, as a sampler would. Alternating loops play
+// every other repetition backwards and backward loops play reversed.
+// Cue points after a loop are moved by the audio inserted before
+// them, and the loops are removed from the smpl chunk. IMA and G.726
+// audio, without byte aligned frames, can't be expanded.
+func (w *Wav) ExpandLoops(repeats int) (*Wav, error) {
+	if repeats < 1 {
+		return nil, fmt.Errorf("invalid loop repeats[%d]", repeats)
+	}
+	sampler, err := w.Sampler()
+	if err != nil {
+		return nil, err
+	}
+	if sampler == nil || len(sampler.Loops) == 0 {
+		return w.Clone(), nil
+	}
+
+	format := w.Header.RIFFChunkFmt
+	block, err := frameSize(format)
+	if err != nil {
+		// IMA blocks and packed G.726 codewords don't split into frames
+		return nil, fmt.Errorf("can't expand loops of format[%s]: %s", format.AudioFormat, err)
+	}
+	if block != int(format.BytesPerBloc) {
+		return nil, fmt.Errorf(
+			"bytes/block[%d] doesn't match the frame size[%d] of the encoding",
+			format.BytesPerBloc,
+			block,
+		)
+	}
+	frames := uint32(len(w.Data) / block)
+
+	loops := append([]SampleLoop(nil), sampler.Loops...)
+	sort.Slice(loops, func(i, j int) bool { return loops[i].Start < loops[j].Start })
+	for i, loop := range loops {
+		if loop.Start > loop.End || loop.End >= frames {
+			return nil, fmt.Errorf("loop[%d] from [%d] to [%d] outside frames[%d]", i, loop.Start, loop.End, frames)
+		}
+		if i > 0 && loop.Start <= loops[i-1].End {
+			return nil, fmt.Errorf("loop[%d] overlaps the previous one", i)
+		}
+	}
+
+	var (
+		data     []byte
+		pos      int
+		inserted []uint32 // frames inserted after each loop end
+	)
+	for _, loop := range loops {
+		start, end := int(loop.Start)*block, (int(loop.End)+1)*block
+		body := w.Data[start:end]
+		reversed := reverseFrames(body, block)
+
+		plays := int(loop.PlayCount)
+		if plays == 0 {
+			plays = repeats
+		}
+
+		data = append(data, w.Data[pos:start]...)
+		for i := 0; i < plays; i++ {
+			backwards := loop.Type == 2 || loop.Type == 1 && i%2 == 1
+			if backwards {
+				data = append(data, reversed...)
+			} else {
+				data = append(data, body...)
+			}
+		}
+		pos = end
+		inserted = append(inserted, uint32((plays-1)*len(body)/block))
+	}
+	data = append(data, w.Data[pos:]...)
+
+	expanded := &Wav{Header: w.Header, Data: data}
+	expanded.Header.DataBlockSize = uint32(len(data))
+	if err := carryMetadata(expanded, w, 0, 1); err != nil {
+		return nil, err
+	}
+
+	points, err := expanded.CuePoints()
+	if err != nil {
+		return nil, err
+	}
+	for i, point := range points {
+		shift := uint32(0)
+		for j, loop := range loops {
+			if point.SampleOffset > loop.End {
+				shift += inserted[j]
+			}
+		}
+		if point.Position == point.SampleOffset {
+			points[i].Position += shift
+		}
+		points[i].SampleOffset += shift
+	}
+	if points != nil {
+		expanded.SetCuePoints(points)
+	}
+
+	sampler.Loops = nil
+	expanded.SetSampler(sampler)
+	expanded.Header.RIFFHdr.ChunkSize = riffChunkSize(expanded.Chunks, expanded.Header.DataBlockSize) +
+		headerExtraSize(&expanded.Header)
+	return expanded, nil
+}
+
+func reverseFrames(data []byte, block int) []byte {
+	reversed := make([]byte, 0, len(data))
+	for i := len(data) - block; i >= 0; i -= block {
+		reversed = append(reversed, data[i:i+block]...)
+	}
+	return reversed
+}
@@ -0,0 +1,74 @@
+package waveparser
+
+import "testing"
+
+func TestExpandLoops(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 1000, 8)
+	wav.Data = []byte{0, 1, 2, 3, 4, 5, 6, 7}
+	wav.Header.DataBlockSize = 8
+	wav.SetCuePoints([]CuePoint{
+		{ID: 1, Position: 1, SampleOffset: 1},
+		{ID: 2, Position: 7, SampleOffset: 7},
+	})
+	wav.SetSampler(&Sampler{Loops: []SampleLoop{
+		{CuePointID: 1, Start: 1, End: 2},
+		{Type: 1, Start: 4, End: 5, PlayCount: 3},
+	}})
+
+	expanded, err := wav.ExpandLoops(2)
+	assertNoError(t, err)
+	assertBytesEqual(t, []byte{0, 1, 2, 1, 2, 3, 4, 5, 5, 4, 4, 5, 6, 7}, expanded.Data)
+
+	if expanded.Header.DataBlockSize != 14 {
+		t.Fatalf("unexpected data block size[%d]", expanded.Header.DataBlockSize)
+	}
+
+	points, err := expanded.CuePoints()
+	assertNoError(t, err)
+	if points[0].SampleOffset != 1 || points[1].SampleOffset != 13 {
+		t.Fatalf("unexpected cue points: %+v", points)
+	}
+
+	sampler, err := expanded.Sampler()
+	assertNoError(t, err)
+	if len(sampler.Loops) != 0 {
+		t.Fatalf("expected loops removed: %+v", sampler.Loops)
+	}
+
+	// the source is left untouched
+	sampler, err = wav.Sampler()
+	assertNoError(t, err)
+	if len(sampler.Loops) != 2 {
+		t.Fatalf("source loops changed: %+v", sampler.Loops)
+	}
+
+	_, err = wav.ExpandLoops(0)
+	assertError(t, err)
+
+	wav.SetSampler(&Sampler{Loops: []SampleLoop{{Start: 1, End: 8}}})
+	_, err = wav.ExpandLoops(1)
+	assertError(t, err)
+}
+
+func TestExpandLoopsFormats(t *testing.T) {
+	loops := &Sampler{Loops: []SampleLoop{{Start: 10, End: 19}}}
+
+	ima := New(WaveFormatIMAADPCM, 1, 8000, 4)
+	assertNoError(t, ima.SetSamples(make([]float64, 8000)))
+	ima.SetSampler(loops)
+	_, err := ima.ExpandLoops(2)
+	assertError(t, err)
+
+	wav, err := ParseBytes(extensibleWav(t, WaveFormatPCM, 0x3, 2))
+	assertNoError(t, err)
+	assertNoError(t, wav.SetSamples(make([]float64, 100*2)))
+	wav.SetSampler(loops)
+
+	expanded, err := wav.ExpandLoops(2)
+	assertNoError(t, err)
+	data, err := expanded.Bytes()
+	assertNoError(t, err)
+	if size := uint32(len(data) - 8); expanded.Header.RIFFHdr.ChunkSize != size {
+		t.Fatalf("expected RIFF size [%d], got [%d]", size, expanded.Header.RIFFHdr.ChunkSize)
+	}
+}
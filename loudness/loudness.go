@@ -0,0 +1,96 @@
+// Package loudness measures loudness as specified by ITU-R BS.1770.
+package loudness
+
+import (
+	"math"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+const (
+	blockDuration   = 0.4 // seconds
+	blockOverlap    = 4   // blocks start every blockDuration/blockOverlap
+	absoluteGate    = -70.0
+	relativeGate    = -10.0
+	loudnessOffset  = -0.691
+	surroundWeight  = 1.41
+	lfeChannel      = 3
+	surroundChannel = 4
+)
+
+// biquad is a direct form I second order IIR filter.
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+	x1, x2, y1, y2     float64
+}
+
+func (f *biquad) process(x float64) float64 {
+	y := f.b0*x + f.b1*f.x1 + f.b2*f.x2 - f.a1*f.y1 - f.a2*f.y2
+	f.x2, f.x1 = f.x1, x
+	f.y2, f.y1 = f.y1, y
+	return y
+}
+
+// kWeighting returns the two filter stages of the BS.1770 K-weighting
+// for the sample rate (coefficients derived as in libebur128).
+func kWeighting(sampleRate float64) (*biquad, *biquad) {
+	f0 := 1681.974450955533
+	g := 3.999843853973347
+	q := 0.7071752369554196
+
+	k := math.Tan(math.Pi * f0 / sampleRate)
+	vh := math.Pow(10, g/20)
+	vb := math.Pow(vh, 0.4996667741545416)
+	a0 := 1 + k/q + k*k
+
+	shelf := &biquad{
+		b0: (vh + vb*k/q + k*k) / a0,
+		b1: 2 * (k*k - vh) / a0,
+		b2: (vh - vb*k/q + k*k) / a0,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+
+	f0 = 38.13547087602444
+	q = 0.5003270373238773
+	k = math.Tan(math.Pi * f0 / sampleRate)
+	a0 = 1 + k/q + k*k
+
+	highpass := &biquad{
+		b0: 1,
+		b1: -2,
+		b2: 1,
+		a1: 2 * (k*k - 1) / a0,
+		a2: (1 - k/q + k*k) / a0,
+	}
+	return shelf, highpass
+}
+
+// channelWeight follows the BS.1770 weighting for the 5.1 channel
+// order (L, R, C, LFE, Ls, Rs).
+func channelWeight(channel int) float64 {
+	switch {
+	case channel == lfeChannel:
+		return 0
+	case channel >= surroundChannel:
+		return surroundWeight
+	}
+	return 1
+}
+
+// Integrated returns the gated integrated loudness of the audio in LUFS,
+// -Inf when it is too quiet to be measured.
+func Integrated(w *waveparser.Wav) (float64, error) {
+	samples, err := w.Samples()
+	if err != nil {
+		return 0, err
+	}
+
+	format := w.Header.RIFFChunkFmt
+	m, err := NewMeter(int(format.NumChannels), format.SampleRate)
+	if err != nil {
+		return 0, err
+	}
+	m.Write(samples)
+	return m.Integrated(), nil
+}
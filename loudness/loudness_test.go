@@ -0,0 +1,47 @@
+package loudness
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+	"github.com/NeowayLabs/waveparser/generate"
+)
+
+func TestIntegratedSine(t *testing.T) {
+	for _, rate := range []uint32{44100, 48000} {
+		format := waveparser.New(waveparser.WaveFormatIEEEFloat, 1, rate, 32).Header.RIFFChunkFmt
+
+		// a -20 dBFS 1 kHz sine measures -23 LUFS (BS.1770 calibration)
+		wav, err := generate.Sine(format, 1000, 0.1, 3*time.Second)
+		assertNoError(t, err)
+
+		lufs, err := Integrated(wav)
+		assertNoError(t, err)
+
+		if math.Abs(lufs+23.01) > 0.05 {
+			t.Fatalf("rate[%d]: expected -23 LUFS, got %f", rate, lufs)
+		}
+	}
+}
+
+func TestIntegratedSilence(t *testing.T) {
+	format := waveparser.New(waveparser.WaveFormatPCM, 2, 16000, 16).Header.RIFFChunkFmt
+	wav, err := generate.Silence(format, time.Second)
+	assertNoError(t, err)
+
+	lufs, err := Integrated(wav)
+	assertNoError(t, err)
+
+	if !math.IsInf(lufs, -1) {
+		t.Fatalf("expected -Inf, got %f", lufs)
+	}
+}
+
+func assertNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
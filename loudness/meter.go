@@ -0,0 +1,235 @@
+package loudness
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+const (
+	shortTermDuration = 3.0 // seconds
+	rangeGate         = -20.0
+	rangeLow          = 0.10
+	rangeHigh         = 0.95
+)
+
+type (
+	// Meter measures loudness incrementally, the audio being written
+	// to it in blocks of any size. Blocks are measured every 100ms as
+	// in EBU Tech 3341 and the loudness range follows EBU Tech 3342.
+	Meter struct {
+		channels int
+		filters  [][2]*biquad
+		peaks    []*peakMeter
+		pending  []float64 // samples of an incomplete frame
+
+		step   int       // frames per 100ms
+		frames int       // frames accumulated into sums
+		sums   []float64 // per channel sums of squared K-weighted samples
+
+		// channel weighted mean powers of the last completed steps,
+		// enough for a short term window
+		steps []float64
+
+		blocks     []float64 // momentary block powers, for integration
+		shortTerms []float64 // short term powers, for the loudness range
+	}
+)
+
+// NewMeter creates a Meter for interleaved audio with the given
+// channel count and sample rate.
+func NewMeter(channels int, sampleRate uint32) (*Meter, error) {
+	step := int(blockDuration * float64(sampleRate) / blockOverlap)
+	if channels <= 0 || step == 0 {
+		return nil, fmt.Errorf("invalid meter: channels[%d] sample rate[%d]", channels, sampleRate)
+	}
+
+	m := &Meter{
+		channels: channels,
+		filters:  make([][2]*biquad, channels),
+		peaks:    make([]*peakMeter, channels),
+		step:     step,
+		sums:     make([]float64, channels),
+	}
+	for c := range m.filters {
+		shelf, highpass := kWeighting(float64(sampleRate))
+		m.filters[c] = [2]*biquad{shelf, highpass}
+		m.peaks[c] = newPeakMeter(sampleRate)
+	}
+	return m, nil
+}
+
+// Measure runs a Meter through the audio of a lazily loaded file, so
+// files too big to fit in memory can be measured.
+func Measure(lw *waveparser.LazyWav) (*Meter, error) {
+	format := lw.Header.RIFFChunkFmt
+	m, err := NewMeter(int(format.NumChannels), format.SampleRate)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]float64, m.step*m.channels)
+	for {
+		n, err := lw.ReadSamples(buf)
+		m.Write(buf[:n])
+		if err == io.EOF {
+			return m, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Write feeds interleaved samples to the meter. A trailing incomplete
+// frame is kept until the next Write.
+func (m *Meter) Write(samples []float64) {
+	if len(m.pending) > 0 {
+		need := m.channels - len(m.pending)
+		if len(samples) < need {
+			m.pending = append(m.pending, samples...)
+			return
+		}
+		m.pending = append(m.pending, samples[:need]...)
+		m.writeFrame(m.pending)
+		m.pending = m.pending[:0]
+		samples = samples[need:]
+	}
+
+	for len(samples) >= m.channels {
+		m.writeFrame(samples[:m.channels])
+		samples = samples[m.channels:]
+	}
+	m.pending = append(m.pending, samples...)
+}
+
+func (m *Meter) writeFrame(frame []float64) {
+	for c, v := range frame {
+		y := m.filters[c][1].process(m.filters[c][0].process(v))
+		m.sums[c] += y * y
+		m.peaks[c].process(v)
+	}
+
+	m.frames++
+	if m.frames == m.step {
+		m.completeStep()
+	}
+}
+
+// completeStep closes the current 100ms step, producing a momentary
+// block and a short term window once there are enough steps.
+func (m *Meter) completeStep() {
+	var power float64
+	for c, sum := range m.sums {
+		power += channelWeight(c) * sum / float64(m.step)
+		m.sums[c] = 0
+	}
+	m.frames = 0
+
+	shortTermSteps := int(shortTermDuration * blockOverlap / blockDuration)
+	m.steps = append(m.steps, power)
+	if len(m.steps) > shortTermSteps {
+		m.steps = m.steps[1:]
+	}
+
+	if len(m.steps) >= blockOverlap {
+		m.blocks = append(m.blocks, meanOf(m.steps[len(m.steps)-blockOverlap:]))
+	}
+	if len(m.steps) == shortTermSteps {
+		m.shortTerms = append(m.shortTerms, meanOf(m.steps))
+	}
+}
+
+// Momentary returns the loudness of the last 400ms in LUFS, -Inf when
+// less audio was written.
+func (m *Meter) Momentary() float64 {
+	if len(m.steps) < blockOverlap {
+		return math.Inf(-1)
+	}
+	return powerLoudness(meanOf(m.steps[len(m.steps)-blockOverlap:]))
+}
+
+// ShortTerm returns the loudness of the last 3s in LUFS, -Inf when
+// less audio was written.
+func (m *Meter) ShortTerm() float64 {
+	if len(m.shortTerms) == 0 {
+		return math.Inf(-1)
+	}
+	return powerLoudness(m.shortTerms[len(m.shortTerms)-1])
+}
+
+// Integrated returns the gated loudness of all the audio written in
+// LUFS, -Inf when it is too quiet to be measured.
+func (m *Meter) Integrated() float64 {
+	gated := gatePowers(m.blocks, absoluteGate)
+	if len(gated) == 0 {
+		return math.Inf(-1)
+	}
+	gated = gatePowers(gated, powerLoudness(meanOf(gated))+relativeGate)
+	if len(gated) == 0 {
+		return math.Inf(-1)
+	}
+	return powerLoudness(meanOf(gated))
+}
+
+// LoudnessRange returns the spread, in LU, between the 10th and 95th
+// percentiles of the gated short term loudness.
+func (m *Meter) LoudnessRange() float64 {
+	gated := gatePowers(m.shortTerms, absoluteGate)
+	if len(gated) == 0 {
+		return 0
+	}
+	gated = gatePowers(gated, powerLoudness(meanOf(gated))+rangeGate)
+	if len(gated) == 0 {
+		return 0
+	}
+
+	levels := make([]float64, len(gated))
+	for i, p := range gated {
+		levels[i] = powerLoudness(p)
+	}
+	sort.Float64s(levels)
+
+	last := float64(len(levels) - 1)
+	low := levels[int(math.Round(last*rangeLow))]
+	high := levels[int(math.Round(last*rangeHigh))]
+	return high - low
+}
+
+// Peaks returns the sample and true peaks of the audio written so far.
+func (m *Meter) Peaks() Peaks {
+	peaks := Peaks{
+		Sample: make([]float64, m.channels),
+		True:   make([]float64, m.channels),
+	}
+	for c, p := range m.peaks {
+		peaks.Sample[c] = decibels(p.samplePeak)
+		peaks.True[c] = decibels(p.truePeak)
+	}
+	return peaks
+}
+
+func powerLoudness(power float64) float64 {
+	return loudnessOffset + 10*math.Log10(power)
+}
+
+func gatePowers(powers []float64, threshold float64) []float64 {
+	var kept []float64
+	for _, p := range powers {
+		if powerLoudness(p) > threshold {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+func meanOf(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
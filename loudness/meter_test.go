@@ -0,0 +1,106 @@
+package loudness
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+	"github.com/NeowayLabs/waveparser/generate"
+)
+
+func TestMeterIncremental(t *testing.T) {
+	format := waveparser.New(waveparser.WaveFormatIEEEFloat, 2, 48000, 32).Header.RIFFChunkFmt
+	wav, err := generate.Sine(format, 1000, 0.1, 4*time.Second)
+	assertNoError(t, err)
+	samples, err := wav.Samples()
+	assertNoError(t, err)
+
+	m, err := NewMeter(2, 48000)
+	assertNoError(t, err)
+
+	// odd sized blocks split frames between writes
+	for len(samples) > 0 {
+		n := 1001
+		if n > len(samples) {
+			n = len(samples)
+		}
+		m.Write(samples[:n])
+		samples = samples[n:]
+	}
+
+	// a stereo -20 dBFS sine is 3 LU louder than the mono calibration
+	for name, lufs := range map[string]float64{
+		"momentary":  m.Momentary(),
+		"short term": m.ShortTerm(),
+		"integrated": m.Integrated(),
+	} {
+		if math.Abs(lufs+20) > 0.05 {
+			t.Fatalf("%s: expected -20 LUFS, got %f", name, lufs)
+		}
+	}
+
+	if lra := m.LoudnessRange(); math.Abs(lra) > 0.05 {
+		t.Fatalf("expected no loudness range for a steady sine, got %f", lra)
+	}
+}
+
+func TestMeterLoudnessRange(t *testing.T) {
+	m, err := NewMeter(1, 8000)
+	assertNoError(t, err)
+
+	// 10s at -30 dBFS then 10s at -10 dBFS
+	for _, amplitude := range []float64{math.Pow(10, -30.0/20), math.Pow(10, -10.0/20)} {
+		format := waveparser.New(waveparser.WaveFormatIEEEFloat, 1, 8000, 32).Header.RIFFChunkFmt
+		wav, err := generate.Sine(format, 1000, amplitude, 10*time.Second)
+		assertNoError(t, err)
+		samples, err := wav.Samples()
+		assertNoError(t, err)
+		m.Write(samples)
+	}
+
+	if lra := m.LoudnessRange(); math.Abs(lra-20) > 1 {
+		t.Fatalf("expected loudness range of 20 LU, got %f", lra)
+	}
+}
+
+func TestMeasureLazy(t *testing.T) {
+	format := waveparser.New(waveparser.WaveFormatPCM, 1, 44100, 16).Header.RIFFChunkFmt
+	wav, err := generate.Sine(format, 1000, 0.1, 3*time.Second)
+	assertNoError(t, err)
+
+	dir, err := ioutil.TempDir("", "loudness")
+	assertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sine.wav")
+	assertNoError(t, wav.Save(path))
+
+	lw, err := waveparser.Open(path)
+	assertNoError(t, err)
+	defer lw.Close()
+
+	m, err := Measure(lw)
+	assertNoError(t, err)
+	if lufs := m.Integrated(); math.Abs(lufs+23.01) > 0.05 {
+		t.Fatalf("expected -23 LUFS, got %f", lufs)
+	}
+}
+
+func TestMeterSilence(t *testing.T) {
+	m, err := NewMeter(1, 16000)
+	assertNoError(t, err)
+	m.Write(make([]float64, 16000))
+
+	if !math.IsInf(m.Integrated(), -1) || !math.IsInf(m.ShortTerm(), -1) {
+		t.Fatalf("expected -Inf, got integrated[%f] short term[%f]", m.Integrated(), m.ShortTerm())
+	}
+
+	_, err = NewMeter(0, 16000)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
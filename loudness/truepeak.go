@@ -0,0 +1,135 @@
+package loudness
+
+import (
+	"math"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+const truePeakTaps = 12 // per oversampling phase, as BS.1770 Annex 2
+
+type (
+	// Peaks holds the per channel peak levels in dB relative to full
+	// scale, -Inf meaning digital silence. True peaks are measured on
+	// the oversampled signal (dBTP), catching inter sample peaks that
+	// a DAC would reconstruct.
+	Peaks struct {
+		Sample []float64
+		True   []float64
+	}
+
+	// peakMeter tracks the sample and true peak of one channel.
+	peakMeter struct {
+		factor     int
+		filter     []float64
+		history    []float64 // last truePeakTaps samples, newest first
+		samplePeak float64
+		truePeak   float64
+	}
+)
+
+// TruePeak measures the sample and true peak of each channel of w.
+func TruePeak(w *waveparser.Wav) (Peaks, error) {
+	samples, err := w.Samples()
+	if err != nil {
+		return Peaks{}, err
+	}
+
+	format := w.Header.RIFFChunkFmt
+	m, err := NewMeter(int(format.NumChannels), format.SampleRate)
+	if err != nil {
+		return Peaks{}, err
+	}
+	m.Write(samples)
+	return m.Peaks(), nil
+}
+
+// MaxSample returns the sample peak of the loudest channel.
+func (p Peaks) MaxSample() float64 {
+	return maxLevel(p.Sample)
+}
+
+// MaxTrue returns the true peak of the loudest channel.
+func (p Peaks) MaxTrue() float64 {
+	return maxLevel(p.True)
+}
+
+func maxLevel(levels []float64) float64 {
+	max := math.Inf(-1)
+	for _, l := range levels {
+		max = math.Max(max, l)
+	}
+	return max
+}
+
+// newPeakMeter oversamples 4x below 96 kHz and 2x below 192 kHz,
+// higher rates are measured as they are.
+func newPeakMeter(sampleRate uint32) *peakMeter {
+	factor := 1
+	switch {
+	case sampleRate < 96000:
+		factor = 4
+	case sampleRate < 192000:
+		factor = 2
+	}
+	return &peakMeter{
+		factor:  factor,
+		filter:  interpolationFilter(factor),
+		history: make([]float64, truePeakTaps),
+	}
+}
+
+// interpolationFilter designs a Hann windowed sinc lowpass at the
+// original Nyquist frequency, with unity gain on every phase.
+func interpolationFilter(factor int) []float64 {
+	size := truePeakTaps * factor
+	center := float64(size-1) / 2
+	filter := make([]float64, size)
+	for i := range filter {
+		x := (float64(i) - center) / float64(factor)
+		window := 0.5 - 0.5*math.Cos(2*math.Pi*(float64(i)+0.5)/float64(size))
+		filter[i] = sinc(x) * window
+	}
+
+	for p := 0; p < factor; p++ {
+		var sum float64
+		for k := 0; k < truePeakTaps; k++ {
+			sum += filter[k*factor+p]
+		}
+		for k := 0; k < truePeakTaps; k++ {
+			filter[k*factor+p] /= sum
+		}
+	}
+	return filter
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+func (p *peakMeter) process(x float64) {
+	p.samplePeak = math.Max(p.samplePeak, math.Abs(x))
+
+	copy(p.history[1:], p.history)
+	p.history[0] = x
+
+	// the true peak is never below the sample peak
+	p.truePeak = math.Max(p.truePeak, p.samplePeak)
+	if p.factor == 1 {
+		return
+	}
+	for phase := 0; phase < p.factor; phase++ {
+		var y float64
+		for k, v := range p.history {
+			y += p.filter[k*p.factor+phase] * v
+		}
+		p.truePeak = math.Max(p.truePeak, math.Abs(y))
+	}
+}
+
+func decibels(amplitude float64) float64 {
+	return 20 * math.Log10(amplitude)
+}
@@ -0,0 +1,44 @@
+package loudness
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+func TestTruePeakInterSample(t *testing.T) {
+	// a quarter sample rate sine sampled 45 degrees off its crests
+	// peaks at -6 dBTP while its samples peak at -9 dBFS
+	wav := waveparser.New(waveparser.WaveFormatIEEEFloat, 2, 48000, 32)
+	samples := make([]float64, 2*4800)
+	for i := 0; i < len(samples)/2; i++ {
+		samples[2*i] = 0.5 * math.Sin(math.Pi/2*float64(i)+math.Pi/4)
+		samples[2*i+1] = 0.25 * math.Sin(math.Pi/2*float64(i)+math.Pi/4)
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	peaks, err := TruePeak(wav)
+	assertNoError(t, err)
+
+	if math.Abs(peaks.MaxSample()-decibels(0.5*math.Sqrt2/2)) > 0.01 {
+		t.Fatalf("expected -9 dBFS sample peak, got %f", peaks.MaxSample())
+	}
+	if math.Abs(peaks.MaxTrue()-decibels(0.5)) > 0.5 {
+		t.Fatalf("expected -6 dBTP true peak, got %f", peaks.MaxTrue())
+	}
+	if peaks.True[1] >= peaks.True[0] {
+		t.Fatalf("expected quieter second channel: %v", peaks.True)
+	}
+}
+
+func TestTruePeakSilence(t *testing.T) {
+	wav := waveparser.New(waveparser.WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 800)))
+
+	peaks, err := TruePeak(wav)
+	assertNoError(t, err)
+	if !math.IsInf(peaks.MaxTrue(), -1) || !math.IsInf(peaks.MaxSample(), -1) {
+		t.Fatalf("expected -Inf peaks, got %+v", peaks)
+	}
+}
@@ -0,0 +1,78 @@
+package waveparser
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestInfoFromFile(t *testing.T) {
+	wav, err := Load("testdata/r.wav")
+	assertNoError(t, err)
+
+	info, err := wav.Info()
+	assertNoError(t, err)
+
+	expected := Info{InfoSoftware: "Lavf56.40.101"}
+	if !reflect.DeepEqual(info, expected) {
+		t.Fatalf("expected info %v, got %v", expected, info)
+	}
+}
+
+func TestMetadataRoundTrip(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.1, 0.2, 0.3}))
+
+	info := Info{InfoTitle: "odd", InfoArtist: "even"}
+	bext := &Bext{
+		Description:     "a description",
+		Originator:      "waveparser",
+		OriginationDate: "2018-09-06",
+		OriginationTime: "10:20:30",
+		TimeReference:   1 << 33,
+		Version:         1,
+		LoudnessValue:   -2300,
+		CodingHistory:   "A=PCM,F=8000,W=16,M=mono\r\n",
+	}
+	wav.SetInfo(info)
+	wav.SetBext(bext)
+
+	buf := &bytes.Buffer{}
+	_, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+
+	var chunks []Chunk
+	hdr, err := parse(bytes.NewReader(buf.Bytes()), func(id [4]byte, size uint32, r io.Reader) error {
+		data, err := ioutil.ReadAll(r)
+		chunks = append(chunks, Chunk{ID: id, Data: data})
+		return err
+	})
+	assertNoError(t, err)
+
+	loaded := &Wav{Header: hdr, Chunks: chunks}
+
+	gotInfo, err := loaded.Info()
+	assertNoError(t, err)
+	if !reflect.DeepEqual(gotInfo, info) {
+		t.Fatalf("expected info %v, got %v", info, gotInfo)
+	}
+
+	gotBext, err := loaded.Bext()
+	assertNoError(t, err)
+	if !reflect.DeepEqual(gotBext, bext) {
+		t.Fatalf("expected bext %#v, got %#v", bext, gotBext)
+	}
+
+	assertBytesEqual(t, wav.Data, buf.Bytes()[hdr.FirstSamplePos:])
+	if hdr.RIFFHdr.ChunkSize != uint32(buf.Len()-8) {
+		t.Fatalf("RIFF size[%d] doesn't match file size[%d]", hdr.RIFFHdr.ChunkSize, buf.Len())
+	}
+
+	loaded.SetInfo(nil)
+	loaded.SetBext(nil)
+	if len(loaded.Chunks) != 0 {
+		t.Fatalf("expected no chunks left, got %v", loaded.Chunks)
+	}
+}
@@ -0,0 +1,39 @@
+package waveparser
+
+import "fmt"
+
+// ToMidSide returns a copy of the stereo w with the left channel
+// replaced by mid, (L+R)/2, and the right by side, (L-R)/2.
+func (w *Wav) ToMidSide() (*Wav, error) {
+	return w.stereoMatrix(func(a, b float64) (float64, float64) {
+		return (a + b) / 2, (a - b) / 2
+	})
+}
+
+// FromMidSide reverts ToMidSide, returning a copy of w with the left
+// and right channels, M+S and M-S.
+func (w *Wav) FromMidSide() (*Wav, error) {
+	return w.stereoMatrix(func(m, s float64) (float64, float64) {
+		return m + s, m - s
+	})
+}
+
+func (w *Wav) stereoMatrix(matrix func(a, b float64) (float64, float64)) (*Wav, error) {
+	if w.Header.RIFFChunkFmt.NumChannels != 2 {
+		return nil, fmt.Errorf("mid/side needs stereo audio, got channels[%d]", w.Header.RIFFChunkFmt.NumChannels)
+	}
+
+	samples, err := w.Samples()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+1 < len(samples); i += 2 {
+		samples[i], samples[i+1] = matrix(samples[i], samples[i+1])
+	}
+
+	out := w.Clone()
+	if err := out.SetSamples(samples); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
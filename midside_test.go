@@ -0,0 +1,29 @@
+package waveparser
+
+import "testing"
+
+func TestMidSide(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 2, 8000, 64)
+	samples := []float64{0.5, 0.5, 0.5, -0.5, 0.25, 0, -1, 1}
+	assertNoError(t, wav.SetSamples(samples))
+
+	ms, err := wav.ToMidSide()
+	assertNoError(t, err)
+	got, err := ms.Samples()
+	assertNoError(t, err)
+	assertSamplesClose(t, []float64{0.5, 0, 0, 0.5, 0.125, 0.125, 0, -1}, got, 0)
+
+	back, err := ms.FromMidSide()
+	assertNoError(t, err)
+	got, err = back.Samples()
+	assertNoError(t, err)
+	assertSamplesClose(t, samples, got, 0)
+
+	// the source is left untouched
+	got, err = wav.Samples()
+	assertNoError(t, err)
+	assertSamplesClose(t, samples, got, 0)
+
+	_, err = New(WaveFormatPCM, 1, 8000, 16).ToMidSide()
+	assertError(t, err)
+}
@@ -0,0 +1,29 @@
+package waveparser
+
+import "fmt"
+
+// ContentType is the MIME type of a file with the given header, with
+// the format code of its audio (the sub format of extensible files)
+// as codec, like "audio/wav; codecs=1" for PCM.
+func ContentType(hdr WavHeader) string {
+	return fmt.Sprintf("audio/wav; codecs=%d", uint16(hdr.RIFFChunkFmt.AudioFormat))
+}
+
+// Extensions suggests file extensions for audio of the given format,
+// the preferred first. WAV files always use ".wav", the others are for
+// headerless audio, like the output of wav2raw.
+func Extensions(format AudioFormat) []string {
+	switch format {
+	case WaveFormatPCM:
+		return []string{".wav", ".pcm", ".raw"}
+	case WaveFormatIEEEFloat:
+		return []string{".wav", ".f32", ".raw"}
+	case WaveFormatALAW:
+		return []string{".wav", ".al", ".alaw"}
+	case WaveFormatMULAW:
+		return []string{".wav", ".ul", ".ulaw"}
+	case WaveFormatG726ITU, WaveFormatG726ADPCM:
+		return []string{".wav", ".g726"}
+	}
+	return []string{".wav"}
+}
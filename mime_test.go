@@ -0,0 +1,36 @@
+package waveparser
+
+import "testing"
+
+func TestContentType(t *testing.T) {
+	type tcase struct {
+		format   AudioFormat
+		expected string
+	}
+
+	tcases := []tcase{
+		{format: WaveFormatPCM, expected: "audio/wav; codecs=1"},
+		{format: WaveFormatIEEEFloat, expected: "audio/wav; codecs=3"},
+		{format: WaveFormatMULAW, expected: "audio/wav; codecs=7"},
+		{format: WaveFormatIMAADPCM, expected: "audio/wav; codecs=17"},
+	}
+
+	for _, tc := range tcases {
+		wav := New(tc.format, 1, 8000, 8)
+		if got := ContentType(wav.Header); got != tc.expected {
+			t.Fatalf("format[%s]: expected [%s], got [%s]", tc.format, tc.expected, got)
+		}
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	for _, format := range []AudioFormat{WaveFormatPCM, WaveFormatMULAW, WaveFormatIMAADPCM, AudioFormat(0x55)} {
+		extensions := Extensions(format)
+		if len(extensions) == 0 || extensions[0] != ".wav" {
+			t.Fatalf("format[%s]: expected .wav first, got %v", format, extensions)
+		}
+	}
+	if ext := Extensions(WaveFormatMULAW); len(ext) != 3 || ext[1] != ".ul" {
+		t.Fatalf("unexpected µ-law extensions %v", ext)
+	}
+}
@@ -0,0 +1,66 @@
+package waveparser
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+)
+
+const (
+	// MultipartMaxDataSize is the audio data budget of LoadMultipart
+	// when no WithMaxDataSize option is given.
+	MultipartMaxDataSize = 256 << 20
+
+	// multipartMetadataSize is the room given to the chunks other than
+	// data on uploads.
+	multipartMetadataSize = 1 << 20
+)
+
+// LoadMultipart parses the WAV file uploaded on the given form field,
+// or on the first part that is a file or has an audio content type
+// when field is empty. The part is parsed as it is read, uploads with
+// audio data larger than MultipartMaxDataSize (or the WithMaxDataSize
+// budget) or with more than 1 MiB of other chunks are refused.
+func LoadMultipart(r *multipart.Reader, field string, opts ...ParseOption) (*Wav, error) {
+	options := newParseOptions(opts)
+	if options.maxDataSize <= 0 {
+		options.maxDataSize = MultipartMaxDataSize
+	}
+
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			return nil, fmt.Errorf("upload has no audio part[%s]", field)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading upload: %s", err)
+		}
+		if !isAudioPart(part, field) {
+			part.Close()
+			continue
+		}
+		defer part.Close()
+
+		// one byte past the limit tells a larger upload from one that
+		// is exactly at it
+		limit := options.maxDataSize + multipartMetadataSize
+		limited := &io.LimitedReader{R: part, N: limit + 1}
+		wav, err := parseWav(newForwardSeeker(limited), nil, options)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing part[%s]: %w", part.FormName(), err)
+		}
+		if limited.N == 0 {
+			return nil, fmt.Errorf("part[%s] is larger than the upload limit", part.FormName())
+		}
+		return wav, nil
+	}
+}
+
+func isAudioPart(part *multipart.Part, field string) bool {
+	if field != "" {
+		return part.FormName() == field
+	}
+	return part.FileName() != "" ||
+		strings.HasPrefix(part.Header.Get("Content-Type"), "audio/")
+}
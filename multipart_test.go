@@ -0,0 +1,67 @@
+package waveparser
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"testing"
+)
+
+func newUpload(t *testing.T, field string, file []byte) *multipart.Reader {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	assertNoError(t, form.WriteField("name", "upload"))
+	part, err := form.CreateFormFile(field, "audio.wav")
+	assertNoError(t, err)
+	_, err = part.Write(file)
+	assertNoError(t, err)
+	assertNoError(t, form.Close())
+
+	return multipart.NewReader(body, form.Boundary())
+}
+
+func TestLoadMultipart(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5, 0.25}))
+	file, err := wav.Bytes()
+	assertNoError(t, err)
+
+	for _, field := range []string{"audio", ""} {
+		loaded, err := LoadMultipart(newUpload(t, "audio", file), field)
+		assertNoError(t, err)
+		assertBytesEqual(t, wav.Data, loaded.Data)
+	}
+
+	_, err = LoadMultipart(newUpload(t, "audio", file), "missing")
+	assertError(t, err)
+
+	_, err = LoadMultipart(newUpload(t, "audio", file), "audio", WithMaxDataSize(4))
+	var sizeErr *DataSizeError
+	if !errors.As(err, &sizeErr) {
+		t.Fatalf("expected a DataSizeError, got %v", err)
+	}
+
+	_, err = LoadMultipart(newUpload(t, "audio", []byte("not a wav")), "audio")
+	assertError(t, err)
+}
+
+func TestLoadMultipartLimit(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 8)))
+	// fills the metadata room: RIFF header, fmt and data chunk headers
+	wav.Chunks = []Chunk{{ID: chunkID("JUNK"), Data: make([]byte, multipartMetadataSize-52)}}
+
+	file, err := wav.Bytes()
+	assertNoError(t, err)
+	if len(file) != 16+multipartMetadataSize {
+		t.Fatalf("expected a file of [%d] bytes, got [%d]", 16+multipartMetadataSize, len(file))
+	}
+
+	_, err = LoadMultipart(newUpload(t, "audio", file), "audio", WithMaxDataSize(16))
+	assertNoError(t, err)
+
+	_, err = LoadMultipart(newUpload(t, "audio", append(file, 0)), "audio", WithMaxDataSize(16))
+	assertError(t, err)
+}
@@ -0,0 +1,175 @@
+package waveparser
+
+type (
+	// ParseOption configures how Parse and Load handle the file.
+	ParseOption func(*parseOptions)
+
+	// SampleOption configures how samples are decoded and encoded.
+	SampleOption func(*sampleOptions)
+
+	// TransformOption configures transforms like Slice and Resample.
+	TransformOption func(*transformOptions)
+
+	// ASROption configures ToASRInput.
+	ASROption func(*asrOptions)
+
+	// WriterOption configures a Writer.
+	WriterOption func(*writerOptions)
+
+	// DataChunkMode selects what to do with files that have more
+	// than one data chunk.
+	DataChunkMode int
+
+	parseOptions struct {
+		dataChunks  DataChunkMode
+		maxDataSize int64
+	}
+
+	sampleOptions struct {
+		workers int
+		dither  Dither
+	}
+
+	transformOptions struct {
+		dropMetadata bool
+	}
+
+	asrOptions struct {
+		sampleRate uint32
+		normalize  bool
+		peak       float64
+	}
+
+	writerOptions struct {
+		peak      bool
+		extension FmtExtension
+	}
+)
+
+const (
+	// DataChunksFirst loads only the first data chunk, the default.
+	DataChunksFirst DataChunkMode = iota
+	// DataChunksConcat concatenates every data chunk into Data. The
+	// header keeps describing the first one.
+	DataChunksConcat
+	// DataChunksList loads the first data chunk into Data and every
+	// one of them into DataChunks.
+	DataChunksList
+)
+
+// WithDataChunks sets how multiple data chunks are handled.
+func WithDataChunks(mode DataChunkMode) ParseOption {
+	return func(o *parseOptions) {
+		o.dataChunks = mode
+	}
+}
+
+// WithMaxDataSize refuses, with a *DataSizeError, to load more than n
+// bytes of audio data, counting every data chunk loaded. The data is
+// read as it arrives, so files declaring bogus sizes load normally
+// while within the budget.
+func WithMaxDataSize(n int64) ParseOption {
+	return func(o *parseOptions) {
+		o.maxDataSize = n
+	}
+}
+
+func newParseOptions(opts []ParseOption) parseOptions {
+	var o parseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithParallelism splits the audio data into ranges converted by up
+// to workers goroutines. Samples are independent of each other, so
+// the result is the same as converting sequentially.
+func WithParallelism(workers int) SampleOption {
+	return func(o *sampleOptions) {
+		o.workers = workers
+	}
+}
+
+// WithDither dithers samples being encoded as PCM.
+func WithDither(d Dither) SampleOption {
+	return func(o *sampleOptions) {
+		o.dither = d
+	}
+}
+
+func newSampleOptions(opts []SampleOption) sampleOptions {
+	o := sampleOptions{workers: 1, dither: NoDither}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// DropMetadata makes transforms return only the audio, without the
+// chunks of the source file.
+func DropMetadata() TransformOption {
+	return func(o *transformOptions) {
+		o.dropMetadata = true
+	}
+}
+
+func newTransformOptions(opts []TransformOption) transformOptions {
+	var o transformOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithASRSampleRate sets the sample rate of ToASRInput, 16 kHz by
+// default (8 kHz for telephony models).
+func WithASRSampleRate(rate uint32) ASROption {
+	return func(o *asrOptions) {
+		o.sampleRate = rate
+	}
+}
+
+// WithASRPeak normalizes the sample peak of ToASRInput to the given
+// level, in dBFS, so quiet recordings reach the models at a usable
+// level.
+func WithASRPeak(peak float64) ASROption {
+	return func(o *asrOptions) {
+		o.normalize = true
+		o.peak = peak
+	}
+}
+
+func newASROptions(opts []ASROption) asrOptions {
+	o := asrOptions{sampleRate: 16000}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithPeakChunk makes the Writer track the peak of each channel and
+// write them as a PEAK chunk when closed, giving editors an instant
+// level display.
+func WithPeakChunk() WriterOption {
+	return func(o *writerOptions) {
+		o.peak = true
+	}
+}
+
+// WithExtension makes the Writer write a WAVE_FORMAT_EXTENSIBLE fmt
+// chunk with the given fields, the format given to NewWriter becoming
+// its sub format.
+func WithExtension(ext FmtExtension) WriterOption {
+	return func(o *writerOptions) {
+		o.extension = ext
+	}
+}
+
+func newWriterOptions(opts []WriterOption) writerOptions {
+	var o writerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,43 @@
+package waveparser
+
+import "fmt"
+
+// OverrideSampleRate replaces the sample rate of the header, keeping
+// the audio data untouched, to fix files whose header doesn't match
+// the rate they were captured at. Use Resample to convert the audio
+// to another rate instead.
+func (w *Wav) OverrideSampleRate(rate uint32) error {
+	if rate == 0 {
+		return fmt.Errorf("invalid sample rate[%d]", rate)
+	}
+
+	w.SetSampleRate(rate)
+	return nil
+}
+
+// OverrideChannels replaces the number of channels of the header,
+// reinterpreting the interleaved audio data as n channels. The data
+// must hold whole frames of the new layout. Cue points and loops,
+// which are positioned in frames, aren't adjusted.
+func (w *Wav) OverrideChannels(n uint16) error {
+	if n == 0 {
+		return fmt.Errorf("invalid number of channels[%d]", n)
+	}
+
+	changed := w.Header.RIFFChunkFmt
+	changed.NumChannels = n
+	changed.BytesPerBloc, changed.BytesPerSec = blockAlign(changed)
+	if changed.BytesPerBloc == 0 {
+		return fmt.Errorf("invalid bits per sample[%d]", changed.BitsPerSample)
+	}
+	if len(w.Data)%int(changed.BytesPerBloc) != 0 {
+		return fmt.Errorf(
+			"data size[%d] isn't a multiple of the frame size[%d] of channels[%d]",
+			len(w.Data),
+			changed.BytesPerBloc,
+			n,
+		)
+	}
+	w.SetChannels(n)
+	return nil
+}
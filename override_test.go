@@ -0,0 +1,42 @@
+package waveparser
+
+import "testing"
+
+func TestOverrideSampleRate(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 8000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 1600)))
+	data := append([]byte(nil), wav.Data...)
+
+	assertNoError(t, wav.OverrideSampleRate(16000))
+
+	expected := New(WaveFormatPCM, 2, 16000, 16).Header.RIFFChunkFmt
+	if wav.Header.RIFFChunkFmt != expected {
+		t.Fatalf("expected fmt %+v, got %+v", expected, wav.Header.RIFFChunkFmt)
+	}
+	assertBytesEqual(t, data, wav.Data)
+
+	assertError(t, wav.OverrideSampleRate(0))
+}
+
+func TestOverrideChannels(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 8000, 24)
+	assertNoError(t, wav.SetSamples(make([]float64, 12)))
+	wav.Header.Extension.ChannelMask = uint32(SpeakerFrontLeft | SpeakerFrontRight)
+
+	assertNoError(t, wav.OverrideChannels(1))
+
+	expected := New(WaveFormatPCM, 1, 8000, 24).Header.RIFFChunkFmt
+	if wav.Header.RIFFChunkFmt != expected {
+		t.Fatalf("expected fmt %+v, got %+v", expected, wav.Header.RIFFChunkFmt)
+	}
+	if wav.Header.Extension.ChannelMask != 0 {
+		t.Fatalf("expected channel mask reset, got %x", wav.Header.Extension.ChannelMask)
+	}
+
+	assertNoError(t, wav.OverrideChannels(4))
+	assertError(t, wav.OverrideChannels(5))
+	assertError(t, wav.OverrideChannels(0))
+	if wav.Channels() != 4 {
+		t.Fatalf("expected 4 channels, got %d", wav.Channels())
+	}
+}
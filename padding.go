@@ -0,0 +1,54 @@
+package waveparser
+
+import "fmt"
+
+type (
+	// Padding is a JUNK or PAD chunk, used by recorders to reserve
+	// space or to align the audio data.
+	Padding struct {
+		ID     [4]byte
+		Offset uint32 // position of the chunk header on the file
+		Size   uint32 // size of the chunk contents
+	}
+)
+
+// DataOffset is where the audio data starts when w is written.
+func (w *Wav) DataOffset() uint32 {
+	offset := riffHeaderSize + chunkHeaderSize + fmtChunkSize + headerExtraSize(&w.Header) + chunkHeaderSize
+	for _, chunk := range w.Chunks {
+		offset += chunkHeaderSize + paddedSize(chunk.Data)
+	}
+	return offset
+}
+
+// AlignData sizes a JUNK chunk right before the data chunk so the
+// audio data starts at a multiple of alignment when w is written. A
+// JUNK/PAD chunk that already is the last one is resized instead.
+func (w *Wav) AlignData(alignment uint32) error {
+	if alignment == 0 || alignment%2 != 0 {
+		return fmt.Errorf("alignment[%d] must be a positive even number", alignment)
+	}
+
+	id := chunkID("JUNK")
+	if last := len(w.Chunks) - 1; last >= 0 && isPadding(w.Chunks[last].ID) {
+		id = w.Chunks[last].ID
+		w.Chunks = w.Chunks[:last]
+	}
+
+	// chunks are padded to even sizes, so the JUNK size is even too
+	unaligned := (w.DataOffset() + chunkHeaderSize) % alignment
+	size := (alignment - unaligned) % alignment
+	w.Chunks = append(w.Chunks, Chunk{ID: id, Data: make([]byte, size)})
+
+	w.Header.FirstSamplePos = w.DataOffset()
+	w.Header.RIFFHdr.ChunkSize = riffChunkSize(w.Chunks, uint32(len(w.Data))) + headerExtraSize(&w.Header)
+	return nil
+}
+
+func isPadding(id [4]byte) bool {
+	switch string(id[:]) {
+	case "JUNK", "junk", "PAD ":
+		return true
+	}
+	return false
+}
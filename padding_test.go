@@ -0,0 +1,39 @@
+package waveparser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAlignData(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 48000, 24)
+	wav.SetChunk("LIST", []byte("INFOsome tags"))
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5}))
+
+	for _, alignment := range []uint32{4096, 512, 2} {
+		assertNoError(t, wav.AlignData(alignment))
+		if wav.Header.FirstSamplePos%alignment != 0 {
+			t.Fatalf("data at [%d] isn't aligned to [%d]", wav.Header.FirstSamplePos, alignment)
+		}
+
+		buf := &bytes.Buffer{}
+		_, err := wav.WriteTo(buf)
+		assertNoError(t, err)
+
+		parsed, err := ParseBytes(buf.Bytes())
+		assertNoError(t, err)
+
+		if parsed.Header != wav.Header {
+			t.Fatalf("expected header %+v, got %+v", wav.Header, parsed.Header)
+		}
+		if len(parsed.Padding) != 1 {
+			t.Fatalf("expected one padding chunk, got %v", parsed.Padding)
+		}
+		junk := parsed.Padding[0]
+		if junk.Offset+chunkHeaderSize+junk.Size+chunkHeaderSize != parsed.Header.FirstSamplePos {
+			t.Fatalf("padding %+v should end right before the data chunk", junk)
+		}
+	}
+
+	assertError(t, wav.AlignData(3))
+}
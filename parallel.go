@@ -0,0 +1,34 @@
+package waveparser
+
+import "sync"
+
+// minSamplesPerWorker avoids spawning goroutines for tiny ranges,
+// where the synchronization costs more than the conversion.
+const minSamplesPerWorker = 64 * 1024
+
+// inParallel calls convert over consecutive ranges covering [0, n),
+// concurrently when workers > 1 and n is large enough.
+func inParallel(n int, workers int, convert func(start, end int)) {
+	if max := n / minSamplesPerWorker; workers > max {
+		workers = max
+	}
+	if workers <= 1 {
+		convert(0, n)
+		return
+	}
+
+	var wg sync.WaitGroup
+	step := (n + workers - 1) / workers
+	for start := 0; start < n; start += step {
+		end := start + step
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			convert(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
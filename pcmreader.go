@@ -0,0 +1,105 @@
+package waveparser
+
+import (
+	"fmt"
+	"io"
+)
+
+type (
+	// SampleFormat is a raw PCM sample encoding, as used by tools
+	// like ffmpeg (s16le, f32le...).
+	SampleFormat int
+
+	pcmReader struct {
+		data    []byte
+		decode  sampleDecodeFunc
+		inSize  int
+		frame   int
+		encode  sampleEncodeFunc
+		outSize int
+		buf     []byte
+		err     error
+	}
+)
+
+const (
+	SampleUint8 SampleFormat = iota
+	SampleInt16LE
+	SampleInt24LE
+	SampleInt32LE
+	SampleFloat32LE
+	SampleFloat64LE
+)
+
+// samples converted per Read call on the PCMReader
+const pcmReaderBatch = 4096
+
+func (f SampleFormat) riffFmt() (RiffChunkFmt, error) {
+	switch f {
+	case SampleUint8:
+		return RiffChunkFmt{AudioFormat: WaveFormatPCM, BitsPerSample: 8}, nil
+	case SampleInt16LE:
+		return RiffChunkFmt{AudioFormat: WaveFormatPCM, BitsPerSample: 16}, nil
+	case SampleInt24LE:
+		return RiffChunkFmt{AudioFormat: WaveFormatPCM, BitsPerSample: 24}, nil
+	case SampleInt32LE:
+		return RiffChunkFmt{AudioFormat: WaveFormatPCM, BitsPerSample: 32}, nil
+	case SampleFloat32LE:
+		return RiffChunkFmt{AudioFormat: WaveFormatIEEEFloat, BitsPerSample: 32}, nil
+	case SampleFloat64LE:
+		return RiffChunkFmt{AudioFormat: WaveFormatIEEEFloat, BitsPerSample: 64}, nil
+	}
+	return RiffChunkFmt{}, fmt.Errorf("unknown sample format[%d]", f)
+}
+
+// PCMReader streams the audio data converted to the given raw sample
+// format, interleaved as in the file, decoding it on demand. A trailing
+// incomplete frame is dropped. Conversion errors are returned by Read.
+func (w *Wav) PCMReader(format SampleFormat) io.Reader {
+	r := &pcmReader{data: w.Data}
+
+	out, err := format.riffFmt()
+	if err != nil {
+		r.err = err
+		return r
+	}
+
+	if r.frame, r.err = frameSize(w.Header.RIFFChunkFmt); r.err != nil {
+		return r
+	}
+	r.inSize = r.frame / int(w.Header.RIFFChunkFmt.NumChannels)
+	if r.decode, r.err = sampleDecoder(w.Header.RIFFChunkFmt); r.err != nil {
+		return r
+	}
+	r.outSize = int(out.BitsPerSample / 8)
+	r.encode, r.err = sampleEncoder(out)
+	return r
+}
+
+func (r *pcmReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	if len(r.buf) == 0 {
+		// whole frames only
+		n := len(r.data) / r.frame * (r.frame / r.inSize)
+		if n == 0 {
+			r.err = io.EOF
+			return 0, r.err
+		}
+		if n > pcmReaderBatch {
+			n = pcmReaderBatch
+		}
+
+		r.buf = make([]byte, n*r.outSize)
+		for i := 0; i < n; i++ {
+			r.encode(r.buf[i*r.outSize:], r.decode(r.data[i*r.inSize:]))
+		}
+		r.data = r.data[n*r.inSize:]
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
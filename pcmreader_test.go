@@ -0,0 +1,57 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"testing"
+)
+
+func TestPCMReader(t *testing.T) {
+	wav, err := Load("testdata/audios/sint16le.wav")
+	assertNoError(t, err)
+
+	got, err := ioutil.ReadAll(wav.PCMReader(SampleFloat32LE))
+	assertNoError(t, err)
+
+	samples, err := wav.Samples()
+	assertNoError(t, err)
+
+	expected := &bytes.Buffer{}
+	for _, s := range samples {
+		assertNoError(t, binary.Write(expected, binary.LittleEndian, float32(s)))
+	}
+	assertBytesEqual(t, expected.Bytes(), got)
+
+	same, err := ioutil.ReadAll(wav.PCMReader(SampleInt16LE))
+	assertNoError(t, err)
+	assertBytesEqual(t, wav.Data, same)
+}
+
+func TestPCMReaderErrors(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+
+	_, err := ioutil.ReadAll(wav.PCMReader(SampleFormat(42)))
+	assertError(t, err)
+
+	wav.Header.RIFFChunkFmt.BitsPerSample = 12
+	_, err = ioutil.ReadAll(wav.PCMReader(SampleInt16LE))
+	assertError(t, err)
+}
+
+func TestPCMReaderDropsIncompleteFrame(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5, 0.25, -0.25}))
+	// half of a third frame, only the left sample
+	wav.Data = append(wav.Data, 0x00, 0x10)
+
+	got, err := ioutil.ReadAll(wav.PCMReader(SampleInt16LE))
+	assertNoError(t, err)
+	assertBytesEqual(t, wav.Data[:8], got)
+
+	samples, err := wav.Samples()
+	assertNoError(t, err)
+	if len(samples) != 4 {
+		t.Fatalf("expected 4 samples, got %d", len(samples))
+	}
+}
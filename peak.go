@@ -0,0 +1,122 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+type (
+	// Peak is the PEAK chunk, holding the peak of each channel so level
+	// meters can be drawn without scanning the audio data.
+	Peak struct {
+		Version   uint32
+		Timestamp uint32 // seconds since 1970-01-01
+		Channels  []ChannelPeak
+	}
+
+	// ChannelPeak is the peak of a channel, Value is normalized like
+	// the samples and Position is the frame where it happens.
+	ChannelPeak struct {
+		Value    float32
+		Position uint32
+	}
+
+	peakHeader struct {
+		Version   uint32
+		Timestamp uint32
+	}
+)
+
+// Peak parses the PEAK chunk, returning nil when the file has none.
+func (w *Wav) Peak() (*Peak, error) {
+	chunk := w.Chunk("PEAK")
+	if chunk == nil {
+		return nil, nil
+	}
+
+	var hdr peakHeader
+	r := bytes.NewReader(chunk.Data)
+	if err := binary.Read(r, binary.LittleEndian, &hdr); err != nil {
+		return nil, fmt.Errorf("error parsing PEAK chunk: %s", err)
+	}
+
+	channels := int(w.Header.RIFFChunkFmt.NumChannels)
+	if channels*8 > r.Len() {
+		return nil, fmt.Errorf(
+			"PEAK chunk for channels[%d] has only [%d] bytes",
+			channels,
+			r.Len(),
+		)
+	}
+
+	p := &Peak{
+		Version:   hdr.Version,
+		Timestamp: hdr.Timestamp,
+		Channels:  make([]ChannelPeak, channels),
+	}
+	binary.Read(r, binary.LittleEndian, p.Channels)
+	return p, nil
+}
+
+// SetPeak replaces the PEAK chunk, removing it when p is nil.
+func (w *Wav) SetPeak(p *Peak) {
+	if p == nil {
+		w.RemoveChunk("PEAK")
+		return
+	}
+	w.SetChunk("PEAK", peakChunkData(p))
+}
+
+// UpdatePeak computes the peak of each channel of the audio data,
+// replacing the PEAK chunk with them.
+func (w *Wav) UpdatePeak() error {
+	samples, err := w.Samples()
+	if err != nil {
+		return err
+	}
+	channels := int(w.Header.RIFFChunkFmt.NumChannels)
+	if channels == 0 {
+		return fmt.Errorf("invalid number of channels[%d]", channels)
+	}
+
+	p := newPeak(channels)
+	p.track(samples, 0)
+	w.SetPeak(p)
+	return nil
+}
+
+func newPeak(channels int) *Peak {
+	return &Peak{
+		Version:   1,
+		Timestamp: uint32(time.Now().Unix()),
+		Channels:  make([]ChannelPeak, channels),
+	}
+}
+
+// track updates the peaks with interleaved samples, first is the index
+// of the first of them on the audio data.
+func (p *Peak) track(samples []float64, first int) {
+	channels := len(p.Channels)
+	for i, sample := range samples {
+		v := float32(math.Abs(sample))
+		index := first + i
+		peak := &p.Channels[index%channels]
+		if v > peak.Value {
+			peak.Value = v
+			peak.Position = uint32(index / channels)
+		}
+	}
+}
+
+func peakChunkData(p *Peak) []byte {
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.LittleEndian, peakHeader{
+		Version:   p.Version,
+		Timestamp: p.Timestamp,
+	})
+	binary.Write(buf, binary.LittleEndian, p.Channels)
+	return buf.Bytes()
+}
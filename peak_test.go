@@ -0,0 +1,68 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestPeak(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 2, 8000, 32)
+
+	p, err := wav.Peak()
+	assertNoError(t, err)
+	if p != nil {
+		t.Fatalf("expected no PEAK chunk, got %+v", p)
+	}
+
+	chunk := &bytes.Buffer{}
+	binary.Write(chunk, binary.LittleEndian, []uint32{1, 1500000000})
+	binary.Write(chunk, binary.LittleEndian, []ChannelPeak{
+		{Value: 0.5, Position: 10},
+		{Value: 0.25, Position: 20},
+	})
+	wav.SetChunk("PEAK", chunk.Bytes())
+
+	buf := &bytes.Buffer{}
+	_, err = wav.WriteTo(buf)
+	assertNoError(t, err)
+	parsed, err := ParseBytes(buf.Bytes())
+	assertNoError(t, err)
+
+	p, err = parsed.Peak()
+	assertNoError(t, err)
+	if p.Version != 1 || p.Timestamp != 1500000000 || len(p.Channels) != 2 {
+		t.Fatalf("unexpected PEAK %+v", p)
+	}
+	if p.Channels[0] != (ChannelPeak{Value: 0.5, Position: 10}) ||
+		p.Channels[1] != (ChannelPeak{Value: 0.25, Position: 20}) {
+		t.Fatalf("unexpected channel peaks %+v", p.Channels)
+	}
+
+	wav.SetChunk("PEAK", chunk.Bytes()[:12])
+	_, err = wav.Peak()
+	assertError(t, err)
+}
+
+func TestUpdatePeak(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 2, 8000, 32)
+	assertNoError(t, wav.SetSamples([]float64{0.1, -0.2, -0.5, 0.1, 0.5, 0.75}))
+	assertNoError(t, wav.UpdatePeak())
+
+	p, err := wav.Peak()
+	assertNoError(t, err)
+	expected := []ChannelPeak{{Value: 0.5, Position: 1}, {Value: 0.75, Position: 2}}
+	if p.Version != 1 || p.Timestamp == 0 {
+		t.Fatalf("unexpected PEAK header %+v", p)
+	}
+	for i := range expected {
+		if p.Channels[i] != expected[i] {
+			t.Fatalf("channel[%d]: expected %+v, got %+v", i, expected[i], p.Channels[i])
+		}
+	}
+
+	wav.SetPeak(nil)
+	if wav.Chunk("PEAK") != nil {
+		t.Fatal("expected PEAK chunk removed")
+	}
+}
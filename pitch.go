@@ -0,0 +1,119 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type (
+	// PitchConfig configures Pitch, zero fields taking the defaults
+	// suited to speech.
+	PitchConfig struct {
+		MinFreq   float64       // lowest f0 searched, 50 Hz by default
+		MaxFreq   float64       // highest f0 searched, 500 Hz by default
+		Frame     time.Duration // analysis window, 40ms by default
+		Hop       time.Duration // distance between frames, 10ms by default
+		Threshold float64       // YIN aperiodicity threshold, 0.15 by default
+	}
+)
+
+func (cfg PitchConfig) withDefaults() PitchConfig {
+	if cfg.MinFreq == 0 {
+		cfg.MinFreq = 50
+	}
+	if cfg.MaxFreq == 0 {
+		cfg.MaxFreq = 500
+	}
+	if cfg.Frame == 0 {
+		cfg.Frame = 40 * time.Millisecond
+	}
+	if cfg.Hop == 0 {
+		cfg.Hop = 10 * time.Millisecond
+	}
+	if cfg.Threshold == 0 {
+		cfg.Threshold = 0.15
+	}
+	return cfg
+}
+
+// Pitch estimates the fundamental frequency of each frame of the
+// audio, all channels mixed down, with the YIN algorithm. Unvoiced
+// frames have f0 0. The frame must span two periods of MinFreq.
+func Pitch(wav *Wav, cfg PitchConfig) ([]float64, error) {
+	cfg = cfg.withDefaults()
+	rate := float64(wav.Header.RIFFChunkFmt.SampleRate)
+	if cfg.MinFreq <= 0 || cfg.MaxFreq <= cfg.MinFreq || cfg.MaxFreq >= rate/2 {
+		return nil, fmt.Errorf(
+			"invalid pitch range: min[%f] max[%f] samplerate[%d]",
+			cfg.MinFreq,
+			cfg.MaxFreq,
+			wav.Header.RIFFChunkFmt.SampleRate,
+		)
+	}
+
+	minLag := int(rate / cfg.MaxFreq)
+	maxLag := int(math.Ceil(rate / cfg.MinFreq))
+	if int(cfg.Frame.Seconds()*rate) < 2*maxLag {
+		return nil, fmt.Errorf("frame[%s] shorter than two periods of [%f] Hz", cfg.Frame, cfg.MinFreq)
+	}
+
+	diff := make([]float64, maxLag+1)
+	return wav.framewise(cfg.Frame, cfg.Hop, func(x []float64) float64 {
+		tau := yin(x, diff, minLag, maxLag, cfg.Threshold)
+		if tau == 0 {
+			return 0
+		}
+		return rate / tau
+	})
+}
+
+// yin returns the fractional lag of the period of x, 0 when none has
+// an aperiodicity under threshold.
+func yin(x, diff []float64, minLag, maxLag int, threshold float64) float64 {
+	window := len(x) - maxLag
+	for tau := 1; tau <= maxLag; tau++ {
+		var d float64
+		for j := 0; j < window; j++ {
+			delta := x[j] - x[j+tau]
+			d += delta * delta
+		}
+		diff[tau] = d
+	}
+
+	// cumulative mean normalized difference
+	diff[0] = 1
+	var sum float64
+	for tau := 1; tau <= maxLag; tau++ {
+		sum += diff[tau]
+		if sum == 0 {
+			diff[tau] = 1
+			continue
+		}
+		diff[tau] *= float64(tau) / sum
+	}
+
+	for tau := minLag; tau <= maxLag; tau++ {
+		if diff[tau] >= threshold {
+			continue
+		}
+		for tau+1 <= maxLag && diff[tau+1] < diff[tau] {
+			tau++
+		}
+		return refineLag(diff, tau, maxLag)
+	}
+	return 0
+}
+
+// refineLag interpolates the minimum around tau with a parabola.
+func refineLag(diff []float64, tau, maxLag int) float64 {
+	if tau <= 1 || tau >= maxLag {
+		return float64(tau)
+	}
+	prev, cur, next := diff[tau-1], diff[tau], diff[tau+1]
+	denom := prev - 2*cur + next
+	if denom == 0 {
+		return float64(tau)
+	}
+	return float64(tau) + (prev-next)/(2*denom)
+}
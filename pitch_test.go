@@ -0,0 +1,46 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPitch(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 16000, 16)
+
+	// 220 Hz for half a second, then silence
+	samples := make([]float64, 16000)
+	for i := 0; i < 8000; i++ {
+		samples[i] = 0.5 * math.Sin(2*math.Pi*220*float64(i)/16000)
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	track, err := Pitch(wav, PitchConfig{})
+	assertNoError(t, err)
+
+	if len(track) != 97 {
+		t.Fatalf("expected 97 frames, got %d", len(track))
+	}
+	for i, f0 := range track[:40] {
+		if math.Abs(f0-220) > 1 {
+			t.Fatalf("frame[%d]: expected 220 Hz, got %f", i, f0)
+		}
+	}
+	for i, f0 := range track[60:] {
+		if f0 != 0 {
+			t.Fatalf("frame[%d]: expected unvoiced, got %f", i+60, f0)
+		}
+	}
+}
+
+func TestPitchInvalidConfig(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 16000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 1600)))
+
+	_, err := Pitch(wav, PitchConfig{MinFreq: 40, Frame: 20 * time.Millisecond})
+	assertError(t, err)
+
+	_, err = Pitch(wav, PitchConfig{MaxFreq: 9000})
+	assertError(t, err)
+}
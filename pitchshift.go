@@ -0,0 +1,128 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	stretchFrame     = 0.04 // seconds, WSOLA window
+	stretchTolerance = 0.5  // of the hop, searched for the best overlap
+)
+
+// PitchShift returns a copy of w with the pitch moved by the given
+// semitones, keeping its duration: the audio is time stretched with
+// WSOLA and then resampled back to its original length.
+func (w *Wav) PitchShift(semitones float64) (*Wav, error) {
+	format := w.Header.RIFFChunkFmt
+	channels := int(format.NumChannels)
+	if channels == 0 || format.SampleRate == 0 {
+		return nil, fmt.Errorf(
+			"can't pitch shift channels[%d] samplerate[%d]",
+			format.NumChannels,
+			format.SampleRate,
+		)
+	}
+
+	samples, err := w.Samples()
+	if err != nil {
+		return nil, err
+	}
+
+	factor := math.Pow(2, semitones/12)
+	stretched := timeStretch(samples, channels, int(stretchFrame*float64(format.SampleRate)), factor)
+
+	// playing the stretched audio factor times faster restores the
+	// duration, raising the pitch by factor
+	from := uint32(math.Round(float64(format.SampleRate) * factor))
+	shifted, err := resample(stretched, channels, from, format.SampleRate, ResampleSinc)
+	if err != nil {
+		return nil, err
+	}
+
+	// stretching rounds the length to whole hops
+	frames := len(samples) / channels
+	if len(shifted) > frames*channels {
+		shifted = shifted[:frames*channels]
+	}
+
+	out := New(format.AudioFormat, format.NumChannels, format.SampleRate, format.BitsPerSample)
+	if err := out.SetSamples(shifted); err != nil {
+		return nil, err
+	}
+	if err := carryMetadata(out, w, 0, 1); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// timeStretch makes the interleaved samples factor times longer,
+// keeping their pitch, with waveform similarity overlap add: each
+// window is taken near its nominal position, where it best continues
+// the audio already written.
+func timeStretch(samples []float64, channels, size int, factor float64) []float64 {
+	frames := len(samples) / channels
+	if size < 4 || frames < size || factor == 1 {
+		return samples
+	}
+
+	mono, _ := remix(samples, channels, 1)
+	hop := size / 2
+	tolerance := int(float64(hop) * stretchTolerance)
+	stride := size/256 + 1
+
+	window := make([]float64, size)
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(size))
+	}
+
+	outFrames := int(float64(frames)*factor) + size
+	out := make([]float64, outFrames*channels)
+	weights := make([]float64, outFrames)
+
+	previous := 0
+	for k := 0; ; k++ {
+		at := k * hop
+		nominal := int(float64(at) / factor)
+		if at+size > outFrames || nominal+size > frames {
+			break
+		}
+
+		pos := nominal
+		if k > 0 {
+			// the natural continuation of the previous window
+			natural := previous + hop
+			best := math.Inf(-1)
+			for delta := -tolerance; delta <= tolerance; delta++ {
+				candidate := nominal + delta
+				if candidate < 0 || candidate+size > frames || natural+size > frames {
+					continue
+				}
+				var corr float64
+				for i := 0; i < size; i += stride {
+					corr += mono[candidate+i] * mono[natural+i]
+				}
+				if corr > best {
+					best, pos = corr, candidate
+				}
+			}
+		}
+		previous = pos
+
+		for i := 0; i < size; i++ {
+			weights[at+i] += window[i]
+			for c := 0; c < channels; c++ {
+				out[(at+i)*channels+c] += window[i] * samples[(pos+i)*channels+c]
+			}
+		}
+	}
+
+	for i, weight := range weights {
+		if weight > 1e-3 {
+			for c := 0; c < channels; c++ {
+				out[i*channels+c] /= weight
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package waveparser
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestPitchShift(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 16000, 16)
+	samples := make([]float64, 2*16000)
+	for i := 0; i < len(samples)/2; i++ {
+		s := 0.5 * math.Sin(2*math.Pi*200*float64(i)/16000)
+		samples[2*i], samples[2*i+1] = s, s
+	}
+	assertNoError(t, wav.SetSamples(samples))
+	wav.SetInfo(Info{InfoTitle: "shifted"})
+
+	for _, tcase := range []struct {
+		semitones float64
+		expected  float64
+	}{
+		{semitones: 12, expected: 400},
+		{semitones: -12, expected: 100},
+		{semitones: 7, expected: 200 * math.Pow(2, 7.0/12)},
+	} {
+		shifted, err := wav.PitchShift(tcase.semitones)
+		assertNoError(t, err)
+
+		if d := shifted.Duration() - wav.Duration(); d > wav.Duration()/100 || -d > wav.Duration()/100 {
+			t.Fatalf("semitones[%f]: duration changed from %s to %s", tcase.semitones, wav.Duration(), shifted.Duration())
+		}
+
+		track, err := Pitch(shifted, PitchConfig{})
+		assertNoError(t, err)
+		sort.Float64s(track)
+		median := track[len(track)/2]
+		if math.Abs(median-tcase.expected) > tcase.expected*0.02 {
+			t.Fatalf("semitones[%f]: expected %f Hz, got %f", tcase.semitones, tcase.expected, median)
+		}
+
+		info, err := shifted.Info()
+		assertNoError(t, err)
+		if info[InfoTitle] != "shifted" {
+			t.Fatalf("metadata not preserved: %v", info)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package waveparser
+
+import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// ProbeDuration returns the playing time of the WAV file at the given
+// path reading only its header. The sample length of the fact chunk
+// is preferred when present, since the bytes/second of compressed
+// formats is often an approximation.
+func ProbeDuration(audiofile string) (time.Duration, error) {
+	f, err := os.Open(audiofile)
+	if err != nil {
+		return 0, err
+	}
+
+	defer f.Close()
+
+	return probeDuration(f)
+}
+
+func probeDuration(r io.ReadSeeker) (time.Duration, error) {
+	var fact []byte
+	onChunk := func(id [4]byte, size uint32, chunk io.Reader) error {
+		if string(id[:]) != "fact" {
+			return nil
+		}
+		var err error
+		fact, err = ioutil.ReadAll(chunk)
+		return err
+	}
+
+	hdr, err := parse(r, onChunk)
+	if err != nil {
+		return 0, err
+	}
+
+	rate := hdr.RIFFChunkFmt.SampleRate
+	if len(fact) >= 4 && rate > 0 {
+		frames := binary.LittleEndian.Uint32(fact)
+		return time.Duration(float64(frames) / float64(rate) * float64(time.Second)), nil
+	}
+	return hdr.duration(), nil
+}
@@ -0,0 +1,46 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestProbeDuration(t *testing.T) {
+	const audiofile = "./testdata/audios/sint16le.wav"
+
+	wav, err := Load(audiofile)
+	assertNoError(t, err)
+
+	got, err := ProbeDuration(audiofile)
+	assertNoError(t, err)
+	if got != wav.Duration() {
+		t.Fatalf("expected duration %s, got %s", wav.Duration(), got)
+	}
+
+	_, err = ProbeDuration("./testdata/audios/sint16le.raw")
+	assertError(t, err)
+	_, err = ProbeDuration("./testdata/nonexistent.wav")
+	assertError(t, err)
+}
+
+func TestProbeDurationUsesFact(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 8000)))
+
+	fact := make([]byte, 4)
+	binary.LittleEndian.PutUint32(fact, 4000)
+	wav.Chunks = append(wav.Chunks, Chunk{ID: [4]byte{'f', 'a', 'c', 't'}, Data: fact})
+	wav.Header.RIFFHdr.ChunkSize = riffChunkSize(wav.Chunks, wav.Header.DataBlockSize)
+
+	var buf bytes.Buffer
+	_, err := wav.WriteTo(&buf)
+	assertNoError(t, err)
+
+	got, err := probeDuration(bytes.NewReader(buf.Bytes()))
+	assertNoError(t, err)
+	if got != 500*time.Millisecond {
+		t.Fatalf("expected 500ms, got %s", got)
+	}
+}
@@ -0,0 +1,78 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+)
+
+type (
+	// QuantizationReport measures the error introduced by encoding
+	// samples, normalized as in Wav.Samples.
+	QuantizationReport struct {
+		Samples   int
+		MaxError  float64
+		MeanError float64 // mean absolute error
+		Clipped   int     // samples beyond full scale saturated by the encoding
+	}
+)
+
+// Exceeds reports whether the encoding clipped any sample or had an
+// error greater than maxError.
+func (r QuantizationReport) Exceeds(maxError float64) bool {
+	return r.Clipped > 0 || r.MaxError > maxError
+}
+
+func (r QuantizationReport) String() string {
+	return fmt.Sprintf(
+		"samples[%d] max error[%g] mean error[%g] clipped[%d]",
+		r.Samples,
+		r.MaxError,
+		r.MeanError,
+		r.Clipped,
+	)
+}
+
+// SetSamplesReport replaces the audio data like SetSamples, reporting
+// the error introduced by encoding the samples.
+func (w *Wav) SetSamplesReport(samples []float64, opts ...SampleOption) (QuantizationReport, error) {
+	if err := w.SetSamples(samples, opts...); err != nil {
+		return QuantizationReport{}, err
+	}
+	return w.quantizationReport(samples)
+}
+
+// quantizationReport compares the samples encoded on w with the
+// decoded audio data.
+func (w *Wav) quantizationReport(samples []float64) (QuantizationReport, error) {
+	decoded, err := w.Samples()
+	if err != nil {
+		return QuantizationReport{}, err
+	}
+	if len(decoded) != len(samples) {
+		return QuantizationReport{}, fmt.Errorf(
+			"encoded samples[%d] decoded as [%d]",
+			len(samples),
+			len(decoded),
+		)
+	}
+
+	// floats keep values beyond full scale
+	saturates := w.Header.RIFFChunkFmt.AudioFormat != WaveFormatIEEEFloat
+
+	report := QuantizationReport{Samples: len(samples)}
+	var sum float64
+	for i, s := range samples {
+		e := math.Abs(s - decoded[i])
+		sum += e
+		if e > report.MaxError {
+			report.MaxError = e
+		}
+		if saturates && (s > 1 || s < -1) {
+			report.Clipped++
+		}
+	}
+	if len(samples) > 0 {
+		report.MeanError = sum / float64(len(samples))
+	}
+	return report, nil
+}
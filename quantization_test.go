@@ -0,0 +1,51 @@
+package waveparser
+
+import "testing"
+
+func TestSetSamplesReport(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 8)
+
+	report, err := wav.SetSamplesReport([]float64{0.5, 0.3, -0.3, 1.5, -2})
+	assertNoError(t, err)
+
+	if report.Samples != 5 || report.Clipped != 2 {
+		t.Fatalf("unexpected report %s", report)
+	}
+	if report.MaxError < 0.9 {
+		t.Fatalf("expected the clipped -2 to give the max error, got %s", report)
+	}
+	if !report.Exceeds(0.01) {
+		t.Fatalf("report %s must exceed the threshold", report)
+	}
+
+	report, err = wav.SetSamplesReport([]float64{0.5, 0.3, -0.3})
+	assertNoError(t, err)
+	if report.Clipped != 0 || report.MaxError > 1.0/256 || report.MeanError > report.MaxError {
+		t.Fatalf("unexpected report %s", report)
+	}
+	if report.Exceeds(1.0 / 128) {
+		t.Fatalf("report %s must be within the threshold", report)
+	}
+}
+
+func TestConvertReport(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 1, 8000, 32)
+	assertNoError(t, wav.SetSamples([]float64{0.5, 0.25, -0.125, 1.25}))
+
+	to := New(WaveFormatPCM, 1, 8000, 16).Header.RIFFChunkFmt
+	converted, report, err := ConvertReport(wav, to)
+	assertNoError(t, err)
+
+	if converted.Header.RIFFChunkFmt != to {
+		t.Fatalf("expected fmt %+v, got %+v", to, converted.Header.RIFFChunkFmt)
+	}
+	if report.Samples != 4 || report.Clipped != 1 {
+		t.Fatalf("unexpected report %s", report)
+	}
+
+	_, report, err = ConvertReport(wav, wav.Header.RIFFChunkFmt)
+	assertNoError(t, err)
+	if report.Clipped != 0 || report.MaxError != 0 {
+		t.Fatalf("float to float must be lossless, got %s", report)
+	}
+}
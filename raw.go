@@ -0,0 +1,36 @@
+package waveparser
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// LoadRaw wraps headerless audio (eg: Asterisk .sln or .ulaw files)
+// with a synthetic header built from the given format. BytesPerSec and
+// BytesPerBloc are derived from the other fields, and a trailing
+// incomplete frame is dropped.
+func LoadRaw(r io.Reader, format RiffChunkFmt) (*Wav, error) {
+	if format.NumChannels == 0 || format.SampleRate == 0 {
+		return nil, fmt.Errorf(
+			"invalid raw format: channels[%d] samplerate[%d]",
+			format.NumChannels,
+			format.SampleRate,
+		)
+	}
+	if _, err := sampleDecoder(format); err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	wav := New(format.AudioFormat, format.NumChannels, format.SampleRate, format.BitsPerSample)
+	block := int(wav.Header.RIFFChunkFmt.BytesPerBloc)
+	wav.Data = data[:len(data)-len(data)%block]
+	wav.Header.DataBlockSize = uint32(len(wav.Data))
+	wav.Header.RIFFHdr.ChunkSize = riffChunkSize(nil, wav.Header.DataBlockSize)
+	return wav, nil
+}
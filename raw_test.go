@@ -0,0 +1,55 @@
+package waveparser
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestLoadRaw(t *testing.T) {
+	raw, err := ioutil.ReadFile("testdata/audios/sint16le.raw")
+	assertNoError(t, err)
+
+	expected, err := Load("testdata/audios/sint16le.wav")
+	assertNoError(t, err)
+
+	wav, err := LoadRaw(bytes.NewReader(raw), RiffChunkFmt{
+		AudioFormat:   WaveFormatPCM,
+		NumChannels:   1,
+		SampleRate:    8000,
+		BitsPerSample: 16,
+	})
+	assertNoError(t, err)
+
+	if wav.Header.RIFFChunkFmt != expected.Header.RIFFChunkFmt {
+		t.Fatalf("format differs:\n%#v\n!=\n%#v", wav.Header.RIFFChunkFmt, expected.Header.RIFFChunkFmt)
+	}
+	assertBytesEqual(t, expected.Data, wav.Data)
+}
+
+func TestLoadRawDropsIncompleteFrame(t *testing.T) {
+	wav, err := LoadRaw(bytes.NewReader([]byte{1, 2, 3, 4, 5}), RiffChunkFmt{
+		AudioFormat:   WaveFormatMULAW,
+		NumChannels:   2,
+		SampleRate:    8000,
+		BitsPerSample: 8,
+	})
+	assertNoError(t, err)
+
+	if wav.Header.DataBlockSize != 4 {
+		t.Fatalf("expected 4 bytes of data, got %d", wav.Header.DataBlockSize)
+	}
+}
+
+func TestLoadRawInvalidFormat(t *testing.T) {
+	_, err := LoadRaw(bytes.NewReader(nil), RiffChunkFmt{AudioFormat: WaveFormatPCM, BitsPerSample: 16})
+	assertError(t, err)
+
+	_, err = LoadRaw(bytes.NewReader(nil), RiffChunkFmt{
+		AudioFormat:   WaveFormatPCM,
+		NumChannels:   1,
+		SampleRate:    8000,
+		BitsPerSample: 12,
+	})
+	assertError(t, err)
+}
@@ -0,0 +1,102 @@
+package waveparser
+
+import (
+	"fmt"
+	"io"
+)
+
+type (
+	// RangeDecoder decodes arbitrary sample ranges of a WAV stored
+	// behind an io.ReaderAt, like objects on S3 or GCS accessed
+	// through range requests. Only the header and the requested
+	// ranges are read. It is safe for concurrent use.
+	RangeDecoder struct {
+		Header WavHeader
+
+		r    io.ReaderAt
+		data *io.SectionReader
+	}
+)
+
+// NewRangeDecoder parses the header of the WAV of the given size
+// held by r.
+func NewRangeDecoder(r io.ReaderAt, size int64) (*RangeDecoder, error) {
+	hdr, err := parseHeader(io.NewSectionReader(r, 0, size))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sampleSize(hdr.RIFFChunkFmt); err != nil {
+		return nil, err
+	}
+	if hdr.RIFFChunkFmt.NumChannels == 0 {
+		return nil, fmt.Errorf("invalid number of channels[%d]", hdr.RIFFChunkFmt.NumChannels)
+	}
+
+	// truncated files just get the samples available
+	datasize := int64(hdr.DataBlockSize)
+	if avail := size - int64(hdr.FirstSamplePos); avail < datasize {
+		datasize = avail
+	}
+	return &RangeDecoder{
+		Header: hdr,
+		r:      r,
+		data:   io.NewSectionReader(r, int64(hdr.FirstSamplePos), datasize),
+	}, nil
+}
+
+// Frames returns how many whole frames the audio data has.
+func (d *RangeDecoder) Frames() int64 {
+	return d.data.Size() / d.frameSize()
+}
+
+// ReadDataAt reads raw audio data starting at off, relative to the
+// start of the data chunk, with the semantics of io.ReaderAt.
+func (d *RangeDecoder) ReadDataAt(p []byte, off int64) (int, error) {
+	return d.data.ReadAt(p, off)
+}
+
+// ReadFramesAt decodes the frames starting at frame into samples,
+// interleaved and normalized as in Wav.Samples. Only whole frames are
+// read, it returns how many samples were decoded and, like
+// io.ReaderAt, io.EOF when samples couldn't be filled because the
+// data ended.
+func (d *RangeDecoder) ReadFramesAt(samples []float64, frame int64) (int, error) {
+	format := d.Header.RIFFChunkFmt
+	channels := int(format.NumChannels)
+	if frame < 0 {
+		return 0, fmt.Errorf("invalid frame[%d]", frame)
+	}
+	if len(samples)%channels != 0 {
+		return 0, fmt.Errorf(
+			"samples len[%d] isn't a multiple of channels[%d]",
+			len(samples),
+			channels,
+		)
+	}
+
+	size, _ := sampleSize(format)
+	decode, err := sampleDecoder(format)
+	if err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, len(samples)*size)
+	n, err := d.data.ReadAt(buf, frame*d.frameSize())
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+
+	count := n / int(d.frameSize()) * channels
+	for i := 0; i < count; i++ {
+		samples[i] = decode(buf[i*size:])
+	}
+	if count < len(samples) {
+		return count, io.EOF
+	}
+	return count, nil
+}
+
+func (d *RangeDecoder) frameSize() int64 {
+	size, _ := sampleSize(d.Header.RIFFChunkFmt)
+	return int64(size) * int64(d.Header.RIFFChunkFmt.NumChannels)
+}
@@ -0,0 +1,87 @@
+package waveparser
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestRangeDecoder(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 8000, 16)
+	samples := make([]float64, 2*1000)
+	for i := range samples {
+		samples[i] = math.Sin(float64(i) / 7)
+	}
+	assertNoError(t, wav.SetSamples(samples))
+	expected, err := wav.Samples()
+	assertNoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = wav.WriteTo(&buf)
+	assertNoError(t, err)
+	raw := buf.Bytes()
+
+	dec, err := NewRangeDecoder(bytes.NewReader(raw), int64(len(raw)))
+	assertNoError(t, err)
+	if dec.Header != wav.Header {
+		t.Fatalf("expected header %+v, got %+v", wav.Header, dec.Header)
+	}
+	if dec.Frames() != 1000 {
+		t.Fatalf("expected 1000 frames, got %d", dec.Frames())
+	}
+
+	var wg sync.WaitGroup
+	for _, frame := range []int64{0, 100, 500, 990} {
+		wg.Add(1)
+		go func(frame int64) {
+			defer wg.Done()
+			got := make([]float64, 2*10)
+			n, err := dec.ReadFramesAt(got, frame)
+			if err != nil || n != len(got) {
+				t.Errorf("frame[%d]: unexpected n[%d] err[%v]", frame, n, err)
+				return
+			}
+			want := expected[frame*2 : frame*2+20]
+			for i := range want {
+				if want[i] != got[i] {
+					t.Errorf("frame[%d]: sample[%d] expected[%f] got[%f]", frame, i, want[i], got[i])
+					return
+				}
+			}
+		}(frame)
+	}
+	wg.Wait()
+
+	got := make([]float64, 2*10)
+	n, err := dec.ReadFramesAt(got, 995)
+	if err != io.EOF || n != 10 {
+		t.Fatalf("expected 10 samples and EOF, got n[%d] err[%v]", n, err)
+	}
+	assertSamplesClose(t, expected[1990:], got[:n], 0)
+
+	_, err = dec.ReadFramesAt(make([]float64, 3), 0)
+	assertError(t, err)
+	_, err = dec.ReadFramesAt(got, -1)
+	assertError(t, err)
+}
+
+func TestRangeDecoderTruncated(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 100)))
+
+	var buf bytes.Buffer
+	_, err := wav.WriteTo(&buf)
+	assertNoError(t, err)
+	raw := buf.Bytes()[:buf.Len()-21]
+
+	dec, err := NewRangeDecoder(bytes.NewReader(raw), int64(len(raw)))
+	assertNoError(t, err)
+	if dec.Frames() != 89 {
+		t.Fatalf("expected 89 frames, got %d", dec.Frames())
+	}
+
+	_, err = NewRangeDecoder(bytes.NewReader(raw[:10]), 10)
+	assertError(t, err)
+}
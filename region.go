@@ -0,0 +1,145 @@
+package waveparser
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type (
+	// Region is a cue point with its adtl label. Regions have the
+	// length given by a ltxt entry, plain markers have End == Start.
+	Region struct {
+		ID    uint32 // cue point id
+		Label string
+		Start time.Duration
+		End   time.Duration
+	}
+)
+
+// Regions returns the cue points of the file as regions, sorted by
+// their start.
+func (w *Wav) Regions() ([]Region, error) {
+	points, err := w.CuePoints()
+	if err != nil {
+		return nil, err
+	}
+	list, err := w.adtl()
+	if err != nil {
+		return nil, err
+	}
+
+	rate := w.Header.RIFFChunkFmt.SampleRate
+	if rate == 0 && len(points) > 0 {
+		return nil, fmt.Errorf("can't compute regions: samplerate[%d]", rate)
+	}
+
+	regions := make([]Region, 0, len(points))
+	for _, point := range points {
+		start := int(point.SampleOffset)
+		end := start + int(list.texts[point.ID].SampleLength)
+		regions = append(regions, Region{
+			ID:    point.ID,
+			Label: list.labels[point.ID],
+			Start: framesDuration(start, rate),
+			End:   framesDuration(end, rate),
+		})
+	}
+	sort.SliceStable(regions, func(i, j int) bool {
+		return regions[i].Start < regions[j].Start
+	})
+	return regions, nil
+}
+
+// SliceRegion returns the audio of the first region with the given
+// label, like Slice does.
+func (w *Wav) SliceRegion(label string, opts ...TransformOption) (*Wav, error) {
+	regions, err := w.Regions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, region := range regions {
+		if region.Label != label {
+			continue
+		}
+		if region.End == region.Start {
+			return nil, fmt.Errorf("region[%s] is a marker, it has no length", label)
+		}
+		return w.Slice(region.Start, region.End, opts...)
+	}
+	return nil, fmt.Errorf("region[%s] not found", label)
+}
+
+// SetRegion moves the region with the given label to start and end,
+// creating it when there is none, returning its cue id. The smpl loops
+// attached to the region's cue are moved along.
+func (w *Wav) SetRegion(label string, start, end time.Duration) (uint32, error) {
+	if label == "" || end <= start {
+		return 0, fmt.Errorf("invalid region[%s]: start[%s] end[%s]", label, start, end)
+	}
+	first, err := w.cueFrame(start)
+	if err != nil {
+		return 0, err
+	}
+	last, err := w.cueFrame(end)
+	if err != nil {
+		return 0, err
+	}
+
+	list, err := w.adtl()
+	if err != nil {
+		return 0, err
+	}
+	points, err := w.CuePoints()
+	if err != nil {
+		return 0, err
+	}
+
+	index := -1
+	for i, point := range points {
+		if list.labels[point.ID] == label {
+			index = i
+			break
+		}
+	}
+
+	var id uint32
+	if index < 0 {
+		id, err = w.AddCue(label, start)
+		if err != nil {
+			return 0, err
+		}
+		list.labels[id] = label
+	} else {
+		id = points[index].ID
+		points[index].Position = first
+		points[index].SampleOffset = first
+		w.SetCuePoints(points)
+	}
+
+	text, ok := list.texts[id]
+	if !ok {
+		text.Purpose = chunkID("rgn")
+	}
+	text.SampleLength = last - first
+	list.texts[id] = text
+	w.setAdtl(list)
+
+	sampler, err := w.Sampler()
+	if err != nil {
+		return 0, err
+	}
+	if sampler != nil {
+		for i, loop := range sampler.Loops {
+			if loop.CuePointID == id {
+				sampler.Loops[i].Start = first
+				sampler.Loops[i].End = last - 1
+			}
+		}
+		w.SetSampler(sampler)
+	}
+
+	w.Header.RIFFHdr.ChunkSize = riffChunkSize(w.Chunks, w.Header.DataBlockSize) + headerExtraSize(&w.Header)
+	return id, nil
+}
@@ -0,0 +1,65 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestRegions(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 48000, 16)
+	samples := make([]float64, 48000)
+	for i := range samples {
+		samples[i] = float64(i%100) / 100
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	wav.SetCuePoints([]CuePoint{
+		{ID: 1, SampleOffset: 24000},
+		{ID: 2, SampleOffset: 1},
+		{ID: 3, SampleOffset: 100},
+	})
+
+	// as Audacity writes labels: labl for every cue, ltxt for regions
+	adtl := &bytes.Buffer{}
+	adtl.WriteString("adtl")
+	writeSubchunk(adtl, chunkID("labl"), append(le32(1), "marker\x00"...))
+	writeSubchunk(adtl, chunkID("labl"), append(le32(2), "take 1\x00"...))
+	writeSubchunk(adtl, chunkID("ltxt"), append(le32(2), append(le32(99), "rgn \x00\x00\x00\x00\x00\x00\x00\x00"...)...))
+	wav.SetChunk("LIST", adtl.Bytes())
+
+	regions, err := wav.Regions()
+	assertNoError(t, err)
+
+	expected := []Region{
+		{ID: 2, Label: "take 1", Start: framesDuration(1, 48000), End: framesDuration(100, 48000)},
+		{ID: 3, Start: framesDuration(100, 48000), End: framesDuration(100, 48000)},
+		{ID: 1, Label: "marker", Start: 500 * time.Millisecond, End: 500 * time.Millisecond},
+	}
+	if len(regions) != len(expected) {
+		t.Fatalf("expected %+v, got %+v", expected, regions)
+	}
+	for i := range expected {
+		if regions[i] != expected[i] {
+			t.Fatalf("region[%d]: expected %+v, got %+v", i, expected[i], regions[i])
+		}
+	}
+
+	take, err := wav.SliceRegion("take 1")
+	assertNoError(t, err)
+	got, err := take.Samples()
+	assertNoError(t, err)
+	assertSamplesClose(t, samples[1:100], got, 1.0/(1<<15))
+
+	_, err = wav.SliceRegion("marker")
+	assertError(t, err)
+	_, err = wav.SliceRegion("missing")
+	assertError(t, err)
+}
+
+func le32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	return b
+}
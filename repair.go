@@ -0,0 +1,85 @@
+package waveparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// RepairReport lists the header fields changed by Repair.
+	RepairReport struct {
+		Changes []RepairChange
+	}
+
+	// RepairChange is a header field fixed by Repair.
+	RepairChange struct {
+		Field    string
+		Old, New uint32
+	}
+)
+
+// Repair makes the header of w consistent with its encoding and audio
+// data: bytes/block and bytes/second are recomputed from the channels,
+// sample rate and bits per sample, an incomplete trailing frame is
+// dropped from Data and the data and RIFF chunk sizes are recomputed.
+func Repair(w *Wav) (RepairReport, error) {
+	var report RepairReport
+	format := &w.Header.RIFFChunkFmt
+	if format.NumChannels == 0 || format.BitsPerSample == 0 {
+		return report, fmt.Errorf(
+			"can't repair channels[%d] bits per sample[%d]",
+			format.NumChannels,
+			format.BitsPerSample,
+		)
+	}
+
+	change := func(field string, old, fixed uint32) {
+		if old != fixed {
+			report.Changes = append(report.Changes, RepairChange{Field: field, Old: old, New: fixed})
+		}
+	}
+
+	bytesPerBloc, bytesPerSec := blockAlign(*format)
+	frame := int(bytesPerBloc)
+	if !isIMA(format.AudioFormat) && format.BitsPerSample%8 == 0 {
+		// blockAlign wraps frames that don't fit the 16 bits field
+		frame = int(format.NumChannels) * int(format.BitsPerSample/8)
+	}
+	if frame == 0 || frame > 0xffff {
+		return report, fmt.Errorf("can't repair frame size[%d]", frame)
+	}
+
+	change("BytesPerBloc", uint32(format.BytesPerBloc), uint32(bytesPerBloc))
+	change("BytesPerSec", format.BytesPerSec, bytesPerSec)
+	format.BytesPerBloc = bytesPerBloc
+	format.BytesPerSec = bytesPerSec
+
+	dataSize := len(w.Data) - len(w.Data)%int(bytesPerBloc)
+	change("Data", uint32(len(w.Data)), uint32(dataSize))
+	w.Data = w.Data[:dataSize]
+
+	change("DataBlockSize", w.Header.DataBlockSize, uint32(dataSize))
+	w.Header.DataBlockSize = uint32(dataSize)
+
+	chunkSize := riffChunkSize(w.Chunks, w.Header.DataBlockSize) + headerExtraSize(&w.Header)
+	change("ChunkSize", w.Header.RIFFHdr.ChunkSize, chunkSize)
+	w.Header.RIFFHdr.ChunkSize = chunkSize
+
+	return report, nil
+}
+
+// Repaired tells if Repair changed anything.
+func (r RepairReport) Repaired() bool {
+	return len(r.Changes) > 0
+}
+
+func (r RepairReport) String() string {
+	if !r.Repaired() {
+		return "Nothing to repair"
+	}
+	strs := make([]string, len(r.Changes))
+	for i, c := range r.Changes {
+		strs[i] = fmt.Sprintf("%s: %d -> %d", c.Field, c.Old, c.New)
+	}
+	return strings.Join(strs, "\n")
+}
@@ -0,0 +1,57 @@
+package waveparser
+
+import "testing"
+
+func TestRepair(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 8000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 100)))
+	expected := wav.Header
+
+	wav.Data = append(wav.Data, 0, 0) // incomplete frame
+	wav.Header.RIFFChunkFmt.BytesPerSec = 16000
+	wav.Header.RIFFChunkFmt.BytesPerBloc = 2
+	wav.Header.DataBlockSize = 0xffffffff
+	wav.Header.RIFFHdr.ChunkSize = 0xffffffff
+
+	report, err := Repair(wav)
+	assertNoError(t, err)
+
+	if wav.Header != expected {
+		t.Fatalf("expected header %+v, got %+v", expected, wav.Header)
+	}
+	if len(wav.Data) != 200 {
+		t.Fatalf("expected 200 bytes of data, got %d", len(wav.Data))
+	}
+
+	fields := []string{"BytesPerBloc", "BytesPerSec", "Data", "DataBlockSize", "ChunkSize"}
+	if len(report.Changes) != len(fields) {
+		t.Fatalf("expected changes to %v, got %+v", fields, report.Changes)
+	}
+	for i, field := range fields {
+		if report.Changes[i].Field != field {
+			t.Fatalf("change[%d]: expected field %s, got %+v", i, field, report.Changes[i])
+		}
+	}
+	if report.Changes[1].Old != 16000 || report.Changes[1].New != 32000 {
+		t.Fatalf("unexpected bytes/sec change %+v", report.Changes[1])
+	}
+
+	report, err = Repair(wav)
+	assertNoError(t, err)
+	if report.Repaired() {
+		t.Fatalf("expected nothing to repair, got %s", report)
+	}
+}
+
+func TestRepairInvalidFormat(t *testing.T) {
+	_, err := Repair(New(WaveFormatPCM, 0, 8000, 16))
+	assertError(t, err)
+
+	// frames that don't fit the 16 bits bytes/block field
+	for _, format := range []AudioFormat{WaveFormatPCM, WaveFormatIMAADPCM} {
+		wav := New(format, 0x8000, 8000, 16)
+		wav.Data = make([]byte, 10)
+		_, err = Repair(wav)
+		assertError(t, err)
+	}
+}
@@ -0,0 +1,153 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+)
+
+type (
+	// ResampleQuality selects the interpolation used by Resample,
+	// trading speed for fidelity.
+	ResampleQuality int
+)
+
+const (
+	ResampleLinear ResampleQuality = iota
+	ResampleCubic
+	ResampleSinc
+)
+
+// sincZeroCrossings is the half-width, in zero crossings, of the
+// windowed sinc kernel.
+const sincZeroCrossings = 16
+
+// Resample returns a copy of w converted to the given sample rate,
+// keeping the sample encoding, channel count and chunks. Cue points
+// and the bext time reference are scaled to the new rate.
+func Resample(w *Wav, rate uint32, quality ResampleQuality, opts ...TransformOption) (*Wav, error) {
+	format := w.Header.RIFFChunkFmt
+	if rate == 0 || format.SampleRate == 0 || format.NumChannels == 0 {
+		return nil, fmt.Errorf(
+			"invalid resampling: from[%d] to[%d] channels[%d]",
+			format.SampleRate,
+			rate,
+			format.NumChannels,
+		)
+	}
+
+	samples, err := w.Samples()
+	if err != nil {
+		return nil, err
+	}
+
+	resampled, err := resample(samples, int(format.NumChannels), format.SampleRate, rate, quality)
+	if err != nil {
+		return nil, err
+	}
+
+	out := New(format.AudioFormat, format.NumChannels, rate, format.BitsPerSample)
+	if err := out.SetSamples(resampled); err != nil {
+		return nil, err
+	}
+
+	if newTransformOptions(opts).dropMetadata {
+		return out, nil
+	}
+	ratio := float64(rate) / float64(format.SampleRate)
+	if err := carryMetadata(out, w, 0, ratio); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// resample converts interleaved samples between sample rates.
+func resample(samples []float64, channels int, from, to uint32, quality ResampleQuality) ([]float64, error) {
+	interpolate, err := interpolator(quality, float64(to)/float64(from))
+	if err != nil {
+		return nil, err
+	}
+
+	frames := len(samples) / channels
+	outFrames := int(int64(frames) * int64(to) / int64(from))
+	step := float64(from) / float64(to)
+
+	resampled := make([]float64, outFrames*channels)
+	channel := make([]float64, frames)
+	for c := 0; c < channels; c++ {
+		for i := range channel {
+			channel[i] = samples[i*channels+c]
+		}
+		for i := 0; i < outFrames; i++ {
+			resampled[i*channels+c] = interpolate(channel, float64(i)*step)
+		}
+	}
+	return resampled, nil
+}
+
+// interpolator returns a function computing the value of x at the
+// fractional position t.
+func interpolator(quality ResampleQuality, ratio float64) (func(x []float64, t float64) float64, error) {
+	switch quality {
+	case ResampleLinear:
+		return linearInterpolation, nil
+	case ResampleCubic:
+		return cubicInterpolation, nil
+	case ResampleSinc:
+		// when downsampling the kernel doubles as an anti aliasing filter
+		return func(x []float64, t float64) float64 {
+			return sincInterpolation(x, t, math.Min(1, ratio))
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown resample quality[%d]", quality)
+}
+
+func at(x []float64, i int) float64 {
+	if i < 0 || i >= len(x) {
+		return 0
+	}
+	return x[i]
+}
+
+func linearInterpolation(x []float64, t float64) float64 {
+	i := int(math.Floor(t))
+	frac := t - float64(i)
+	return at(x, i)*(1-frac) + at(x, i+1)*frac
+}
+
+// cubicInterpolation uses a Catmull-Rom spline over four neighbours.
+func cubicInterpolation(x []float64, t float64) float64 {
+	i := int(math.Floor(t))
+	f := t - float64(i)
+	p0, p1, p2, p3 := at(x, i-1), at(x, i), at(x, i+1), at(x, i+2)
+	return p1 + 0.5*f*(p2-p0+f*(2*p0-5*p1+4*p2-p3+f*(3*(p1-p2)+p3-p0)))
+}
+
+// sincInterpolation applies a Blackman windowed sinc low-pass filter
+// with the given cutoff (relative to the input Nyquist frequency).
+func sincInterpolation(x []float64, t float64, cutoff float64) float64 {
+	halfWidth := sincZeroCrossings / cutoff
+	first := int(math.Ceil(t - halfWidth))
+	last := int(math.Floor(t + halfWidth))
+
+	var sum float64
+	for n := first; n <= last; n++ {
+		d := t - float64(n)
+		sum += at(x, n) * cutoff * sinc(cutoff*d) * blackman(d/halfWidth)
+	}
+	return sum
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// blackman evaluates a Blackman window spanning [-1, 1].
+func blackman(x float64) float64 {
+	if x < -1 || x > 1 {
+		return 0
+	}
+	return 0.42 + 0.5*math.Cos(math.Pi*x) + 0.08*math.Cos(2*math.Pi*x)
+}
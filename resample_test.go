@@ -0,0 +1,74 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+)
+
+func sineWav(t *testing.T, freq float64, rate uint32, frames int) *Wav {
+	t.Helper()
+	wav := New(WaveFormatIEEEFloat, 1, rate, 64)
+	samples := make([]float64, frames)
+	for i := range samples {
+		samples[i] = 0.5 * math.Sin(2*math.Pi*freq*float64(i)/float64(rate))
+	}
+	assertNoError(t, wav.SetSamples(samples))
+	return wav
+}
+
+func TestResample(t *testing.T) {
+
+	type tcase struct {
+		name      string
+		quality   ResampleQuality
+		tolerance float64
+	}
+
+	tcases := []tcase{
+		{name: "linear", quality: ResampleLinear, tolerance: 0.02},
+		{name: "cubic", quality: ResampleCubic, tolerance: 0.005},
+		{name: "sinc", quality: ResampleSinc, tolerance: 0.005},
+	}
+
+	for _, tcase := range tcases {
+		for _, rates := range [][2]uint32{{8000, 16000}, {16000, 8000}, {44100, 48000}} {
+			from, to := rates[0], rates[1]
+			t.Run(tcase.name, func(t *testing.T) {
+				wav := sineWav(t, 440, from, int(from))
+
+				resampled, err := Resample(wav, to, tcase.quality)
+				assertNoError(t, err)
+
+				if resampled.Header.RIFFChunkFmt.SampleRate != to {
+					t.Fatalf("expected rate[%d], got[%d]", to, resampled.Header.RIFFChunkFmt.SampleRate)
+				}
+
+				got, err := resampled.Samples()
+				assertNoError(t, err)
+				if len(got) != int(to) {
+					t.Fatalf("expected %d samples, got %d", to, len(got))
+				}
+
+				expected := sineWav(t, 440, to, int(to))
+				want, err := expected.Samples()
+				assertNoError(t, err)
+
+				// edges are affected by the zero padding of the kernels
+				margin := int(to) / 50
+				assertSamplesClose(t, want[margin:len(want)-margin], got[margin:len(got)-margin], tcase.tolerance)
+			})
+		}
+	}
+}
+
+func TestConvertResampled(t *testing.T) {
+	wav := sineWav(t, 100, 8000, 800)
+
+	converted, err := convertResampled(wav, New(WaveFormatPCM, 2, 16000, 16).Header.RIFFChunkFmt)
+	assertNoError(t, err)
+
+	format := converted.Header.RIFFChunkFmt
+	if format.SampleRate != 16000 || format.NumChannels != 2 || len(converted.Data) != 1600*4 {
+		t.Fatalf("unexpected conversion result: %#v with %d bytes", format, len(converted.Data))
+	}
+}
@@ -0,0 +1,110 @@
+package waveparser
+
+import "math"
+
+// carryMetadata copies the chunks of src to dst, a transformed copy of
+// it, moving time referenced fields to the new timeline: a frame f of
+// src becomes (f - first) * ratio on dst. Cue points and loops falling
+// outside dst are dropped, with their labels.
+func carryMetadata(dst, src *Wav, first uint32, ratio float64) error {
+	// the fact chunk describes the audio of dst, not of src
+	fact := dst.Chunk("fact")
+	dst.Chunks = nil
+	if fact != nil {
+		dst.Chunks = append(dst.Chunks, *fact)
+	}
+	for _, chunk := range src.Chunks {
+		if string(chunk.ID[:]) == "fact" {
+			continue
+		}
+		dst.Chunks = append(dst.Chunks, Chunk{ID: chunk.ID, Data: cloneBytes(chunk.Data)})
+	}
+
+	frames := float64(dst.Header.frames())
+	retime := func(frame uint32) (uint32, bool) {
+		f := math.Round((float64(frame) - float64(first)) * ratio)
+		if f < 0 || f > frames {
+			return 0, false
+		}
+		return uint32(f), true
+	}
+
+	if err := retimeCues(dst, src, retime, ratio); err != nil {
+		return err
+	}
+
+	sampler, err := src.Sampler()
+	if err != nil {
+		return err
+	}
+	if sampler != nil {
+		loops := sampler.Loops[:0]
+		for _, loop := range sampler.Loops {
+			start, ok := retime(loop.Start)
+			end, inside := retime(loop.End)
+			if ok && inside {
+				loop.Start, loop.End = start, end
+				loops = append(loops, loop)
+			}
+		}
+		sampler.Loops = loops
+		if rate := dst.Header.RIFFChunkFmt.SampleRate; rate > 0 {
+			sampler.SamplePeriod = uint32(math.Round(1e9 / float64(rate)))
+		}
+		dst.SetSampler(sampler)
+	}
+
+	bext, err := src.Bext()
+	if err != nil {
+		return err
+	}
+	if bext != nil {
+		// the reference is of the first sample, which moves forward
+		bext.TimeReference = uint64(math.Round(float64(bext.TimeReference+uint64(first)) * ratio))
+		dst.SetBext(bext)
+	}
+
+	dst.Header.RIFFHdr.ChunkSize = riffChunkSize(dst.Chunks, uint32(len(dst.Data))) +
+		headerExtraSize(&dst.Header)
+	return nil
+}
+
+// retimeCues moves the cue points of src, and the lengths of their
+// regions, to the timeline of dst.
+func retimeCues(dst, src *Wav, retime func(uint32) (uint32, bool), ratio float64) error {
+	points, err := src.CuePoints()
+	if err != nil {
+		return err
+	}
+	list, err := src.adtl()
+	if err != nil {
+		return err
+	}
+	if points == nil {
+		return nil
+	}
+
+	kept := points[:0]
+	for _, point := range points {
+		offset, ok := retime(point.SampleOffset)
+		if !ok {
+			list.remove(point.ID)
+			continue
+		}
+		if point.Position == point.SampleOffset {
+			point.Position = offset
+		}
+		point.SampleOffset = offset
+		kept = append(kept, point)
+	}
+	dst.SetCuePoints(kept)
+
+	for id, text := range list.texts {
+		text.SampleLength = uint32(math.Round(float64(text.SampleLength) * ratio))
+		list.texts[id] = text
+	}
+	if dst.listChunk("adtl") != nil {
+		dst.setAdtl(list)
+	}
+	return nil
+}
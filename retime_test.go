@@ -0,0 +1,101 @@
+package waveparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSliceRetimesMetadata(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 10, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 20)))
+	wav.SetCuePoints([]CuePoint{
+		{ID: 1, Position: 2, SampleOffset: 2},
+		{ID: 2, Position: 8, SampleOffset: 8},
+		{ID: 3, Position: 18, SampleOffset: 18},
+	})
+	wav.SetBext(&Bext{TimeReference: 1000})
+
+	sliced, err := wav.Slice(500*time.Millisecond, 1500*time.Millisecond)
+	assertNoError(t, err)
+
+	points, err := sliced.CuePoints()
+	assertNoError(t, err)
+	if len(points) != 1 || points[0].ID != 2 || points[0].SampleOffset != 3 || points[0].Position != 3 {
+		t.Fatalf("unexpected cue points: %+v", points)
+	}
+
+	bext, err := sliced.Bext()
+	assertNoError(t, err)
+	if bext.TimeReference != 1005 {
+		t.Fatalf("expected time reference[1005], got[%d]", bext.TimeReference)
+	}
+
+	// the source keeps its metadata
+	points, err = wav.CuePoints()
+	assertNoError(t, err)
+	if len(points) != 3 {
+		t.Fatalf("source cue points changed: %+v", points)
+	}
+
+	bare, err := wav.Slice(500*time.Millisecond, 1500*time.Millisecond, DropMetadata())
+	assertNoError(t, err)
+	if len(bare.Chunks) != 0 {
+		t.Fatalf("expected no chunks, got %d", len(bare.Chunks))
+	}
+}
+
+func TestResampleRetimesMetadata(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 800)))
+	wav.SetCuePoints([]CuePoint{{ID: 1, Position: 400, SampleOffset: 400}})
+	wav.SetBext(&Bext{TimeReference: 8000})
+	wav.SetInfo(Info{InfoTitle: "resampled"})
+
+	resampled, err := Resample(wav, 16000, ResampleLinear)
+	assertNoError(t, err)
+
+	points, err := resampled.CuePoints()
+	assertNoError(t, err)
+	if len(points) != 1 || points[0].SampleOffset != 800 {
+		t.Fatalf("unexpected cue points: %+v", points)
+	}
+
+	bext, err := resampled.Bext()
+	assertNoError(t, err)
+	if bext.TimeReference != 16000 {
+		t.Fatalf("expected time reference[16000], got[%d]", bext.TimeReference)
+	}
+
+	info, err := resampled.Info()
+	assertNoError(t, err)
+	if info[InfoTitle] != "resampled" {
+		t.Fatalf("info not preserved: %v", info)
+	}
+	if resampled.Header.RIFFHdr.ChunkSize != riffChunkSize(resampled.Chunks, resampled.Header.DataBlockSize) {
+		t.Fatalf("riff chunk size not updated[%d]", resampled.Header.RIFFHdr.ChunkSize)
+	}
+}
+
+func TestCuePointsRoundTrip(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	points := []CuePoint{
+		{ID: 1, Position: 10, DataChunkID: chunkID("data"), SampleOffset: 10},
+		{ID: 2, Position: 20, DataChunkID: chunkID("data"), SampleOffset: 20},
+	}
+	wav.SetCuePoints(points)
+
+	got, err := wav.CuePoints()
+	assertNoError(t, err)
+	if len(got) != 2 || got[0] != points[0] || got[1] != points[1] {
+		t.Fatalf("expected %+v, got %+v", points, got)
+	}
+
+	wav.SetCuePoints(nil)
+	if wav.Chunk("cue ") != nil {
+		t.Fatal("expected cue chunk removed")
+	}
+
+	wav.SetChunk("cue ", []byte{5, 0, 0, 0})
+	_, err = wav.CuePoints()
+	assertError(t, err)
+}
@@ -0,0 +1,44 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+)
+
+// LoadSalvage loads the WAV file at the given path recovering as much
+// audio as possible from files cut off in the middle of the data
+// chunk, like recordings interrupted by a power loss. The data chunk
+// is taken to run until the end of the file when its size is missing
+// (zero) or beyond the file, and the header is repaired (see Repair)
+// to be consistent with the audio recovered.
+func LoadSalvage(audiofile string) (*Wav, error) {
+	raw, err := ioutil.ReadFile(audiofile)
+	if err != nil {
+		return nil, err
+	}
+	return salvage(raw)
+}
+
+func salvage(raw []byte) (*Wav, error) {
+	hdr, err := parseHeader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	available := uint32(len(raw)) - hdr.FirstSamplePos
+	if hdr.DataBlockSize == 0 || hdr.DataBlockSize > available {
+		patched := append([]byte(nil), raw...)
+		binary.LittleEndian.PutUint32(patched[hdr.FirstSamplePos-4:], available)
+		raw = patched
+	}
+
+	w, err := ParseBytes(raw)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Repair(w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
@@ -0,0 +1,72 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSalvage(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 8000, 16)
+	samples := make([]float64, 200)
+	for i := range samples {
+		samples[i] = float64(i) / 200
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	var buf bytes.Buffer
+	_, err := wav.WriteTo(&buf)
+	assertNoError(t, err)
+	complete := buf.Bytes()
+
+	type tcase struct {
+		name     string
+		raw      []byte
+		expected int
+	}
+
+	// cut in the middle of a frame
+	truncated := complete[:len(complete)-101]
+
+	// streaming recorders may never get to write the sizes
+	unsized := append([]byte(nil), complete...)
+	binary.LittleEndian.PutUint32(unsized[wav.Header.FirstSamplePos-4:], 0)
+	binary.LittleEndian.PutUint32(unsized[4:], 0)
+
+	tcases := []tcase{
+		{name: "complete", raw: complete, expected: 400},
+		{name: "truncated", raw: truncated, expected: 296},
+		{name: "unsized", raw: unsized, expected: 400},
+	}
+
+	dir, err := ioutil.TempDir("", "waveparser-salvage")
+	assertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			path := filepath.Join(dir, tcase.name+".wav")
+			assertNoError(t, ioutil.WriteFile(path, tcase.raw, 0644))
+
+			got, err := LoadSalvage(path)
+			assertNoError(t, err)
+
+			if len(got.Data) != tcase.expected {
+				t.Fatalf("expected %d bytes of data, got %d", tcase.expected, len(got.Data))
+			}
+			assertBytesEqual(t, wav.Data[:tcase.expected], got.Data)
+
+			report, err := Repair(got)
+			assertNoError(t, err)
+			if report.Repaired() {
+				t.Fatalf("expected consistent header, got repairs:\n%s", report)
+			}
+		})
+	}
+
+	_, err = LoadSalvage(filepath.Join(dir, "nonexistent.wav"))
+	assertError(t, err)
+}
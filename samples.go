@@ -0,0 +1,254 @@
+package waveparser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+type (
+	sampleDecodeFunc func(b []byte) float64
+	sampleEncodeFunc func(b []byte, v float64)
+)
+
+// Samples decodes the audio data into interleaved samples normalized
+// to the [-1, 1] range, whatever the encoding described by the header.
+func (w *Wav) Samples(opts ...SampleOption) ([]float64, error) {
+	samples, err := decodeSamples(w.Header.RIFFChunkFmt, w.Data, newSampleOptions(opts).workers)
+	if err != nil {
+		return nil, err
+	}
+	if isIMA(w.Header.RIFFChunkFmt.AudioFormat) {
+		samples = w.trimIMA(samples)
+	}
+	return samples, nil
+}
+
+// SetSamples encodes the interleaved samples using the encoding
+// described by the header, replacing the audio data.
+func (w *Wav) SetSamples(samples []float64, opts ...SampleOption) error {
+	data, err := encodeSamples(w.Header.RIFFChunkFmt, samples, newSampleOptions(opts))
+	if err != nil {
+		return err
+	}
+	w.Data = data
+	if isIMA(w.Header.RIFFChunkFmt.AudioFormat) {
+		w.setFact(uint32(len(samples) / int(w.Header.RIFFChunkFmt.NumChannels)))
+	}
+	w.Header.DataBlockSize = uint32(len(data))
+	w.Header.RIFFHdr.ChunkSize = riffChunkSize(w.Chunks, w.Header.DataBlockSize) +
+		headerExtraSize(&w.Header)
+	return nil
+}
+
+// CompandedSamples returns the raw 8 bits G.711 codewords of A-law and
+// µ-law files, to relay them without decoding. The returned slice
+// shares memory with Data.
+func (w *Wav) CompandedSamples() ([]byte, error) {
+	f := w.Header.RIFFChunkFmt
+	if f.AudioFormat != WaveFormatALAW && f.AudioFormat != WaveFormatMULAW {
+		return nil, fmt.Errorf("format[%s] isn't companded", f.AudioFormat)
+	}
+	if f.BitsPerSample != 8 {
+		return nil, fmt.Errorf("companded samples must have 8 bits, got bits[%d]", f.BitsPerSample)
+	}
+	if f.NumChannels == 0 {
+		return nil, fmt.Errorf("invalid number of channels[%d]", f.NumChannels)
+	}
+
+	// only whole frames
+	frames := len(w.Data) / int(f.NumChannels)
+	return w.Data[:frames*int(f.NumChannels)], nil
+}
+
+func sampleSize(f RiffChunkFmt) (int, error) {
+	if f.BitsPerSample == 0 || f.BitsPerSample%8 != 0 {
+		return 0, fmt.Errorf("unsupported bits per sample[%d]", f.BitsPerSample)
+	}
+	return int(f.BitsPerSample / 8), nil
+}
+
+// frameSize is the size of a frame of byte aligned encodings. Audio is
+// always read in whole frames, so a trailing incomplete frame never
+// shifts the interleaving of the channels.
+func frameSize(f RiffChunkFmt) (int, error) {
+	size, err := sampleSize(f)
+	if err != nil {
+		return 0, err
+	}
+	if f.NumChannels == 0 {
+		return 0, fmt.Errorf("invalid number of channels[%d]", f.NumChannels)
+	}
+	return size * int(f.NumChannels), nil
+}
+
+func decodeSamples(f RiffChunkFmt, data []byte, workers int) ([]float64, error) {
+	return decodeSamplesInto(nil, f, data, workers)
+}
+
+// decodeSamplesInto decodes data reusing dst when it has enough capacity.
+func decodeSamplesInto(dst []float64, f RiffChunkFmt, data []byte, workers int) ([]float64, error) {
+	if isG726(f.AudioFormat) {
+		return decodeG726(f, data)
+	}
+	if isIMA(f.AudioFormat) {
+		return decodeIMA(f, data)
+	}
+
+	size, err := sampleSize(f)
+	if err != nil {
+		return nil, err
+	}
+	decode, err := sampleDecoder(f)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(data) / size
+	if f.NumChannels > 0 {
+		n -= n % int(f.NumChannels)
+	}
+	if cap(dst) < n {
+		dst = make([]float64, n)
+	}
+	samples := dst[:n]
+	inParallel(len(samples), workers, func(start, end int) {
+		for i := start; i < end; i++ {
+			samples[i] = decode(data[i*size:])
+		}
+	})
+	return samples, nil
+}
+
+func encodeSamples(f RiffChunkFmt, samples []float64, opts sampleOptions) ([]byte, error) {
+	if isG726(f.AudioFormat) {
+		return encodeG726(f, samples)
+	}
+	if isIMA(f.AudioFormat) {
+		return encodeIMA(f, samples)
+	}
+
+	size, err := sampleSize(f)
+	if err != nil {
+		return nil, err
+	}
+	encode, err := sampleEncoder(f)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.AudioFormat == WaveFormatPCM && opts.dither != NoDither {
+		samples = append([]float64(nil), samples...)
+		opts.dither.Dither(samples, int(f.NumChannels), uint(f.BitsPerSample))
+	}
+
+	data := make([]byte, len(samples)*size)
+	inParallel(len(samples), opts.workers, func(start, end int) {
+		for i := start; i < end; i++ {
+			encode(data[i*size:], samples[i])
+		}
+	})
+	return data, nil
+}
+
+func sampleDecoder(f RiffChunkFmt) (sampleDecodeFunc, error) {
+	switch {
+	case f.AudioFormat == WaveFormatPCM && f.BitsPerSample == 8:
+		return func(b []byte) float64 {
+			return float64(int(b[0])-128) / 128
+		}, nil
+	case f.AudioFormat == WaveFormatPCM && f.BitsPerSample == 16:
+		return func(b []byte) float64 {
+			return float64(int16(binary.LittleEndian.Uint16(b))) / (1 << 15)
+		}, nil
+	case f.AudioFormat == WaveFormatPCM && f.BitsPerSample == 24:
+		return func(b []byte) float64 {
+			v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+			return float64(v) / (1 << 23)
+		}, nil
+	case f.AudioFormat == WaveFormatPCM && f.BitsPerSample == 32:
+		return func(b []byte) float64 {
+			return float64(int32(binary.LittleEndian.Uint32(b))) / (1 << 31)
+		}, nil
+	case f.AudioFormat == WaveFormatIEEEFloat && f.BitsPerSample == 32:
+		return func(b []byte) float64 {
+			return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		}, nil
+	case f.AudioFormat == WaveFormatIEEEFloat && f.BitsPerSample == 64:
+		return func(b []byte) float64 {
+			return math.Float64frombits(binary.LittleEndian.Uint64(b))
+		}, nil
+	case f.AudioFormat == WaveFormatALAW && f.BitsPerSample == 8:
+		return func(b []byte) float64 {
+			return float64(alawToLinear(b[0])) / (1 << 15)
+		}, nil
+	case f.AudioFormat == WaveFormatMULAW && f.BitsPerSample == 8:
+		return func(b []byte) float64 {
+			return float64(ulawToLinear(b[0])) / (1 << 15)
+		}, nil
+	}
+	return nil, fmt.Errorf(
+		"unsupported sample encoding: format[%d] bits[%d]",
+		f.AudioFormat,
+		f.BitsPerSample,
+	)
+}
+
+func sampleEncoder(f RiffChunkFmt) (sampleEncodeFunc, error) {
+	switch {
+	case f.AudioFormat == WaveFormatPCM && f.BitsPerSample == 8:
+		return func(b []byte, v float64) {
+			b[0] = byte(quantize(v, 8) + 128)
+		}, nil
+	case f.AudioFormat == WaveFormatPCM && f.BitsPerSample == 16:
+		return func(b []byte, v float64) {
+			binary.LittleEndian.PutUint16(b, uint16(quantize(v, 16)))
+		}, nil
+	case f.AudioFormat == WaveFormatPCM && f.BitsPerSample == 24:
+		return func(b []byte, v float64) {
+			q := quantize(v, 24)
+			b[0] = byte(q)
+			b[1] = byte(q >> 8)
+			b[2] = byte(q >> 16)
+		}, nil
+	case f.AudioFormat == WaveFormatPCM && f.BitsPerSample == 32:
+		return func(b []byte, v float64) {
+			binary.LittleEndian.PutUint32(b, uint32(quantize(v, 32)))
+		}, nil
+	case f.AudioFormat == WaveFormatIEEEFloat && f.BitsPerSample == 32:
+		return func(b []byte, v float64) {
+			binary.LittleEndian.PutUint32(b, math.Float32bits(float32(v)))
+		}, nil
+	case f.AudioFormat == WaveFormatIEEEFloat && f.BitsPerSample == 64:
+		return func(b []byte, v float64) {
+			binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+		}, nil
+	case f.AudioFormat == WaveFormatALAW && f.BitsPerSample == 8:
+		return func(b []byte, v float64) {
+			b[0] = linearToAlaw(int16(quantize(v, 16)))
+		}, nil
+	case f.AudioFormat == WaveFormatMULAW && f.BitsPerSample == 8:
+		return func(b []byte, v float64) {
+			b[0] = linearToUlaw(int16(quantize(v, 16)))
+		}, nil
+	}
+	return nil, fmt.Errorf(
+		"unsupported sample encoding: format[%d] bits[%d]",
+		f.AudioFormat,
+		f.BitsPerSample,
+	)
+}
+
+// quantize scales a normalized sample to a signed integer of the given
+// width, rounding to nearest and clipping at full scale.
+func quantize(v float64, bits uint) int64 {
+	scale := float64(int64(1) << (bits - 1))
+	q := math.Round(v * scale)
+	if q > scale-1 {
+		q = scale - 1
+	}
+	if q < -scale {
+		q = -scale
+	}
+	return int64(q)
+}
@@ -0,0 +1,113 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSamplesRoundTrip(t *testing.T) {
+
+	type tcase struct {
+		name      string
+		format    AudioFormat
+		bits      uint16
+		tolerance float64
+	}
+
+	tcases := []tcase{
+		{name: "pcm8", format: WaveFormatPCM, bits: 8, tolerance: 1.0 / 128},
+		{name: "pcm16", format: WaveFormatPCM, bits: 16, tolerance: 1.0 / (1 << 15)},
+		{name: "pcm24", format: WaveFormatPCM, bits: 24, tolerance: 1.0 / (1 << 23)},
+		{name: "pcm32", format: WaveFormatPCM, bits: 32, tolerance: 1.0 / (1 << 31)},
+		{name: "float32", format: WaveFormatIEEEFloat, bits: 32, tolerance: 1e-7},
+		{name: "float64", format: WaveFormatIEEEFloat, bits: 64, tolerance: 0},
+		{name: "alaw", format: WaveFormatALAW, bits: 8, tolerance: 0.04},
+		{name: "mulaw", format: WaveFormatMULAW, bits: 8, tolerance: 0.04},
+	}
+
+	samples := []float64{0, 0.5, -0.5, 0.25, -0.99, 0.99, 0.001, -0.001}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			wav := New(tcase.format, 1, 8000, tcase.bits)
+			assertNoError(t, wav.SetSamples(samples))
+
+			if int(wav.Header.DataBlockSize) != len(samples)*int(tcase.bits/8) {
+				t.Fatalf("unexpected data block size[%d]", wav.Header.DataBlockSize)
+			}
+
+			got, err := wav.Samples()
+			assertNoError(t, err)
+			assertSamplesClose(t, samples, got, tcase.tolerance)
+		})
+	}
+}
+
+func TestSamplesClipOnEncode(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{2, -2}))
+
+	samples, err := wav.Int16LESamples()
+	assertNoError(t, err)
+
+	if samples[0] != math.MaxInt16 || samples[1] != math.MinInt16 {
+		t.Fatalf("expected clipped samples, got %v", samples)
+	}
+}
+
+func TestSamplesUnsupportedEncoding(t *testing.T) {
+	wav := New(WaveFormatALAW, 1, 8000, 16)
+	_, err := wav.Samples()
+	assertError(t, err)
+	assertError(t, wav.SetSamples([]float64{0}))
+}
+
+func assertSamplesClose(t *testing.T, expected []float64, got []float64, tolerance float64) {
+	t.Helper()
+	if len(expected) != len(got) {
+		t.Fatalf("expected len[%d] != got len[%d]", len(expected), len(got))
+	}
+	for i, e := range expected {
+		if math.Abs(e-got[i]) > tolerance {
+			t.Fatalf("sample[%d]: expected[%f] got[%f]", i, e, got[i])
+		}
+	}
+}
+
+func TestParallelSamplesMatchSequential(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 48000, 24)
+	samples := make([]float64, 5*minSamplesPerWorker+123)
+	for i := range samples {
+		samples[i] = math.Sin(float64(i) / 10)
+	}
+
+	assertNoError(t, wav.SetSamples(samples))
+	sequential := wav.Data
+
+	assertNoError(t, wav.SetSamples(samples, WithParallelism(4)))
+	assertBytesEqual(t, sequential, wav.Data)
+
+	expected, err := wav.Samples()
+	assertNoError(t, err)
+
+	got, err := wav.Samples(WithParallelism(4))
+	assertNoError(t, err)
+	assertSamplesClose(t, expected, got, 0)
+}
+
+func TestCompandedSamples(t *testing.T) {
+	wav := New(WaveFormatMULAW, 2, 8000, 8)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5, 0.25, -0.25}))
+	wav.Data = append(wav.Data, 0xff) // incomplete frame
+
+	codewords, err := wav.CompandedSamples()
+	assertNoError(t, err)
+	assertBytesEqual(t, wav.Data[:4], codewords)
+
+	wav.Header.RIFFChunkFmt.BitsPerSample = 16
+	_, err = wav.CompandedSamples()
+	assertError(t, err)
+
+	_, err = New(WaveFormatPCM, 1, 8000, 8).CompandedSamples()
+	assertError(t, err)
+}
@@ -0,0 +1,81 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Slice returns a new Wav with the audio between start and end, keeping
+// the format and the chunks of w. Cue points are moved to the sliced
+// timeline, the ones outside it dropped, and the bext time reference
+// is advanced to the new first sample. An end past the audio length is
+// clamped to it. IMA and G.726 audio, without byte aligned frames,
+// can't be sliced.
+func (w *Wav) Slice(start, end time.Duration, opts ...TransformOption) (*Wav, error) {
+	if start < 0 || end <= start {
+		return nil, fmt.Errorf("invalid slice range: start[%s] end[%s]", start, end)
+	}
+
+	first, err := w.byteOffset(start)
+	if err != nil {
+		return nil, err
+	}
+	last, err := w.byteOffset(end)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, last-first)
+	copy(data, w.Data[first:last])
+
+	sliced := &Wav{
+		Header: w.Header,
+		Data:   data,
+	}
+	sliced.Header.DataBlockSize = uint32(len(data))
+	sliced.Header.RIFFHdr.ChunkSize = riffChunkSize(nil, sliced.Header.DataBlockSize) + headerExtraSize(&sliced.Header)
+
+	if newTransformOptions(opts).dropMetadata {
+		return sliced, nil
+	}
+	block := int(w.Header.RIFFChunkFmt.BytesPerBloc)
+	if err := carryMetadata(sliced, w, uint32(first/block), 1); err != nil {
+		return nil, err
+	}
+	return sliced, nil
+}
+
+// byteOffset converts a time offset into the offset of the nearest
+// frame, clamped to the audio data. Rounding makes the offsets of
+// durations computed from frame counts exact.
+func (w *Wav) byteOffset(d time.Duration) (int, error) {
+	format := w.Header.RIFFChunkFmt
+	if format.BytesPerBloc == 0 || format.SampleRate == 0 {
+		return 0, fmt.Errorf(
+			"can't compute offsets: bytes/block[%d] samplerate[%d]",
+			format.BytesPerBloc,
+			format.SampleRate,
+		)
+	}
+
+	block := int(format.BytesPerBloc)
+	frame, err := frameSize(format)
+	if err != nil {
+		// IMA blocks and packed G.726 codewords don't split into frames
+		return 0, fmt.Errorf("can't slice format[%s]: %s", format.AudioFormat, err)
+	}
+	if frame != block {
+		return 0, fmt.Errorf(
+			"bytes/block[%d] doesn't match the frame size[%d] of the encoding",
+			block,
+			frame,
+		)
+	}
+	frames := len(w.Data) / block
+	offset := int(math.Round(d.Seconds() * float64(format.SampleRate)))
+	if offset > frames {
+		offset = frames
+	}
+	return offset * block, nil
+}
@@ -0,0 +1,64 @@
+package waveparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlice(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 10, 16)
+	samples := make([]float64, 20*2)
+	for i := range samples {
+		samples[i] = float64(i) / 100
+	}
+	assertNoError(t, wav.SetSamples(samples))
+	wav.SetInfo(Info{InfoTitle: "sliced"})
+
+	sliced, err := wav.Slice(500*time.Millisecond, 1500*time.Millisecond)
+	assertNoError(t, err)
+
+	got, err := sliced.Samples()
+	assertNoError(t, err)
+	assertSamplesClose(t, samples[10:30], got, 1e-4)
+
+	if sliced.Header.DataBlockSize != 40 {
+		t.Fatalf("expected data block size[40], got[%d]", sliced.Header.DataBlockSize)
+	}
+
+	info, err := sliced.Info()
+	assertNoError(t, err)
+	if info[InfoTitle] != "sliced" {
+		t.Fatalf("metadata not preserved: %v", info)
+	}
+
+	clamped, err := wav.Slice(time.Second, time.Hour)
+	assertNoError(t, err)
+	if len(clamped.Data) != 10*4 {
+		t.Fatalf("expected clamped slice with 10 frames, got %d bytes", len(clamped.Data))
+	}
+
+	_, err = wav.Slice(time.Second, time.Second)
+	assertError(t, err)
+}
+
+func TestSliceBlockMismatch(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 10, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 20*2)))
+	wav.Header.RIFFChunkFmt.BytesPerBloc = 2
+
+	_, err := wav.Slice(0, time.Second)
+	assertError(t, err)
+}
+
+func TestSliceNotByteAligned(t *testing.T) {
+	ima := New(WaveFormatIMAADPCM, 1, 8000, 4)
+	assertNoError(t, ima.SetSamples(make([]float64, 3*8000)))
+
+	g726 := New(WaveFormatG726ADPCM, 1, 8000, 4)
+	assertNoError(t, g726.SetSamples(make([]float64, 3*8000)))
+
+	for _, wav := range []*Wav{ima, g726} {
+		_, err := wav.Slice(time.Second, 2*time.Second)
+		assertError(t, err)
+	}
+}
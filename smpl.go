@@ -0,0 +1,107 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+type (
+	// Sampler is the smpl chunk, describing how samplers play the
+	// audio back.
+	Sampler struct {
+		Manufacturer      uint32
+		Product           uint32
+		SamplePeriod      uint32 // nanoseconds per sample
+		MIDIUnityNote     uint32
+		MIDIPitchFraction uint32
+		SMPTEFormat       uint32
+		SMPTEOffset       uint32
+		Loops             []SampleLoop
+		SamplerData       []byte // manufacturer specific
+	}
+
+	// SampleLoop is a loop of the smpl chunk, Start and End are the
+	// first and last frames played.
+	SampleLoop struct {
+		CuePointID uint32
+		Type       uint32 // 0 forward, 1 alternating, 2 backward
+		Start      uint32
+		End        uint32
+		Fraction   uint32
+		PlayCount  uint32 // 0 loops forever
+	}
+
+	smplHeader struct {
+		Manufacturer      uint32
+		Product           uint32
+		SamplePeriod      uint32
+		MIDIUnityNote     uint32
+		MIDIPitchFraction uint32
+		SMPTEFormat       uint32
+		SMPTEOffset       uint32
+		NumSampleLoops    uint32
+		SamplerDataSize   uint32
+	}
+)
+
+// Sampler parses the smpl chunk, returning nil when the file has none.
+func (w *Wav) Sampler() (*Sampler, error) {
+	chunk := w.Chunk("smpl")
+	if chunk == nil {
+		return nil, nil
+	}
+
+	var hdr smplHeader
+	r := bytes.NewReader(chunk.Data)
+	if err := binary.Read(r, binary.LittleEndian, &hdr); err != nil {
+		return nil, fmt.Errorf("error parsing smpl chunk: %s", err)
+	}
+	if uint64(hdr.NumSampleLoops)*24+uint64(hdr.SamplerDataSize) > uint64(r.Len()) {
+		return nil, fmt.Errorf(
+			"smpl chunk with loops[%d] sampler data[%d] has only [%d] bytes",
+			hdr.NumSampleLoops,
+			hdr.SamplerDataSize,
+			r.Len(),
+		)
+	}
+
+	s := &Sampler{
+		Manufacturer:      hdr.Manufacturer,
+		Product:           hdr.Product,
+		SamplePeriod:      hdr.SamplePeriod,
+		MIDIUnityNote:     hdr.MIDIUnityNote,
+		MIDIPitchFraction: hdr.MIDIPitchFraction,
+		SMPTEFormat:       hdr.SMPTEFormat,
+		SMPTEOffset:       hdr.SMPTEOffset,
+		Loops:             make([]SampleLoop, hdr.NumSampleLoops),
+		SamplerData:       make([]byte, hdr.SamplerDataSize),
+	}
+	binary.Read(r, binary.LittleEndian, s.Loops)
+	r.Read(s.SamplerData)
+	return s, nil
+}
+
+// SetSampler replaces the smpl chunk, removing it when s is nil.
+func (w *Wav) SetSampler(s *Sampler) {
+	if s == nil {
+		w.RemoveChunk("smpl")
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.LittleEndian, smplHeader{
+		Manufacturer:      s.Manufacturer,
+		Product:           s.Product,
+		SamplePeriod:      s.SamplePeriod,
+		MIDIUnityNote:     s.MIDIUnityNote,
+		MIDIPitchFraction: s.MIDIPitchFraction,
+		SMPTEFormat:       s.SMPTEFormat,
+		SMPTEOffset:       s.SMPTEOffset,
+		NumSampleLoops:    uint32(len(s.Loops)),
+		SamplerDataSize:   uint32(len(s.SamplerData)),
+	})
+	binary.Write(buf, binary.LittleEndian, s.Loops)
+	buf.Write(s.SamplerData)
+	w.SetChunk("smpl", buf.Bytes())
+}
@@ -0,0 +1,75 @@
+package waveparser
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// sniffLen is how much of the beginning of a file IsWav reads.
+const sniffLen = 512
+
+// Sniff reports whether header, the beginning of a file, is a WAV file
+// with a sane fmt chunk: a known audio format (the sub format of
+// extensible files, which is returned), channels and a sample rate.
+// The chunks before fmt must fit on header, the audio isn't needed.
+func Sniff(header []byte) (bool, AudioFormat) {
+	if len(header) < riffHeaderSize ||
+		string(header[:4]) != "RIFF" ||
+		string(header[8:riffHeaderSize]) != "WAVE" {
+		return false, 0
+	}
+
+	pos := int64(riffHeaderSize)
+	for pos+chunkHeaderSize <= int64(len(header)) {
+		id := string(header[pos : pos+4])
+		size := int64(binary.LittleEndian.Uint32(header[pos+4:]))
+		if id == "fmt " {
+			return sniffFmt(header[pos+chunkHeaderSize:], size)
+		}
+		pos += chunkHeaderSize + size + size%2
+	}
+	return false, 0
+}
+
+// IsWav sniffs the beginning of r, like Sniff.
+func IsWav(r io.ReaderAt) bool {
+	header := make([]byte, sniffLen)
+	n, err := r.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return false
+	}
+	ok, _ := Sniff(header[:n])
+	return ok
+}
+
+func sniffFmt(body []byte, size int64) (bool, AudioFormat) {
+	if size < fmtChunkSize || len(body) < fmtChunkSize {
+		return false, 0
+	}
+
+	format := AudioFormat(binary.LittleEndian.Uint16(body))
+	channels := binary.LittleEndian.Uint16(body[2:])
+	rate := binary.LittleEndian.Uint32(body[4:])
+	bits := binary.LittleEndian.Uint16(body[14:])
+
+	if format == WaveFormatExtensible {
+		// cbSize, valid bits and channel mask precede the sub format
+		const subFormatAt = fmtChunkSize + 8
+		if size < subFormatAt+16 || len(body) < subFormatAt+16 {
+			return false, 0
+		}
+		var ext FmtExtension
+		copy(ext.SubFormat[:], body[subFormatAt:])
+		sub, err := ext.subFormat()
+		if err != nil {
+			return false, 0
+		}
+		format = sub
+	}
+
+	if !format.Known() || format == WaveFormatExtensible ||
+		channels == 0 || rate == 0 || bits == 0 {
+		return false, 0
+	}
+	return true, format
+}
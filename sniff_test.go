@@ -0,0 +1,47 @@
+package waveparser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSniff(t *testing.T) {
+	pcm := New(WaveFormatPCM, 2, 8000, 16)
+	assertNoError(t, pcm.SetSamples([]float64{0.5, -0.5}))
+	pcmFile, err := pcm.Bytes()
+	assertNoError(t, err)
+
+	extensibleFile := extensibleWav(t, WaveFormatIEEEFloat, 0x3, 2)
+
+	noChannels := append([]byte(nil), pcmFile...)
+	noChannels[22] = 0
+
+	type tcase struct {
+		name   string
+		header []byte
+		ok     bool
+		format AudioFormat
+	}
+
+	tcases := []tcase{
+		{name: "PCM", header: pcmFile, ok: true, format: WaveFormatPCM},
+		{name: "HeaderOnly", header: pcmFile[:36], ok: true, format: WaveFormatPCM},
+		{name: "Extensible", header: extensibleFile, ok: true, format: WaveFormatIEEEFloat},
+		{name: "NoChannels", header: noChannels, ok: false},
+		{name: "TruncatedFmt", header: pcmFile[:30], ok: false},
+		{name: "NotRIFF", header: []byte("ID3\x04\x00\x00\x00\x00\x00\x00\x00\x00"), ok: false},
+		{name: "Empty", header: nil, ok: false},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, format := Sniff(tc.header)
+			if ok != tc.ok || format != tc.format {
+				t.Fatalf("expected [%t] [%s], got [%t] [%s]", tc.ok, tc.format, ok, format)
+			}
+			if IsWav(bytes.NewReader(tc.header)) != tc.ok {
+				t.Fatalf("IsWav differs from Sniff")
+			}
+		})
+	}
+}
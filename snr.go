@@ -0,0 +1,68 @@
+package waveparser
+
+import (
+	"fmt"
+	"math"
+)
+
+// SNR returns the signal to noise ratio, in dB, of test against
+// reference: the power of the reference over the power of their
+// difference. Identical audio has +Inf SNR. Both must have the same
+// channels and sample rate, only their common length is compared.
+func SNR(reference, test *Wav) (float64, error) {
+	signal, noise, _, err := snrPowers(reference, test)
+	if err != nil {
+		return 0, err
+	}
+	return 10 * math.Log10(signal/noise), nil
+}
+
+// PSNR returns the peak signal to noise ratio, in dB, of test against
+// reference, taking full scale as the peak.
+func PSNR(reference, test *Wav) (float64, error) {
+	_, noise, n, err := snrPowers(reference, test)
+	if err != nil {
+		return 0, err
+	}
+	return 10 * math.Log10(float64(n)/noise), nil
+}
+
+// snrPowers returns the energy of the reference, of the difference and
+// the number of samples compared.
+func snrPowers(reference, test *Wav) (float64, float64, int, error) {
+	fr, ft := reference.Header.RIFFChunkFmt, test.Header.RIFFChunkFmt
+	if fr.NumChannels != ft.NumChannels || fr.SampleRate != ft.SampleRate {
+		return 0, 0, 0, fmt.Errorf(
+			"can't compare channels[%d] samplerate[%d] with channels[%d] samplerate[%d]",
+			fr.NumChannels,
+			fr.SampleRate,
+			ft.NumChannels,
+			ft.SampleRate,
+		)
+	}
+
+	s1, err := reference.Samples()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	s2, err := test.Samples()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	n := len(s1)
+	if len(s2) < n {
+		n = len(s2)
+	}
+	if n == 0 {
+		return 0, 0, 0, fmt.Errorf("no samples to compare")
+	}
+
+	var signal, noise float64
+	for i := 0; i < n; i++ {
+		d := s1[i] - s2[i]
+		signal += s1[i] * s1[i]
+		noise += d * d
+	}
+	return signal, noise, n, nil
+}
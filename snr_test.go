@@ -0,0 +1,41 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSNR(t *testing.T) {
+	samples := make([]float64, 1000)
+	noisy := make([]float64, 1000)
+	for i := range samples {
+		samples[i] = 0.5 * math.Sin(float64(i)*0.1)
+		noisy[i] = samples[i] + 0.005*math.Sin(float64(i)*0.1)
+	}
+
+	reference := New(WaveFormatIEEEFloat, 1, 8000, 64)
+	assertNoError(t, reference.SetSamples(samples))
+	test := New(WaveFormatIEEEFloat, 1, 8000, 64)
+	assertNoError(t, test.SetSamples(noisy))
+
+	snr, err := SNR(reference, test)
+	assertNoError(t, err)
+	if math.Abs(snr-40) > 1e-6 {
+		t.Fatalf("expected 40 dB, got %f", snr)
+	}
+
+	psnr, err := PSNR(reference, test)
+	assertNoError(t, err)
+	if math.Abs(psnr-(snr+dBFS(1/0.5*math.Sqrt2))) > 0.1 {
+		t.Fatalf("expected PSNR 9 dB above SNR, got %f", psnr)
+	}
+
+	same, err := SNR(reference, reference)
+	assertNoError(t, err)
+	if !math.IsInf(same, 1) {
+		t.Fatalf("expected +Inf, got %f", same)
+	}
+
+	_, err = SNR(reference, New(WaveFormatIEEEFloat, 2, 8000, 64))
+	assertError(t, err)
+}
@@ -0,0 +1,109 @@
+package waveparser
+
+import (
+	"math"
+	"time"
+)
+
+type (
+	// Stats summarizes the levels of the audio. Levels are in dBFS,
+	// -Inf meaning digital silence.
+	Stats struct {
+		Duration time.Duration
+		Peak     float64
+		RMS      float64
+		Clipped  int     // samples at full scale
+		Silence  float64 // percentage of the audio below SilenceThreshold
+	}
+)
+
+const (
+	// SilenceThreshold is the RMS level, in dBFS, under which a
+	// window of audio is considered silent.
+	SilenceThreshold = -60.0
+
+	silenceWindow = 10 * time.Millisecond
+)
+
+// Duration returns the length of the audio data.
+func (w *Wav) Duration() time.Duration {
+	bytesPerSec := w.Header.RIFFChunkFmt.BytesPerSec
+	if bytesPerSec == 0 {
+		return 0
+	}
+	return time.Duration(float64(len(w.Data)) / float64(bytesPerSec) * float64(time.Second))
+}
+
+// Stats computes duration, levels, clipping and silence of the audio.
+func (w *Wav) Stats() (Stats, error) {
+	samples, err := w.Samples()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	format := w.Header.RIFFChunkFmt
+	clip := clipLevel(format)
+
+	var peak, sum float64
+	clipped := 0
+	for _, s := range samples {
+		a := math.Abs(s)
+		if a > peak {
+			peak = a
+		}
+		if a >= clip {
+			clipped++
+		}
+		sum += s * s
+	}
+
+	var rms float64
+	if len(samples) > 0 {
+		rms = math.Sqrt(sum / float64(len(samples)))
+	}
+
+	return Stats{
+		Duration: w.Duration(),
+		Peak:     dBFS(peak),
+		RMS:      dBFS(rms),
+		Clipped:  clipped,
+		Silence:  silencePercentage(samples, int(format.NumChannels), format.SampleRate),
+	}, nil
+}
+
+func dBFS(level float64) float64 {
+	return 20 * math.Log10(level)
+}
+
+// clipLevel is the highest magnitude the format can represent.
+func clipLevel(format RiffChunkFmt) float64 {
+	if format.AudioFormat != WaveFormatPCM {
+		return 1
+	}
+	scale := float64(int64(1) << (format.BitsPerSample - 1))
+	return (scale - 1) / scale
+}
+
+func silencePercentage(samples []float64, channels int, sampleRate uint32) float64 {
+	window := int(silenceWindow.Seconds()*float64(sampleRate)) * channels
+	if window == 0 || len(samples) == 0 {
+		return 0
+	}
+
+	silent := 0
+	for start := 0; start < len(samples); start += window {
+		end := start + window
+		if end > len(samples) {
+			end = len(samples)
+		}
+
+		var sum float64
+		for _, s := range samples[start:end] {
+			sum += s * s
+		}
+		if dBFS(math.Sqrt(sum/float64(end-start))) < SilenceThreshold {
+			silent += end - start
+		}
+	}
+	return 100 * float64(silent) / float64(len(samples))
+}
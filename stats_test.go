@@ -0,0 +1,52 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 1000, 16)
+
+	samples := make([]float64, 1000)
+	for i := 0; i < 500; i++ {
+		samples[i] = 0.5 * math.Sin(2*math.Pi*50*float64(i)/1000)
+	}
+	samples[10] = 1
+	assertNoError(t, wav.SetSamples(samples))
+
+	stats, err := wav.Stats()
+	assertNoError(t, err)
+
+	if stats.Duration != time.Second {
+		t.Fatalf("expected 1s, got %s", stats.Duration)
+	}
+	if math.Abs(stats.Peak) > 0.001 {
+		t.Fatalf("expected peak at 0 dBFS, got %f", stats.Peak)
+	}
+	if stats.Clipped != 1 {
+		t.Fatalf("expected 1 clipped sample, got %d", stats.Clipped)
+	}
+	if stats.Silence != 50 {
+		t.Fatalf("expected 50%% silence, got %f", stats.Silence)
+	}
+	if stats.RMS > dBFS(0.5) || stats.RMS < dBFS(0.2) {
+		t.Fatalf("unexpected RMS %f", stats.RMS)
+	}
+}
+
+func TestStatsOfSilence(t *testing.T) {
+	wav := New(WaveFormatIEEEFloat, 2, 8000, 32)
+	assertNoError(t, wav.SetSamples(make([]float64, 160)))
+
+	stats, err := wav.Stats()
+	assertNoError(t, err)
+
+	if !math.IsInf(stats.Peak, -1) || !math.IsInf(stats.RMS, -1) {
+		t.Fatalf("expected -Inf levels, got peak[%f] rms[%f]", stats.Peak, stats.RMS)
+	}
+	if stats.Silence != 100 {
+		t.Fatalf("expected 100%% silence, got %f", stats.Silence)
+	}
+}
@@ -0,0 +1,61 @@
+package waveparser
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+type (
+	// forwardSeeker lets the parser run on plain readers, like sockets
+	// and pipes. It only seeks forward, discarding the bytes skipped.
+	forwardSeeker struct {
+		r   io.Reader
+		pos int64
+	}
+)
+
+// ParseStream parses a complete WAV stream from a reader that can't
+// seek, skipping unwanted bytes by reading them.
+func ParseStream(r io.Reader, opts ...ParseOption) (*Wav, error) {
+	return Parse(newForwardSeeker(r), opts...)
+}
+
+// ParseHeaderStream parses only the header of a WAV stream that can't
+// seek, leaving r at the start of the sample data.
+func ParseHeaderStream(r io.Reader) (WavHeader, error) {
+	return parseHeader(newForwardSeeker(r))
+}
+
+func newForwardSeeker(r io.Reader) io.ReadSeeker {
+	if seeker, ok := r.(io.ReadSeeker); ok {
+		return seeker
+	}
+	return &forwardSeeker{r: r}
+}
+
+func (f *forwardSeeker) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	f.pos += int64(n)
+	return n, err
+}
+
+func (f *forwardSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekStart:
+	default:
+		return f.pos, fmt.Errorf("can't seek from the end of a stream")
+	}
+	if offset < f.pos {
+		return f.pos, fmt.Errorf("can't seek backwards on a stream: from[%d] to[%d]", f.pos, offset)
+	}
+
+	// like files, seeking past the end succeeds and reads give EOF
+	if _, err := io.CopyN(ioutil.Discard, f.r, offset-f.pos); err != nil && err != io.EOF {
+		return f.pos, err
+	}
+	f.pos = offset
+	return f.pos, nil
+}
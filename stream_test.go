@@ -0,0 +1,57 @@
+package waveparser
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestParseStream(t *testing.T) {
+	const audiofile = "./testdata/audios/sint16le.wav"
+
+	expected, err := Load(audiofile)
+	assertNoError(t, err)
+
+	file, err := os.Open(audiofile)
+	assertNoError(t, err)
+	defer file.Close()
+
+	// hides Seek, as on pipes and sockets
+	wav, err := ParseStream(io.MultiReader(file))
+	assertNoError(t, err)
+
+	if wav.Header != expected.Header {
+		t.Fatalf("expected header %+v, got %+v", expected.Header, wav.Header)
+	}
+	assertBytesEqual(t, expected.Data, wav.Data)
+	if len(wav.Chunks) != len(expected.Chunks) {
+		t.Fatalf("expected %d chunks, got %d", len(expected.Chunks), len(wav.Chunks))
+	}
+}
+
+func TestParseHeaderStream(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5}))
+	wav.SetInfo(Info{InfoTitle: "skipped"})
+	buf := &bytes.Buffer{}
+	_, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+
+	r, w := io.Pipe()
+	go func() {
+		w.Write(buf.Bytes())
+		w.Close()
+	}()
+
+	hdr, err := ParseHeaderStream(r)
+	assertNoError(t, err)
+	if hdr.DataBlockSize != 4 {
+		t.Fatalf("expected data block size[4], got[%d]", hdr.DataBlockSize)
+	}
+
+	samples, err := ioutil.ReadAll(r)
+	assertNoError(t, err)
+	assertBytesEqual(t, wav.Data, samples)
+}
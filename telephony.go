@@ -0,0 +1,18 @@
+package waveparser
+
+// TelephonyFormat is the 8 kHz mono µ-law (G.711) format of telephony.
+var TelephonyFormat = New(WaveFormatMULAW, 1, 8000, 8).Header.RIFFChunkFmt
+
+// ToTelephony returns a copy of w converted to TelephonyFormat: mixed
+// down to mono (with Downmix for surround audio), resampled to 8 kHz
+// and µ-law encoded.
+func ToTelephony(w *Wav) (*Wav, error) {
+	if w.Header.RIFFChunkFmt.NumChannels > 2 {
+		mono, err := w.Downmix(LayoutMono)
+		if err != nil {
+			return nil, err
+		}
+		w = mono
+	}
+	return convertResampled(w, TelephonyFormat)
+}
@@ -0,0 +1,33 @@
+package waveparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToTelephony(t *testing.T) {
+	for _, channels := range []uint16{1, 2, 6} {
+		wav := New(WaveFormatPCM, channels, 44100, 16)
+		samples := make([]float64, int(channels)*44100)
+		for i := range samples {
+			samples[i] = 0.25 * math.Sin(2*math.Pi*440*float64(i/int(channels))/44100)
+		}
+		assertNoError(t, wav.SetSamples(samples))
+		wav.SetInfo(Info{InfoTitle: "prompt"})
+
+		phone, err := ToTelephony(wav)
+		assertNoError(t, err)
+
+		if !SameFormat(phone.Header.RIFFChunkFmt, TelephonyFormat) {
+			t.Fatalf("channels[%d]: unexpected format %+v", channels, phone.Header.RIFFChunkFmt)
+		}
+		if len(phone.Data) != 8000 {
+			t.Fatalf("channels[%d]: expected a second of audio, got %d bytes", channels, len(phone.Data))
+		}
+		info, err := phone.Info()
+		assertNoError(t, err)
+		if info[InfoTitle] != "prompt" {
+			t.Fatalf("channels[%d]: metadata not preserved: %v", channels, info)
+		}
+	}
+}
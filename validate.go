@@ -0,0 +1,119 @@
+package waveparser
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+type (
+	// Severity grades a Finding of Validate.
+	Severity int
+
+	// Finding is a problem found by Validate, located at the byte
+	// offset of the offending field or chunk of the file.
+	Finding struct {
+		Severity Severity
+		Code     string
+		Message  string
+		Offset   int64
+	}
+)
+
+const (
+	// SeverityWarning marks files readers usually cope with.
+	SeverityWarning Severity = iota
+	// SeverityError marks files that can't be read, or are read wrong.
+	SeverityError
+)
+
+// offsets of fmt fields, the parser requires fmt right after the RIFF
+// header
+const (
+	riffSizeOffset     = 4
+	bytesPerSecOffset  = riffHeaderSize + chunkHeaderSize + 8
+	bytesPerBlocOffset = riffHeaderSize + chunkHeaderSize + 12
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// MarshalText encodes the severity by its name.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Validate checks the WAV file held on data, returning its problems
+// with errors first: files the parser rejects, truncated or misaligned
+// audio, fmt fields inconsistent with the encoding and RIFF sizes that
+// don't match the file. No findings means the file is well formed.
+func Validate(data []byte) []Finding {
+	wav, err := ParseBytes(data)
+	if err != nil {
+		offset := int64(0)
+		var scanErr *ChunkScanError
+		if errors.As(err, &scanErr) {
+			offset = scanErr.Offset
+		}
+		return []Finding{{SeverityError, "parse", err.Error(), offset}}
+	}
+
+	var findings []Finding
+	add := func(severity Severity, code string, offset int64, msg string, args ...interface{}) {
+		findings = append(findings, Finding{severity, code, fmt.Sprintf(msg, args...), offset})
+	}
+
+	hdr := wav.Header
+	format := hdr.RIFFChunkFmt
+	dataSizeOffset := int64(hdr.FirstSamplePos) - 4
+
+	if format.NumChannels == 0 || format.SampleRate == 0 {
+		add(SeverityError, "fmt", riffHeaderSize+chunkHeaderSize,
+			"invalid channels[%d] samplerate[%d]", format.NumChannels, format.SampleRate)
+	}
+	if _, err := sampleDecoder(format); err != nil && !isIMA(format.AudioFormat) && !isG726(format.AudioFormat) {
+		add(SeverityError, "encoding", riffHeaderSize+chunkHeaderSize, "%s", err)
+	}
+
+	// without channels there is no frame size to check against
+	var bytesPerBloc uint16
+	if format.NumChannels != 0 {
+		var bytesPerSec uint32
+		bytesPerBloc, bytesPerSec = blockAlign(format)
+		if format.BytesPerBloc != bytesPerBloc {
+			add(SeverityError, "block-align", bytesPerBlocOffset,
+				"bytes/block[%d] should be [%d]", format.BytesPerBloc, bytesPerBloc)
+		}
+		if format.BytesPerSec != bytesPerSec {
+			add(SeverityWarning, "byte-rate", bytesPerSecOffset,
+				"bytes/second[%d] should be [%d]", format.BytesPerSec, bytesPerSec)
+		}
+	}
+
+	if uint32(len(wav.Data)) < hdr.DataBlockSize {
+		add(SeverityError, "truncated-data", dataSizeOffset,
+			"data chunk of [%d] bytes has only [%d]", hdr.DataBlockSize, len(wav.Data))
+	}
+	if bytesPerBloc > 0 && len(wav.Data)%int(bytesPerBloc) != 0 {
+		add(SeverityWarning, "partial-frame", dataSizeOffset,
+			"data size[%d] isn't a multiple of the frame size[%d]", len(wav.Data), bytesPerBloc)
+	}
+	if (isIMA(format.AudioFormat) || isG726(format.AudioFormat)) && wav.Chunk("fact") == nil {
+		add(SeverityWarning, "missing-fact", dataSizeOffset,
+			"compressed format[%s] without a fact chunk", format.AudioFormat)
+	}
+
+	if riffSize := int64(hdr.RIFFHdr.ChunkSize) + chunkHeaderSize; riffSize != int64(len(data)) {
+		add(SeverityWarning, "riff-size", riffSizeOffset,
+			"RIFF size[%d] doesn't match the file size[%d]", hdr.RIFFHdr.ChunkSize, len(data)-chunkHeaderSize)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity > findings[j].Severity
+	})
+	return findings
+}
@@ -0,0 +1,106 @@
+package waveparser
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 8000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5, 0.25, -0.25}))
+	valid, err := wav.Bytes()
+	assertNoError(t, err)
+
+	patched := func(offset int, value interface{}) []byte {
+		data := append([]byte(nil), valid...)
+		switch v := value.(type) {
+		case uint16:
+			binary.LittleEndian.PutUint16(data[offset:], v)
+		case uint32:
+			binary.LittleEndian.PutUint32(data[offset:], v)
+		}
+		return data
+	}
+
+	ima := New(WaveFormatIMAADPCM, 1, 8000, 4)
+	assertNoError(t, ima.SetSamples(make([]float64, 1000)))
+	imaData, err := ima.Bytes()
+	assertNoError(t, err)
+	noChannels := append([]byte(nil), imaData...)
+	binary.LittleEndian.PutUint16(noChannels[riffHeaderSize+chunkHeaderSize+2:], 0) // channels
+
+	type tcase struct {
+		name     string
+		data     []byte
+		codes    []string
+		severity Severity
+		offset   int64
+	}
+
+	tcases := []tcase{
+		{name: "Valid", data: valid},
+		{name: "NotWav", data: []byte("RIFX"), codes: []string{"parse"}, severity: SeverityError},
+		{
+			name:     "BlockAlign",
+			data:     patched(bytesPerBlocOffset, uint16(3)),
+			codes:    []string{"block-align"},
+			severity: SeverityError,
+			offset:   bytesPerBlocOffset,
+		},
+		{
+			name:     "ByteRate",
+			data:     patched(bytesPerSecOffset, uint32(1)),
+			codes:    []string{"byte-rate"},
+			severity: SeverityWarning,
+			offset:   bytesPerSecOffset,
+		},
+		{
+			name:     "Truncated",
+			data:     valid[:len(valid)-2],
+			codes:    []string{"truncated-data", "partial-frame", "riff-size"},
+			severity: SeverityError,
+			offset:   40,
+		},
+		{
+			name:     "IMANoChannels",
+			data:     noChannels,
+			codes:    []string{"fmt"},
+			severity: SeverityError,
+			offset:   riffHeaderSize + chunkHeaderSize,
+		},
+		{
+			name:     "RiffSize",
+			data:     patched(riffSizeOffset, uint32(1000)),
+			codes:    []string{"riff-size"},
+			severity: SeverityWarning,
+			offset:   riffSizeOffset,
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			findings := Validate(tc.data)
+			if len(findings) != len(tc.codes) {
+				t.Fatalf("expected findings %v, got %+v", tc.codes, findings)
+			}
+			for i, code := range tc.codes {
+				if findings[i].Code != code {
+					t.Fatalf("expected findings %v, got %+v", tc.codes, findings)
+				}
+			}
+			if len(findings) > 0 && (findings[0].Severity != tc.severity || findings[0].Offset != tc.offset) {
+				t.Fatalf("unexpected first finding %+v", findings[0])
+			}
+		})
+	}
+}
+
+func TestSeverityJSON(t *testing.T) {
+	encoded, err := json.Marshal(Finding{Severity: SeverityError, Code: "parse"})
+	assertNoError(t, err)
+	expected := `{"Severity":"error","Code":"parse","Message":"","Offset":0}`
+	if string(encoded) != expected {
+		t.Fatalf("expected %s, got %s", expected, encoded)
+	}
+}
@@ -0,0 +1,60 @@
+package waveparser
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+// WaveformColor is the color RenderWaveform draws the envelope with.
+var WaveformColor = color.RGBA{R: 0x1f, G: 0x77, B: 0xb4, A: 0xff}
+
+// RenderWaveform draws the min/max amplitude envelope of the audio (all
+// channels together), one pixel column per slice of audio, over a
+// transparent background.
+func RenderWaveform(w *Wav, width, height int) (image.Image, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid image size: width[%d] height[%d]", width, height)
+	}
+
+	samples, err := w.Samples()
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	channels := int(w.Header.RIFFChunkFmt.NumChannels)
+	if channels == 0 {
+		return nil, fmt.Errorf("invalid number of channels[%d]", channels)
+	}
+
+	frames := len(samples) / channels
+	if frames == 0 {
+		return img, nil
+	}
+
+	y := func(v float64) int {
+		v = math.Max(-1, math.Min(1, v))
+		return int(math.Round((1 - v) / 2 * float64(height-1)))
+	}
+
+	for x := 0; x < width; x++ {
+		first := x * frames / width
+		last := (x + 1) * frames / width
+		if last <= first {
+			last = first + 1
+		}
+
+		min, max := math.Inf(1), math.Inf(-1)
+		for _, s := range samples[first*channels : last*channels] {
+			min = math.Min(min, s)
+			max = math.Max(max, s)
+		}
+
+		for py := y(max); py <= y(min); py++ {
+			img.Set(x, py, WaveformColor)
+		}
+	}
+	return img, nil
+}
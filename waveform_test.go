@@ -0,0 +1,45 @@
+package waveparser
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestRenderWaveform(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 8000, 16)
+	// first half silent, second half full scale square wave
+	samples := make([]float64, 200*2)
+	for i := 200; i < len(samples); i++ {
+		if (i/2)%2 == 0 {
+			samples[i] = 0.99
+		} else {
+			samples[i] = -0.99
+		}
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	img, err := RenderWaveform(wav, 10, 21)
+	assertNoError(t, err)
+
+	bounds := img.Bounds()
+	if bounds.Dx() != 10 || bounds.Dy() != 21 {
+		t.Fatalf("unexpected image size %v", bounds)
+	}
+
+	painted := func(x, y int) bool {
+		return img.At(x, y) == color.Color(WaveformColor)
+	}
+
+	if !painted(0, 10) || painted(0, 0) || painted(0, 20) {
+		t.Fatal("silent column should only paint the center line")
+	}
+	if !painted(9, 0) || !painted(9, 20) {
+		t.Fatal("full scale column should paint from top to bottom")
+	}
+}
+
+func TestRenderWaveformInvalidSize(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 16)
+	_, err := RenderWaveform(wav, 0, 10)
+	assertError(t, err)
+}
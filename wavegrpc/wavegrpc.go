@@ -0,0 +1,132 @@
+// Package wavegrpc streams Wavs over gRPC as wavepb.Audio messages,
+// each holding a chunk of the audio data, for services declaring
+// streaming methods like:
+//
+//	rpc Upload(stream waveparser.wavepb.Audio) returns (...);
+package wavegrpc
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+	"github.com/NeowayLabs/waveparser/wavepb"
+	"google.golang.org/grpc"
+)
+
+// DefaultChunk is the duration of audio sent on each message.
+const DefaultChunk = 100 * time.Millisecond
+
+var (
+	_ Sender   = grpc.ServerStream(nil)
+	_ Sender   = grpc.ClientStream(nil)
+	_ Receiver = grpc.ServerStream(nil)
+	_ Receiver = grpc.ClientStream(nil)
+)
+
+type (
+	// Sender is the sending side of a stream, grpc.ServerStream and
+	// grpc.ClientStream are Senders.
+	Sender interface {
+		SendMsg(m interface{}) error
+	}
+
+	// Receiver is the receiving side of a stream, grpc.ServerStream
+	// and grpc.ClientStream are Receivers.
+	Receiver interface {
+		RecvMsg(m interface{}) error
+	}
+)
+
+// Send streams the audio of w in messages holding chunk of audio each,
+// DefaultChunk when it isn't positive. Every message carries the
+// format, an empty Wav is sent as a single message without data.
+func Send(s Sender, w *waveparser.Wav, chunk time.Duration) error {
+	format := w.Header.RIFFChunkFmt
+	size, err := chunkSize(format, chunk)
+	if err != nil {
+		return err
+	}
+
+	msgFormat := wavepb.FormatToProto(format)
+	data := w.Data
+	for {
+		n := size
+		if n > len(data) {
+			n = len(data)
+		}
+		if err := s.SendMsg(&wavepb.Audio{Format: msgFormat, Data: data[:n]}); err != nil {
+			return fmt.Errorf("error sending audio: %s", err)
+		}
+		data = data[n:]
+		if len(data) == 0 {
+			return nil
+		}
+	}
+}
+
+// Receive writes the audio streamed by Send to out as a WAV file,
+// using a waveparser.Writer, until the stream ends. It returns the
+// format of the audio received.
+func Receive(r Receiver, out io.WriteSeeker) (waveparser.RiffChunkFmt, error) {
+	var (
+		writer *waveparser.Writer
+		format waveparser.RiffChunkFmt
+	)
+
+	for {
+		var msg wavepb.Audio
+		err := r.RecvMsg(&msg)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return format, fmt.Errorf("error receiving audio: %s", err)
+		}
+
+		msgFormat, err := wavepb.FormatFromProto(msg.GetFormat())
+		if err != nil {
+			return format, err
+		}
+		if writer == nil {
+			format = msgFormat
+			if writer, err = waveparser.NewWriter(out, format); err != nil {
+				return format, err
+			}
+		} else if msgFormat != format {
+			return format, fmt.Errorf("audio format changed mid-stream: %+v != %+v", msgFormat, format)
+		}
+
+		if _, err := writer.Write(msg.GetData()); err != nil {
+			return format, err
+		}
+	}
+
+	if writer == nil {
+		return format, fmt.Errorf("stream ended without audio")
+	}
+	return format, writer.Close()
+}
+
+// chunkSize is the size of the data sent on each message, a multiple
+// of the block size.
+func chunkSize(format waveparser.RiffChunkFmt, chunk time.Duration) (int, error) {
+	if format.BytesPerBloc == 0 || format.BytesPerSec == 0 {
+		return 0, fmt.Errorf(
+			"can't split audio: bytes/block[%d] bytes/second[%d]",
+			format.BytesPerBloc,
+			format.BytesPerSec,
+		)
+	}
+	if chunk <= 0 {
+		chunk = DefaultChunk
+	}
+
+	block := int(format.BytesPerBloc)
+	size := int(chunk.Seconds()*float64(format.BytesPerSec)) / block * block
+	if size < block {
+		size = block
+	}
+	return size, nil
+}
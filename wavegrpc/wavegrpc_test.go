@@ -0,0 +1,139 @@
+package wavegrpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+	"github.com/NeowayLabs/waveparser/wavepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func assertNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// uploadService is a service with a single client streaming method,
+// receiving the audio into path.
+func uploadService(path string, messages *int) *grpc.ServiceDesc {
+	return &grpc.ServiceDesc{
+		ServiceName: "wavegrpc.test.Audio",
+		HandlerType: (*interface{})(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "Upload",
+			ClientStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				f, err := os.Create(path)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+
+				counter := &countingReceiver{Receiver: stream, count: messages}
+				if _, err := Receive(counter, f); err != nil {
+					return err
+				}
+				return stream.SendMsg(&wavepb.Audio{})
+			},
+		}},
+	}
+}
+
+type countingReceiver struct {
+	Receiver
+	count *int
+}
+
+func (c *countingReceiver) RecvMsg(m interface{}) error {
+	err := c.Receiver.RecvMsg(m)
+	if err == nil {
+		*c.count++
+	}
+	return err
+}
+
+func TestStreamOverGRPC(t *testing.T) {
+	wav := waveparser.New(waveparser.WaveFormatPCM, 2, 8000, 16)
+	samples := make([]float64, 8000*2)
+	for i := range samples {
+		samples[i] = float64(i%100) / 100
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	path := filepath.Join(t.TempDir(), "received.wav")
+	var messages int
+	service := uploadService(path, &messages)
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	server.RegisterService(service, nil)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assertNoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := conn.NewStream(ctx, &service.Streams[0], "/wavegrpc.test.Audio/Upload")
+	assertNoError(t, err)
+
+	assertNoError(t, Send(stream, wav, 250*time.Millisecond))
+	assertNoError(t, stream.CloseSend())
+	assertNoError(t, stream.RecvMsg(&wavepb.Audio{}))
+
+	if messages != 4 {
+		t.Fatalf("expected 1s of audio in [4] messages, got [%d]", messages)
+	}
+
+	received, err := waveparser.Load(path)
+	assertNoError(t, err)
+	if received.Header.RIFFChunkFmt != wav.Header.RIFFChunkFmt {
+		t.Fatalf("expected fmt %+v, got %+v", wav.Header.RIFFChunkFmt, received.Header.RIFFChunkFmt)
+	}
+	if string(received.Data) != string(wav.Data) {
+		t.Fatal("received audio differs from the sent one")
+	}
+}
+
+type sliceStream struct {
+	sent []*wavepb.Audio
+}
+
+func (s *sliceStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m.(*wavepb.Audio))
+	return nil
+}
+
+func TestSendChunks(t *testing.T) {
+	wav := waveparser.New(waveparser.WaveFormatPCM, 1, 1000, 8)
+	assertNoError(t, wav.SetSamples(make([]float64, 250)))
+
+	stream := &sliceStream{}
+	assertNoError(t, Send(stream, wav, 0))
+	if len(stream.sent) != 3 || len(stream.sent[0].Data) != 100 || len(stream.sent[2].Data) != 50 {
+		t.Fatalf("expected chunks of 100ms, got %d messages", len(stream.sent))
+	}
+
+	empty := &sliceStream{}
+	assertNoError(t, Send(empty, waveparser.New(waveparser.WaveFormatPCM, 1, 1000, 8), 0))
+	if len(empty.sent) != 1 || empty.sent[0].GetFormat() == nil {
+		t.Fatal("empty wavs must be sent as a single message with the format")
+	}
+}
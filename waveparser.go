@@ -6,14 +6,15 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"os"
 	"strings"
+	"time"
 )
 
 type (
 	WavHeader struct {
 		RIFFHdr      RiffHeader
-		RIFFChunkFmt RiffChunkFmt
+		RIFFChunkFmt RiffChunkFmt // AudioFormat is the sub format of extensible files
+		Extension    FmtExtension // set only on WAVE_FORMAT_EXTENSIBLE files
 
 		FirstSamplePos uint32 // position of start of sample data
 		DataBlockSize  uint32 // size of sample block (PCM data)
@@ -21,7 +22,21 @@ type (
 
 	Wav struct {
 		Header WavHeader
+		Chunks []Chunk // chunks other than fmt and data, in file order
 		Data   []byte
+
+		// DataChunks has every data chunk, in file order, when parsed
+		// with DataChunksList.
+		DataChunks [][]byte
+
+		// Padding locates the JUNK/PAD chunks of the parsed file, they
+		// are kept on Chunks too.
+		Padding []Padding
+	}
+
+	Chunk struct {
+		ID   [4]byte
+		Data []byte
 	}
 
 	RiffHeader struct {
@@ -32,47 +47,167 @@ type (
 
 	RiffChunkFmt struct {
 		LengthOfHeader uint32
-		AudioFormat    uint16
+		AudioFormat    AudioFormat
 		NumChannels    uint16
 		SampleRate     uint32
 		BytesPerSec    uint32
 		BytesPerBloc   uint16
 		BitsPerSample  uint16
 	}
-)
 
-const (
-	WaveFormatPCM        = 0x0001
-	WaveFormatIEEEFloat  = 0x0003
-	WaveFormatALAW       = 0x0006
-	WaveFormatMULAW      = 0x0007
-	WaveFormatExtensible = 0xFFFE
-)
+	// ChunkScanError aborts the parsing of files whose chunks can't be
+	// walked safely, like crafted files with endless chunk lists.
+	ChunkScanError struct {
+		ID     [4]byte
+		Offset int64 // position of the chunk header
+		Reason string
+	}
 
-func Load(audiofile string) (*Wav, error) {
-	f, err := os.Open(audiofile)
-	if err != nil {
-		return nil, err
+	// DataSizeError is returned when the audio data is larger than the
+	// budget given with WithMaxDataSize.
+	DataSizeError struct {
+		Size int64 // declared size of the data chunks loaded
+		Max  int64
 	}
+)
+
+func (e *DataSizeError) Error() string {
+	return fmt.Sprintf("data size[%d] exceeds the maximum[%d]", e.Size, e.Max)
+}
 
-	defer f.Close()
+// maxChunks bounds the chunks scanned on each side of the data chunk.
+const maxChunks = 4096
 
-	hdr, err := parseHeader(f)
+func (e *ChunkScanError) Error() string {
+	return fmt.Sprintf("error scanning chunk[%s] at offset[%d]: %s", string(e.ID[:]), e.Offset, e.Reason)
+}
+
+// Parse parses a complete WAV stream, collecting its metadata
+// chunks (before and after the data chunk) and sample data.
+func Parse(r io.ReadSeeker, opts ...ParseOption) (*Wav, error) {
+	return parseWav(r, nil, newParseOptions(opts))
+}
+
+// parseWav parses a complete WAV stream, reading the sample data into
+// buf when it has enough capacity.
+func parseWav(r io.ReadSeeker, buf []byte, opts parseOptions) (*Wav, error) {
+	var chunks []Chunk
+	var padding []Padding
+	collect := func(id [4]byte, size uint32, chunk io.Reader) error {
+		pos, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+
+		data, err := ioutil.ReadAll(chunk)
+		if err != nil {
+			return err
+		}
+		chunks = append(chunks, Chunk{ID: id, Data: data})
+
+		if isPadding(id) {
+			padding = append(padding, Padding{
+				ID:     id,
+				Offset: uint32(pos) - chunkHeaderSize,
+				Size:   uint32(len(data)),
+			})
+		}
+		return nil
+	}
+
+	hdr, err := parse(r, collect)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := ioutil.ReadAll(f)
+	// truncated files just get the samples available
+	limit := int64(hdr.DataBlockSize)
+	if opts.maxDataSize > 0 && limit > opts.maxDataSize {
+		limit = opts.maxDataSize + 1
+	}
+	data, err := readData(buf, io.LimitReader(r, limit))
 	if err != nil {
 		return nil, err
 	}
+	if opts.maxDataSize > 0 && int64(len(data)) > opts.maxDataSize {
+		return nil, &DataSizeError{Size: int64(hdr.DataBlockSize), Max: opts.maxDataSize}
+	}
+
+	if uint32(len(data)) < hdr.DataBlockSize {
+		var tag *Chunk
+		if data, tag = splitID3(data); tag != nil {
+			chunks = append(chunks, *tag)
+		}
+	}
+
+	var dataChunks [][]byte
+	if opts.dataChunks == DataChunksList {
+		dataChunks = [][]byte{data}
+	}
+
+	loaded := int64(len(data))
+	trailing := func(id [4]byte, size uint32, r io.Reader) error {
+		if string(id[:]) != "data" {
+			return collect(id, size, r)
+		}
+
+		if opts.dataChunks == DataChunksFirst {
+			return nil
+		}
+		if opts.maxDataSize > 0 && loaded+int64(size) > opts.maxDataSize {
+			return &DataSizeError{Size: loaded + int64(size), Max: opts.maxDataSize}
+		}
+		loaded += int64(size)
+
+		extra, err := ioutil.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if opts.dataChunks == DataChunksConcat {
+			data = append(data, extra...)
+		} else {
+			dataChunks = append(dataChunks, extra)
+		}
+		return nil
+	}
+
+	if uint32(len(data)) == hdr.DataBlockSize {
+		if err := parseTrailingChunks(r, hdr.DataBlockSize, trailing); err != nil {
+			return nil, err
+		}
+	}
 
 	return &Wav{
-		Header: hdr,
-		Data:   data,
+		Header:     hdr,
+		Chunks:     chunks,
+		Data:       data,
+		DataChunks: dataChunks,
+		Padding:    padding,
 	}, nil
 }
 
+// ParseBytes parses a WAV file held in memory.
+func ParseBytes(data []byte, opts ...ParseOption) (*Wav, error) {
+	return Parse(bytes.NewReader(data), opts...)
+}
+
+// ParseHeader parses only the header of a WAV stream. Only the bytes
+// up to the start of the data chunk are needed.
+func ParseHeader(r io.ReadSeeker) (WavHeader, error) {
+	return parseHeader(r)
+}
+
+// ParseWithVisitor parses the header of a WAV stream like ParseHeader,
+// handing every chunk found between the fmt and data chunks to visit.
+// Contents not read by visit are skipped, and an error returned by it
+// aborts the parsing.
+func ParseWithVisitor(
+	r io.ReadSeeker,
+	visit func(id [4]byte, size uint32, r io.Reader) error,
+) (WavHeader, error) {
+	return parse(r, visit)
+}
+
 func (w *Wav) Int16LESamples() ([]int16, error) {
 	// TODO: validate using header
 	const typesize = 2
@@ -122,16 +257,54 @@ func (hdr *WavHeader) String() string {
 		fmt.Sprintf("RIFF Size: %d bytes", hdr.RIFFHdr.ChunkSize),
 		fmt.Sprintf("File type: %s", string(hdr.RIFFHdr.FileType[:])),
 		"=== Fmt ===",
-		fmt.Sprintf("Audio format: %d", hdr.RIFFChunkFmt.AudioFormat),
+		fmt.Sprintf(
+			"Audio format: %d (%s)",
+			hdr.RIFFChunkFmt.AudioFormat,
+			hdr.RIFFChunkFmt.AudioFormat.Name(),
+		),
 		fmt.Sprintf("Number of channels: %d", hdr.RIFFChunkFmt.NumChannels),
 		fmt.Sprintf("Sample rate: %d", hdr.RIFFChunkFmt.SampleRate),
 		fmt.Sprintf("Bytes/seconds: %d", hdr.RIFFChunkFmt.BytesPerSec),
 		fmt.Sprintf("Bytes/block: %d", hdr.RIFFChunkFmt.BytesPerBloc),
 		fmt.Sprintf("Bits/sample: %d", hdr.RIFFChunkFmt.BitsPerSample),
 	}
+	if hdr.IsExtensible() {
+		strs = append(strs,
+			fmt.Sprintf("Valid bits/sample: %d", hdr.Extension.ValidBitsPerSample),
+			fmt.Sprintf("Channel mask: %#x (%s)", hdr.Extension.ChannelMask, ChannelLayout(hdr.ChannelLayout())),
+		)
+	}
+	strs = append(strs,
+		"=== Data ===",
+		fmt.Sprintf("Data offset: %d", hdr.FirstSamplePos),
+		fmt.Sprintf("Data size: %d bytes", hdr.DataBlockSize),
+		fmt.Sprintf("Frames: %d", hdr.frames()),
+		fmt.Sprintf("Duration: %s", hdr.duration()),
+	)
 	return strings.Join(strs, "\n")
 }
 
+// frames is the number of sample frames in the data chunk.
+func (hdr *WavHeader) frames() uint32 {
+	if hdr.RIFFChunkFmt.BytesPerBloc == 0 {
+		return 0
+	}
+	blocks := hdr.DataBlockSize / uint32(hdr.RIFFChunkFmt.BytesPerBloc)
+	if isIMA(hdr.RIFFChunkFmt.AudioFormat) && imaCheckFormat(hdr.RIFFChunkFmt) == nil {
+		return blocks * uint32(imaSamplesPerBlock(hdr.RIFFChunkFmt))
+	}
+	return blocks
+}
+
+// duration is the playing time of the data chunk.
+func (hdr *WavHeader) duration() time.Duration {
+	if hdr.RIFFChunkFmt.BytesPerSec == 0 {
+		return 0
+	}
+	seconds := float64(hdr.DataBlockSize) / float64(hdr.RIFFChunkFmt.BytesPerSec)
+	return time.Duration(seconds * float64(time.Second))
+}
+
 func parseRIFFHeader(r io.Reader) (*RiffHeader, error) {
 	var hdr RiffHeader
 	err := binary.Read(r, binary.LittleEndian, &hdr)
@@ -144,22 +317,18 @@ func parseRIFFHeader(r io.Reader) (*RiffHeader, error) {
 	return &hdr, nil
 }
 
-func isValidWavFormat(fmt uint16) bool {
-	for _, validFormat := range []uint16{
-		WaveFormatMULAW,
-		WaveFormatALAW,
-		WaveFormatIEEEFloat,
-		WaveFormatPCM,
-	} {
-		if fmt == validFormat {
-			return true
-		}
-	}
+// chunkFunc handles the contents of a chunk found while parsing, r is
+// limited to the chunk size.
+type chunkFunc func(id [4]byte, size uint32, r io.Reader) error
 
-	return false
+func parseHeader(r io.ReadSeeker) (WavHeader, error) {
+	return parse(r, nil)
 }
 
-func parseHeader(r io.ReadSeeker) (WavHeader, error) {
+// parse reads the header, handing the contents of every chunk found
+// between the fmt and data chunks to onChunk. When onChunk is nil
+// the chunks are skipped.
+func parse(r io.ReadSeeker, onChunk chunkFunc) (WavHeader, error) {
 	riffhdr, err := parseRIFFHeader(r)
 	if err != nil {
 		return WavHeader{}, err
@@ -183,25 +352,39 @@ func parseHeader(r io.ReadSeeker) (WavHeader, error) {
 		return WavHeader{}, err
 	}
 
-	if !isValidWavFormat(chunkFmt.AudioFormat) {
-		return WavHeader{}, fmt.Errorf("Isn't an audio format: format[%d]", chunkFmt.AudioFormat)
-	}
-
+	var extension FmtExtension
 	if chunkFmt.LengthOfHeader != 16 {
 		var extraparams uint16
 		// Get extra params size
 		if err = binary.Read(r, binary.LittleEndian, &extraparams); err != nil {
 			return WavHeader{}, fmt.Errorf("error getting extra fmt params: %s", err)
 		}
+		if chunkFmt.AudioFormat == WaveFormatExtensible && extraparams >= fmtExtensionSize {
+			if err = binary.Read(r, binary.LittleEndian, &extension); err != nil {
+				return WavHeader{}, fmt.Errorf("error getting extensible fmt params: %s", err)
+			}
+			extraparams -= fmtExtensionSize
+			if chunkFmt.AudioFormat, err = extension.subFormat(); err != nil {
+				return WavHeader{}, err
+			}
+		}
 		// Skip
-		if _, err = r.Seek(int64(extraparams), os.SEEK_CUR); err != nil {
+		if _, err = r.Seek(int64(extraparams), io.SeekCurrent); err != nil {
 			return WavHeader{}, fmt.Errorf("error skipping extra params: %s", err)
 		}
 	}
 
+	if !chunkFmt.AudioFormat.Known() || chunkFmt.AudioFormat == WaveFormatExtensible {
+		return WavHeader{}, fmt.Errorf("Isn't an audio format: format[%d]", chunkFmt.AudioFormat)
+	}
+
 	var chunkSize uint32
 
-	for string(chunk[:]) != "data" {
+	for count := 0; string(chunk[:]) != "data"; count++ {
+		if count == maxChunks {
+			return WavHeader{}, tooManyChunks(r, chunk)
+		}
+
 		// Read chunkID
 		err = binary.Read(r, binary.BigEndian, &chunk)
 		if err != nil {
@@ -213,20 +396,135 @@ func parseHeader(r io.ReadSeeker) (WavHeader, error) {
 			return WavHeader{}, fmt.Errorf("Expected data chunkSize: %s", err)
 		}
 
-		// ignores LIST chunkIDs (unused for now)
 		if string(chunk[:]) != "data" {
-			if _, err = r.Seek(int64(chunkSize), os.SEEK_CUR); err != nil {
+			if err = readChunk(r, chunk, chunkSize, onChunk); err != nil {
 				return WavHeader{}, err
 			}
 		}
 	}
 
-	pos, _ := r.Seek(0, os.SEEK_CUR)
+	pos, _ := r.Seek(0, io.SeekCurrent)
 	return WavHeader{
 		RIFFHdr:      *riffhdr,
 		RIFFChunkFmt: chunkFmt,
 
+		Extension: extension,
+
 		FirstSamplePos: uint32(pos),
 		DataBlockSize:  uint32(chunkSize),
 	}, nil
 }
+
+// readData reads r until EOF appending to buf[:0], growing it only as
+// data arrives so bogus data sizes don't cause huge allocations.
+func readData(buf []byte, r io.Reader) ([]byte, error) {
+	buf = buf[:0]
+	for {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err == io.EOF {
+			return buf, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseTrailingChunks hands the chunks after the data chunk to
+// onChunk, stopping at the end of the stream or at anything that
+// doesn't look like a chunk (eg: zero padding).
+func parseTrailingChunks(
+	r io.ReadSeeker,
+	dataSize uint32,
+	onChunk chunkFunc,
+) error {
+	if dataSize%2 != 0 {
+		if _, err := r.Seek(1, io.SeekCurrent); err != nil {
+			return err
+		}
+	}
+
+	var id [4]byte
+	var size uint32
+
+	for count := 0; ; count++ {
+		if count == maxChunks {
+			return tooManyChunks(r, id)
+		}
+		if err := binary.Read(r, binary.BigEndian, &id); err != nil {
+			return nil
+		}
+		if !isChunkID(id) {
+			return nil
+		}
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return nil
+		}
+
+		handler := onChunk
+		if string(id[:]) == "fmt " {
+			handler = nil
+		}
+		if err := readChunk(r, id, size, handler); err != nil {
+			return err
+		}
+	}
+}
+
+// isChunkID reports whether id is made of printable ASCII.
+func isChunkID(id [4]byte) bool {
+	for _, c := range id {
+		if c < ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// readChunk hands the chunk contents to onChunk (or skips them) and
+// leaves r positioned at the next chunk, honoring the RIFF pad byte.
+func readChunk(
+	r io.ReadSeeker,
+	id [4]byte,
+	size uint32,
+	onChunk chunkFunc,
+) error {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	if onChunk != nil {
+		if err := onChunk(id, size, io.LimitReader(r, int64(size))); err != nil {
+			return fmt.Errorf("error reading chunk[%s]: %w", string(id[:]), err)
+		}
+	}
+
+	next := start + int64(size) + int64(size%2)
+	pos, err := r.Seek(next, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	if pos != next {
+		return &ChunkScanError{
+			ID:     id,
+			Offset: start - chunkHeaderSize,
+			Reason: fmt.Sprintf("seek to the next chunk at [%d] landed at [%d]", next, pos),
+		}
+	}
+	return nil
+}
+
+// tooManyChunks is the error of a chunk list longer than maxChunks.
+func tooManyChunks(r io.Seeker, last [4]byte) error {
+	pos, _ := r.Seek(0, io.SeekCurrent)
+	return &ChunkScanError{
+		ID:     last,
+		Offset: pos,
+		Reason: fmt.Sprintf("more than [%d] chunks", maxChunks),
+	}
+}
@@ -3,27 +3,15 @@ package waveparser
 import (
 	"bytes"
 	"encoding/binary"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"os"
-	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 )
 
-type wavExpectedHeader struct {
-	RIFFHeader struct {
-		Ident     string
-		ChunkSize uint32
-		FileType  string
-	}
-	RIFFChunkFmt   RiffChunkFmt
-	FirstSamplePos uint32
-	DataBlockSize  uint32
-}
-
 func assertNoError(t *testing.T, err error) {
 	t.Helper()
 	if err != nil {
@@ -114,80 +102,6 @@ func TestFloatSamplesMustBeNormalized(t *testing.T) {
 	}
 }
 
-func testParseWAV(t *testing.T, filename string) {
-
-	r, err := os.Open(filename)
-	assertNoError(t, err)
-
-	hdr, hdrerr := parseHeader(r)
-
-	ext := filepath.Ext(filename)
-	noext := strings.TrimSuffix(filename, ext)
-	expectedHdrFile := noext + ".hdr.expected"
-	errFile := noext + ".err"
-
-	expectedErr, err := ioutil.ReadFile(errFile)
-	if err == nil {
-		if hdrerr == nil {
-			t.Fatalf("Expected error: %s but ran successfully...", string(expectedErr))
-		}
-		if hdrerr.Error() != string(expectedErr) {
-			t.Fatalf("Error differs: '%s' != '%s'", hdrerr, string(expectedErr))
-		}
-		return
-	} else if hdrerr != nil {
-		t.Fatalf("Error: %s", hdrerr)
-	}
-
-	expectedHdrContent, err := ioutil.ReadFile(expectedHdrFile)
-	if err == nil {
-		var expectedHdr wavExpectedHeader
-		err = json.Unmarshal(expectedHdrContent, &expectedHdr)
-		assertNoError(t, err)
-
-		expected := WavHeader{
-			RIFFHdr: RiffHeader{
-				ChunkSize: expectedHdr.RIFFHeader.ChunkSize,
-			},
-			RIFFChunkFmt:   expectedHdr.RIFFChunkFmt,
-			FirstSamplePos: expectedHdr.FirstSamplePos,
-			DataBlockSize:  expectedHdr.DataBlockSize,
-		}
-
-		// adjust expected file because JSON spec do not support char/runes
-		expected.RIFFHdr.Ident[0] = expectedHdr.RIFFHeader.Ident[0]
-		expected.RIFFHdr.Ident[1] = expectedHdr.RIFFHeader.Ident[1]
-		expected.RIFFHdr.Ident[2] = expectedHdr.RIFFHeader.Ident[2]
-		expected.RIFFHdr.Ident[3] = expectedHdr.RIFFHeader.Ident[3]
-
-		expected.RIFFHdr.FileType[0] = expectedHdr.RIFFHeader.FileType[0]
-		expected.RIFFHdr.FileType[1] = expectedHdr.RIFFHeader.FileType[1]
-		expected.RIFFHdr.FileType[2] = expectedHdr.RIFFHeader.FileType[2]
-		expected.RIFFHdr.FileType[3] = expectedHdr.RIFFHeader.FileType[3]
-
-		if !reflect.DeepEqual(hdr, expected) {
-			t.Fatalf("WAV header differs:\n\n%#v\n\n!=\n\n%#v\n", hdr, expected)
-		}
-		return
-	}
-
-	t.Fatalf("no error file nor expected file found for input: %s", filename)
-}
-
-func TestParseWAVHeaders(t *testing.T) {
-	files, err := ioutil.ReadDir("testdata")
-	assertNoError(t, err)
-
-	for _, file := range files {
-		if strings.HasSuffix(file.Name(), "wav") {
-			fname := file.Name()
-			t.Run(fmt.Sprintf("header-%s", fname), func(t *testing.T) {
-				testParseWAV(t, filepath.Join("testdata", fname))
-			})
-		}
-	}
-}
-
 func TestSignedInt16LittleEndianSamples(t *testing.T) {
 
 	wav, err := Load("testdata/audios/sint16le.wav")
@@ -236,3 +150,206 @@ func assertBytesEqual(t *testing.T, expected []byte, got []byte) {
 		}
 	}
 }
+
+func TestParseBytes(t *testing.T) {
+	const audiofile = "./testdata/r.wav"
+
+	raw, err := ioutil.ReadFile(audiofile)
+	assertNoError(t, err)
+
+	expected, err := Load(audiofile)
+	assertNoError(t, err)
+
+	got, err := ParseBytes(raw)
+	assertNoError(t, err)
+
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatal("ParseBytes differs from Load")
+	}
+
+	// header inspection needs nothing past the start of the data
+	hdr, err := ParseHeader(bytes.NewReader(raw[:expected.Header.FirstSamplePos]))
+	assertNoError(t, err)
+
+	if hdr != expected.Header {
+		t.Fatalf("expected header %+v, got %+v", expected.Header, hdr)
+	}
+}
+
+func TestParseStopsAtDataChunkEnd(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 8)
+	wav.Data = []byte{1, 2, 3}
+
+	buf := &bytes.Buffer{}
+	_, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+
+	// metadata after the data chunk, then zero padding
+	buf.WriteString("LIST")
+	binary.Write(buf, binary.LittleEndian, uint32(4))
+	buf.WriteString("INFO")
+	buf.Write(make([]byte, 16))
+
+	got, err := ParseBytes(buf.Bytes())
+	assertNoError(t, err)
+
+	assertBytesEqual(t, wav.Data, got.Data)
+	if len(got.Chunks) != 1 || string(got.Chunks[0].ID[:]) != "LIST" {
+		t.Fatalf("expected trailing LIST chunk, got %v", got.Chunks)
+	}
+	assertBytesEqual(t, []byte("INFO"), got.Chunks[0].Data)
+}
+
+func TestHeaderString(t *testing.T) {
+	wav, err := Load("./testdata/r.wav")
+	assertNoError(t, err)
+
+	str := wav.Header.String()
+	for _, line := range []string{
+		"Audio format: 1 (PCM)",
+		"Data offset: 78",
+		"Data size: 7418 bytes",
+		"Frames: 3709",
+		"Duration: 463.625ms",
+	} {
+		if !strings.Contains(str, line) {
+			t.Fatalf("expected [%s] in:\n%s", line, str)
+		}
+	}
+}
+
+func TestParseMultipleDataChunks(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 8)
+	wav.Data = []byte{1, 2, 3}
+
+	buf := &bytes.Buffer{}
+	_, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+
+	buf.WriteString("data")
+	binary.Write(buf, binary.LittleEndian, uint32(2))
+	buf.Write([]byte{4, 5})
+
+	first, err := ParseBytes(buf.Bytes())
+	assertNoError(t, err)
+	assertBytesEqual(t, []byte{1, 2, 3}, first.Data)
+	if first.DataChunks != nil || len(first.Chunks) != 0 {
+		t.Fatal("extra data chunks must be skipped by default")
+	}
+
+	concat, err := ParseBytes(buf.Bytes(), WithDataChunks(DataChunksConcat))
+	assertNoError(t, err)
+	assertBytesEqual(t, []byte{1, 2, 3, 4, 5}, concat.Data)
+
+	list, err := ParseBytes(buf.Bytes(), WithDataChunks(DataChunksList))
+	assertNoError(t, err)
+	assertBytesEqual(t, []byte{1, 2, 3}, list.Data)
+	if len(list.DataChunks) != 2 {
+		t.Fatalf("expected [2] data chunks, got [%d]", len(list.DataChunks))
+	}
+	assertBytesEqual(t, []byte{4, 5}, list.DataChunks[1])
+}
+
+func TestParseWithVisitor(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 8)
+	wav.Data = []byte{1, 2, 3}
+	wav.SetChunk("mrkr", []byte{9, 8, 7})
+	wav.SetChunk("acme", []byte{6})
+
+	buf := &bytes.Buffer{}
+	_, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+
+	var mrkr []byte
+	var sizes []uint32
+	hdr, err := ParseWithVisitor(bytes.NewReader(buf.Bytes()), func(id [4]byte, size uint32, r io.Reader) error {
+		sizes = append(sizes, size)
+		if string(id[:]) != "mrkr" {
+			return nil
+		}
+		var err error
+		mrkr, err = ioutil.ReadAll(r)
+		return err
+	})
+	assertNoError(t, err)
+	assertBytesEqual(t, []byte{9, 8, 7}, mrkr)
+	if !reflect.DeepEqual(sizes, []uint32{3, 1}) {
+		t.Fatalf("expected chunk sizes [3 1], got %v", sizes)
+	}
+	if hdr.DataBlockSize != 3 {
+		t.Fatalf("expected data block size[3], got[%d]", hdr.DataBlockSize)
+	}
+
+	_, err = ParseWithVisitor(bytes.NewReader(buf.Bytes()), func(id [4]byte, size uint32, r io.Reader) error {
+		return fmt.Errorf("rejected")
+	})
+	assertError(t, err)
+}
+
+// stuckSeeker ignores seeks, as some broken readers do.
+type stuckSeeker struct {
+	*bytes.Reader
+}
+
+func (s stuckSeeker) Seek(offset int64, whence int) (int64, error) {
+	return s.Reader.Seek(0, io.SeekCurrent)
+}
+
+func TestParseBoundedChunkScan(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 8)
+	buf := &bytes.Buffer{}
+	_, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+	header := buf.Bytes()[:riffHeaderSize+chunkHeaderSize+fmtChunkSize]
+
+	endless := append([]byte(nil), header...)
+	endless = append(endless, make([]byte, (maxChunks+1)*chunkHeaderSize)...)
+
+	_, err = ParseHeader(bytes.NewReader(endless))
+	var scanErr *ChunkScanError
+	if !errors.As(err, &scanErr) {
+		t.Fatalf("expected a ChunkScanError, got %v", err)
+	}
+
+	skipped := append([]byte(nil), header...)
+	skipped = append(skipped, []byte("acme\x04\x00\x00\x00junk")...)
+
+	_, err = ParseHeader(stuckSeeker{bytes.NewReader(skipped)})
+	if !errors.As(err, &scanErr) {
+		t.Fatalf("expected a ChunkScanError, got %v", err)
+	}
+	if string(scanErr.ID[:]) != "acme" {
+		t.Fatalf("expected error on chunk[acme], got %v", scanErr)
+	}
+}
+
+func TestParseMaxDataSize(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 8)
+	wav.Data = []byte{1, 2, 3, 4}
+	wav.Header.DataBlockSize = 4
+
+	buf := &bytes.Buffer{}
+	_, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+
+	parsed, err := ParseBytes(buf.Bytes(), WithMaxDataSize(4))
+	assertNoError(t, err)
+	assertBytesEqual(t, wav.Data, parsed.Data)
+
+	var sizeErr *DataSizeError
+	_, err = ParseBytes(buf.Bytes(), WithMaxDataSize(3))
+	if !errors.As(err, &sizeErr) || sizeErr.Size != 4 || sizeErr.Max != 3 {
+		t.Fatalf("expected a DataSizeError, got %v", err)
+	}
+
+	buf.WriteString("data")
+	binary.Write(buf, binary.LittleEndian, uint32(2))
+	buf.Write([]byte{5, 6})
+
+	_, err = ParseBytes(buf.Bytes(), WithMaxDataSize(5), WithDataChunks(DataChunksConcat))
+	if !errors.As(err, &sizeErr) || sizeErr.Size != 6 {
+		t.Fatalf("expected a DataSizeError counting every data chunk, got %v", err)
+	}
+	_, err = ParseBytes(buf.Bytes(), WithMaxDataSize(5))
+	assertNoError(t, err)
+}
@@ -0,0 +1,66 @@
+// Package wavepb converts between Wavs and protobuf messages, to send
+// audio fragments between services over gRPC.
+package wavepb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative wavepb.proto
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+// ToProto returns the format and audio data of w. The data shares
+// memory with w.
+func ToProto(w *waveparser.Wav) *Audio {
+	return &Audio{
+		Format: FormatToProto(w.Header.RIFFChunkFmt),
+		Data:   w.Data,
+	}
+}
+
+// FromProto builds a Wav holding the audio of a, like LoadRaw does.
+func FromProto(a *Audio) (*waveparser.Wav, error) {
+	format, err := FormatFromProto(a.GetFormat())
+	if err != nil {
+		return nil, err
+	}
+	return waveparser.LoadRaw(bytes.NewReader(a.GetData()), format)
+}
+
+// FormatToProto returns the message of a fmt chunk.
+func FormatToProto(format waveparser.RiffChunkFmt) *Format {
+	return &Format{
+		AudioFormat:   uint32(format.AudioFormat),
+		Channels:      uint32(format.NumChannels),
+		SampleRate:    format.SampleRate,
+		BitsPerSample: uint32(format.BitsPerSample),
+		BytesPerBlock: uint32(format.BytesPerBloc),
+		BytesPerSec:   format.BytesPerSec,
+	}
+}
+
+// FormatFromProto returns the fmt chunk of a message. The block sizes
+// of the message are ignored, they are derived from the other fields.
+func FormatFromProto(f *Format) (waveparser.RiffChunkFmt, error) {
+	if f == nil {
+		return waveparser.RiffChunkFmt{}, fmt.Errorf("audio message without format")
+	}
+	if f.Channels > 0xffff || f.BitsPerSample > 0xffff || f.AudioFormat > 0xffff {
+		return waveparser.RiffChunkFmt{}, fmt.Errorf(
+			"invalid format: audio format[%d] channels[%d] bits per sample[%d]",
+			f.AudioFormat,
+			f.Channels,
+			f.BitsPerSample,
+		)
+	}
+
+	wav := waveparser.New(
+		waveparser.AudioFormat(f.AudioFormat),
+		uint16(f.Channels),
+		f.SampleRate,
+		uint16(f.BitsPerSample),
+	)
+	return wav.Header.RIFFChunkFmt, nil
+}
@@ -0,0 +1,228 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: wavepb.proto
+
+package wavepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Format is the encoding of the audio, as on the fmt chunk.
+type Format struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AudioFormat   uint32                 `protobuf:"varint,1,opt,name=audio_format,json=audioFormat,proto3" json:"audio_format,omitempty"`
+	Channels      uint32                 `protobuf:"varint,2,opt,name=channels,proto3" json:"channels,omitempty"`
+	SampleRate    uint32                 `protobuf:"varint,3,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`
+	BitsPerSample uint32                 `protobuf:"varint,4,opt,name=bits_per_sample,json=bitsPerSample,proto3" json:"bits_per_sample,omitempty"`
+	BytesPerBlock uint32                 `protobuf:"varint,5,opt,name=bytes_per_block,json=bytesPerBlock,proto3" json:"bytes_per_block,omitempty"`
+	BytesPerSec   uint32                 `protobuf:"varint,6,opt,name=bytes_per_sec,json=bytesPerSec,proto3" json:"bytes_per_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Format) Reset() {
+	*x = Format{}
+	mi := &file_wavepb_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Format) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Format) ProtoMessage() {}
+
+func (x *Format) ProtoReflect() protoreflect.Message {
+	mi := &file_wavepb_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Format.ProtoReflect.Descriptor instead.
+func (*Format) Descriptor() ([]byte, []int) {
+	return file_wavepb_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Format) GetAudioFormat() uint32 {
+	if x != nil {
+		return x.AudioFormat
+	}
+	return 0
+}
+
+func (x *Format) GetChannels() uint32 {
+	if x != nil {
+		return x.Channels
+	}
+	return 0
+}
+
+func (x *Format) GetSampleRate() uint32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *Format) GetBitsPerSample() uint32 {
+	if x != nil {
+		return x.BitsPerSample
+	}
+	return 0
+}
+
+func (x *Format) GetBytesPerBlock() uint32 {
+	if x != nil {
+		return x.BytesPerBlock
+	}
+	return 0
+}
+
+func (x *Format) GetBytesPerSec() uint32 {
+	if x != nil {
+		return x.BytesPerSec
+	}
+	return 0
+}
+
+// Audio is a fragment of audio: its format and the encoded samples,
+// interleaved as on the data chunk.
+type Audio struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        *Format                `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Audio) Reset() {
+	*x = Audio{}
+	mi := &file_wavepb_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Audio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Audio) ProtoMessage() {}
+
+func (x *Audio) ProtoReflect() protoreflect.Message {
+	mi := &file_wavepb_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Audio.ProtoReflect.Descriptor instead.
+func (*Audio) Descriptor() ([]byte, []int) {
+	return file_wavepb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Audio) GetFormat() *Format {
+	if x != nil {
+		return x.Format
+	}
+	return nil
+}
+
+func (x *Audio) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_wavepb_proto protoreflect.FileDescriptor
+
+const file_wavepb_proto_rawDesc = "" +
+	"\n" +
+	"\fwavepb.proto\x12\x11waveparser.wavepb\"\xdc\x01\n" +
+	"\x06Format\x12!\n" +
+	"\faudio_format\x18\x01 \x01(\rR\vaudioFormat\x12\x1a\n" +
+	"\bchannels\x18\x02 \x01(\rR\bchannels\x12\x1f\n" +
+	"\vsample_rate\x18\x03 \x01(\rR\n" +
+	"sampleRate\x12&\n" +
+	"\x0fbits_per_sample\x18\x04 \x01(\rR\rbitsPerSample\x12&\n" +
+	"\x0fbytes_per_block\x18\x05 \x01(\rR\rbytesPerBlock\x12\"\n" +
+	"\rbytes_per_sec\x18\x06 \x01(\rR\vbytesPerSec\"N\n" +
+	"\x05Audio\x121\n" +
+	"\x06format\x18\x01 \x01(\v2\x19.waveparser.wavepb.FormatR\x06format\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04dataB)Z'github.com/NeowayLabs/waveparser/wavepbb\x06proto3"
+
+var (
+	file_wavepb_proto_rawDescOnce sync.Once
+	file_wavepb_proto_rawDescData []byte
+)
+
+func file_wavepb_proto_rawDescGZIP() []byte {
+	file_wavepb_proto_rawDescOnce.Do(func() {
+		file_wavepb_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_wavepb_proto_rawDesc), len(file_wavepb_proto_rawDesc)))
+	})
+	return file_wavepb_proto_rawDescData
+}
+
+var file_wavepb_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_wavepb_proto_goTypes = []any{
+	(*Format)(nil), // 0: waveparser.wavepb.Format
+	(*Audio)(nil),  // 1: waveparser.wavepb.Audio
+}
+var file_wavepb_proto_depIdxs = []int32{
+	0, // 0: waveparser.wavepb.Audio.format:type_name -> waveparser.wavepb.Format
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_wavepb_proto_init() }
+func file_wavepb_proto_init() {
+	if File_wavepb_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wavepb_proto_rawDesc), len(file_wavepb_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_wavepb_proto_goTypes,
+		DependencyIndexes: file_wavepb_proto_depIdxs,
+		MessageInfos:      file_wavepb_proto_msgTypes,
+	}.Build()
+	File_wavepb_proto = out.File
+	file_wavepb_proto_goTypes = nil
+	file_wavepb_proto_depIdxs = nil
+}
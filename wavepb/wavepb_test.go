@@ -0,0 +1,58 @@
+package wavepb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/NeowayLabs/waveparser"
+	"google.golang.org/protobuf/proto"
+)
+
+func assertNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	wav := waveparser.New(waveparser.WaveFormatPCM, 2, 16000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5, 0.25, -0.25}))
+
+	encoded, err := proto.Marshal(ToProto(wav))
+	assertNoError(t, err)
+
+	var decoded Audio
+	assertNoError(t, proto.Unmarshal(encoded, &decoded))
+
+	back, err := FromProto(&decoded)
+	assertNoError(t, err)
+	if back.Header != wav.Header {
+		t.Fatalf("expected header %+v, got %+v", wav.Header, back.Header)
+	}
+	if !bytes.Equal(back.Data, wav.Data) {
+		t.Fatalf("expected data %v, got %v", wav.Data, back.Data)
+	}
+}
+
+func TestFromProtoErrors(t *testing.T) {
+	type tcase struct {
+		name  string
+		audio *Audio
+	}
+
+	tcases := []tcase{
+		{name: "NoFormat", audio: &Audio{Data: []byte{1, 2}}},
+		{name: "NoChannels", audio: &Audio{Format: &Format{AudioFormat: 1, SampleRate: 8000, BitsPerSample: 16}}},
+		{name: "TooManyChannels", audio: &Audio{Format: &Format{AudioFormat: 1, Channels: 1 << 16, SampleRate: 8000, BitsPerSample: 16}}},
+		{name: "UnsupportedBits", audio: &Audio{Format: &Format{AudioFormat: 1, Channels: 1, SampleRate: 8000, BitsPerSample: 12}}},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := FromProto(tc.audio); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
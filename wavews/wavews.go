@@ -0,0 +1,116 @@
+// Package wavews streams PCM audio over WebSocket connections, the way
+// browsers send microphone audio: binary messages holding a fixed
+// duration of raw samples each, sent at the pace of the audio. The
+// receiving side assembles the messages into a WAV file.
+package wavews
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+	"golang.org/x/net/websocket"
+)
+
+// DefaultFrame is the duration of audio sent on each message.
+const DefaultFrame = 20 * time.Millisecond
+
+type (
+	// Options configures Send and SendWav.
+	Options struct {
+		Frame time.Duration // audio per message, DefaultFrame when unset
+		Burst bool          // send messages as soon as read, instead of in real time
+	}
+)
+
+var sampleFormats = map[waveparser.SampleFormat]struct {
+	format waveparser.AudioFormat
+	bits   uint16
+}{
+	waveparser.SampleUint8:     {waveparser.WaveFormatPCM, 8},
+	waveparser.SampleInt16LE:   {waveparser.WaveFormatPCM, 16},
+	waveparser.SampleInt24LE:   {waveparser.WaveFormatPCM, 24},
+	waveparser.SampleInt32LE:   {waveparser.WaveFormatPCM, 32},
+	waveparser.SampleFloat32LE: {waveparser.WaveFormatIEEEFloat, 32},
+	waveparser.SampleFloat64LE: {waveparser.WaveFormatIEEEFloat, 64},
+}
+
+// SendWav decodes the audio of w, converting it to the given sample
+// format, and sends it like Send.
+func SendWav(ws *websocket.Conn, w *waveparser.Wav, format waveparser.SampleFormat, opts Options) error {
+	sample, ok := sampleFormats[format]
+	if !ok {
+		return fmt.Errorf("unknown sample format[%d]", format)
+	}
+	f := w.Header.RIFFChunkFmt
+	pcm := waveparser.New(sample.format, f.NumChannels, f.SampleRate, sample.bits)
+	return Send(ws, w.PCMReader(format), pcm.Header.RIFFChunkFmt, opts)
+}
+
+// Send reads raw audio encoded as format from r, a live stream or a
+// decoded file, sending it in messages of opts.Frame of audio until r
+// ends. Messages hold whole frames, except for the last one when r
+// ends mid-frame.
+func Send(ws *websocket.Conn, r io.Reader, format waveparser.RiffChunkFmt, opts Options) error {
+	frame := opts.Frame
+	if frame <= 0 {
+		frame = DefaultFrame
+	}
+	if format.BytesPerBloc == 0 || format.SampleRate == 0 {
+		return fmt.Errorf(
+			"can't frame audio: bytes/block[%d] samplerate[%d]",
+			format.BytesPerBloc,
+			format.SampleRate,
+		)
+	}
+
+	frames := int(frame.Seconds() * float64(format.SampleRate))
+	if frames == 0 {
+		frames = 1
+	}
+	buf := make([]byte, frames*int(format.BytesPerBloc))
+
+	start := time.Now()
+	for sent := 0; ; sent++ {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if !opts.Burst {
+				time.Sleep(time.Until(start.Add(time.Duration(sent) * frame)))
+			}
+			if err := websocket.Message.Send(ws, buf[:n]); err != nil {
+				return fmt.Errorf("error sending audio: %s", err)
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Receive writes the audio of every message received on ws, encoded as
+// format, to out as a WAV file until the connection is closed.
+func Receive(ws *websocket.Conn, out io.WriteSeeker, format waveparser.RiffChunkFmt) error {
+	writer, err := waveparser.NewWriter(out, format)
+	if err != nil {
+		return err
+	}
+
+	for {
+		var data []byte
+		err := websocket.Message.Receive(ws, &data)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error receiving audio: %s", err)
+		}
+		if _, err := writer.Write(data); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
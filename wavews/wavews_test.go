@@ -0,0 +1,107 @@
+package wavews
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/waveparser"
+	"golang.org/x/net/websocket"
+)
+
+func assertNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// receiveServer saves the audio received by each connection on path,
+// reporting the result of Receive on done.
+func receiveServer(path string, format waveparser.RiffChunkFmt, done chan<- error) *httptest.Server {
+	return httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		f, err := os.Create(path)
+		if err != nil {
+			done <- err
+			return
+		}
+		defer f.Close()
+		done <- Receive(ws, f, format)
+	}))
+}
+
+func dial(t *testing.T, server *httptest.Server) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	ws, err := websocket.Dial(url, "", server.URL)
+	assertNoError(t, err)
+	return ws
+}
+
+func TestSendWavAndReceive(t *testing.T) {
+	wav := waveparser.New(waveparser.WaveFormatIEEEFloat, 2, 8000, 32)
+	samples := make([]float64, 8000*2)
+	for i := range samples {
+		samples[i] = float64(i%200)/100 - 1
+	}
+	assertNoError(t, wav.SetSamples(samples))
+
+	format := waveparser.New(waveparser.WaveFormatPCM, 2, 8000, 16).Header.RIFFChunkFmt
+	path := filepath.Join(t.TempDir(), "received.wav")
+	done := make(chan error, 1)
+	server := receiveServer(path, format, done)
+	defer server.Close()
+
+	ws := dial(t, server)
+	assertNoError(t, SendWav(ws, wav, waveparser.SampleInt16LE, Options{Burst: true}))
+	assertNoError(t, ws.Close())
+	assertNoError(t, <-done)
+
+	received, err := waveparser.Load(path)
+	assertNoError(t, err)
+	if received.Header.RIFFChunkFmt != format {
+		t.Fatalf("expected fmt %+v, got %+v", format, received.Header.RIFFChunkFmt)
+	}
+	got, err := received.Samples()
+	assertNoError(t, err)
+	if len(got) != len(samples) {
+		t.Fatalf("expected [%d] samples, got [%d]", len(samples), len(got))
+	}
+	for i, s := range samples {
+		if d := got[i] - s; d > 1e-4 || d < -1e-4 {
+			t.Fatalf("sample[%d]: expected[%f] got[%f]", i, s, got[i])
+		}
+	}
+}
+
+func TestSendIsPaced(t *testing.T) {
+	wav := waveparser.New(waveparser.WaveFormatPCM, 1, 8000, 16)
+	assertNoError(t, wav.SetSamples(make([]float64, 800)))
+
+	path := filepath.Join(t.TempDir(), "paced.wav")
+	done := make(chan error, 1)
+	server := receiveServer(path, wav.Header.RIFFChunkFmt, done)
+	defer server.Close()
+
+	ws := dial(t, server)
+	start := time.Now()
+	assertNoError(t, SendWav(ws, wav, waveparser.SampleInt16LE, Options{}))
+	elapsed := time.Since(start)
+	assertNoError(t, ws.Close())
+	assertNoError(t, <-done)
+
+	// the last of five 20ms messages is sent 80ms after the first
+	if elapsed < 80*time.Millisecond {
+		t.Fatalf("100ms of audio sent in %s", elapsed)
+	}
+}
+
+func TestSendWavUnknownFormat(t *testing.T) {
+	wav := waveparser.New(waveparser.WaveFormatPCM, 1, 8000, 16)
+	if err := SendWav(nil, wav, waveparser.SampleFormat(42), Options{}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
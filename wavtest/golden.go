@@ -0,0 +1,77 @@
+package wavtest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+// RunHeaderGolden parses the header of every .wav file of dir on a
+// subtest, checking it against its golden files: name.hdr.expected
+// holds the JSON encoded header (as printed by waveheader) and
+// name.err the error parsing must fail with. Every file needs one of
+// them.
+func RunHeaderGolden(t *testing.T, dir string) {
+	t.Helper()
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("error[%s] listing golden files of [%s]", err, dir)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".wav") {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		t.Run("header-"+file.Name(), func(t *testing.T) {
+			checkHeaderGolden(t, path)
+		})
+	}
+}
+
+func checkHeaderGolden(t *testing.T, path string) {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	hdr, hdrerr := waveparser.ParseHeader(f)
+
+	noext := strings.TrimSuffix(path, filepath.Ext(path))
+
+	expectedErr, err := ioutil.ReadFile(noext + ".err")
+	if err == nil {
+		if hdrerr == nil {
+			t.Fatalf("Expected error: %s but ran successfully...", string(expectedErr))
+		}
+		if hdrerr.Error() != string(expectedErr) {
+			t.Fatalf("Error differs: '%s' != '%s'", hdrerr, string(expectedErr))
+		}
+		return
+	} else if hdrerr != nil {
+		t.Fatalf("Error: %s", hdrerr)
+	}
+
+	expectedHdr, err := ioutil.ReadFile(noext + ".hdr.expected")
+	if err != nil {
+		t.Fatalf("no error file nor expected file found for input: %s", path)
+	}
+
+	var expected waveparser.WavHeader
+	if err := json.Unmarshal(expectedHdr, &expected); err != nil {
+		t.Fatalf("error[%s] decoding [%s.hdr.expected]", err, noext)
+	}
+	if !reflect.DeepEqual(hdr, expected) {
+		t.Fatalf("WAV header differs:\n\n%#v\n\n!=\n\n%#v\n", hdr, expected)
+	}
+}
@@ -0,0 +1,87 @@
+// Package wavtest has helpers for tests that need WAV files, building
+// them on the fly instead of shipping binary fixtures, and for checking
+// fixtures against golden headers with RunHeaderGolden.
+package wavtest
+
+import (
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+// New builds a Wav with the given encoding holding samples, which are
+// interleaved and normalized to [-1, 1].
+func New(
+	t testing.TB,
+	format waveparser.AudioFormat,
+	channels uint16,
+	sampleRate uint32,
+	bits uint16,
+	samples []float64,
+) *waveparser.Wav {
+	t.Helper()
+
+	wav := waveparser.New(format, channels, sampleRate, bits)
+	if err := wav.SetSamples(samples); err != nil {
+		t.Fatalf("error[%s] encoding samples", err)
+	}
+	return wav
+}
+
+// Bytes encodes w as a complete WAV file.
+func Bytes(t testing.TB, w *waveparser.Wav) []byte {
+	t.Helper()
+
+	data, err := w.Bytes()
+	if err != nil {
+		t.Fatalf("error[%s] encoding wav", err)
+	}
+	return data
+}
+
+// WriteFile saves w with the given name on a temporary directory that
+// is removed when the test finishes, returning its path.
+func WriteFile(t testing.TB, name string, w *waveparser.Wav) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, Bytes(t, w), 0644); err != nil {
+		t.Fatalf("error[%s] writing [%s]", err, path)
+	}
+	return path
+}
+
+// AssertSamples decodes w and checks its samples against expected.
+func AssertSamples(t testing.TB, w *waveparser.Wav, expected []float64, tolerance float64) {
+	t.Helper()
+
+	got, err := w.Samples()
+	if err != nil {
+		t.Fatalf("error[%s] decoding samples", err)
+	}
+	AssertSamplesClose(t, expected, got, tolerance)
+}
+
+// AssertSamplesClose checks that every sample of got is within
+// tolerance of the expected one.
+func AssertSamplesClose(t testing.TB, expected []float64, got []float64, tolerance float64) {
+	t.Helper()
+
+	if len(expected) != len(got) {
+		t.Fatalf("expected len[%d] != got len[%d]", len(expected), len(got))
+	}
+	for i, e := range expected {
+		if math.Abs(e-got[i]) > tolerance {
+			t.Fatalf("sample[%d]: expected[%f] got[%f]", i, e, got[i])
+		}
+	}
+}
+
+// Tolerance is the quantization error of a PCM encoding with the given
+// bits per sample, handy as the tolerance of sample assertions.
+func Tolerance(bits uint16) float64 {
+	return 1 / math.Pow(2, float64(bits-1))
+}
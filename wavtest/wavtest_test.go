@@ -0,0 +1,32 @@
+package wavtest
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/waveparser"
+)
+
+func TestRoundTrip(t *testing.T) {
+	samples := []float64{0, 0.5, -0.5, 0.25, -1, 0.75}
+
+	for _, bits := range []uint16{8, 16, 24, 32} {
+		wav := New(t, waveparser.WaveFormatPCM, 2, 8000, bits, samples)
+		path := WriteFile(t, "roundtrip.wav", wav)
+
+		loaded, err := waveparser.Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		AssertSamples(t, loaded, samples, Tolerance(bits))
+
+		parsed, err := waveparser.ParseBytes(Bytes(t, wav))
+		if err != nil {
+			t.Fatal(err)
+		}
+		AssertSamples(t, parsed, samples, Tolerance(bits))
+	}
+}
+
+func TestRunHeaderGolden(t *testing.T) {
+	RunHeaderGolden(t, "../testdata")
+}
@@ -0,0 +1,287 @@
+package waveparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+type (
+	// Writer streams audio data into a WAV file, patching the
+	// header sizes when closed.
+	Writer struct {
+		w         io.WriteSeeker
+		format    RiffChunkFmt
+		extension FmtExtension
+		encode    sampleEncodeFunc
+		written   uint32
+		closed    bool
+
+		// peak tracking, with the bytes of an incomplete sample
+		// left by the last Write
+		peak    *Peak
+		decode  sampleDecodeFunc
+		tracked int
+		partial []byte
+	}
+)
+
+const (
+	riffHeaderSize  = 12
+	chunkHeaderSize = 8
+	fmtChunkSize    = 16
+)
+
+// New creates an empty Wav whose header is consistent with the
+// given encoding.
+func New(format AudioFormat, channels uint16, sampleRate uint32, bitsPerSample uint16) *Wav {
+	chunkFmt := RiffChunkFmt{
+		LengthOfHeader: fmtChunkSize,
+		AudioFormat:    format,
+		NumChannels:    channels,
+		SampleRate:     sampleRate,
+		BitsPerSample:  bitsPerSample,
+	}
+	chunkFmt.BytesPerBloc, chunkFmt.BytesPerSec = blockAlign(chunkFmt)
+	chunkFmt.LengthOfHeader += fmtExtraSize(chunkFmt)
+
+	return &Wav{
+		Header: WavHeader{
+			RIFFHdr: RiffHeader{
+				Ident:     [4]byte{'R', 'I', 'F', 'F'},
+				ChunkSize: riffChunkSize(nil, 0) + fmtExtraSize(chunkFmt),
+				FileType:  [4]byte{'W', 'A', 'V', 'E'},
+			},
+			RIFFChunkFmt:   chunkFmt,
+			FirstSamplePos: riffHeaderSize + 2*chunkHeaderSize + fmtChunkSize + fmtExtraSize(chunkFmt),
+		},
+		Data: []byte{},
+	}
+}
+
+// WriteTo writes w as a complete WAV file.
+func (w *Wav) WriteTo(out io.Writer) (int64, error) {
+	data, err := w.Bytes()
+	if err != nil {
+		return 0, err
+	}
+	n, err := out.Write(data)
+	return int64(n), err
+}
+
+// Bytes encodes w as a complete WAV file: the header, the chunks and
+// the audio data.
+func (w *Wav) Bytes() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := writeHeader(buf, w.Header.RIFFChunkFmt, w.Header.Extension, w.Chunks, uint32(len(w.Data))); err != nil {
+		return nil, err
+	}
+	buf.Write(w.Data)
+	if len(w.Data)%2 != 0 {
+		buf.WriteByte(0)
+	}
+	return buf.Bytes(), nil
+}
+
+// blockAlign computes the bytes/block and bytes/second of an encoding.
+// IMA ADPCM keeps its block size, chosen by the encoder, when valid.
+func blockAlign(f RiffChunkFmt) (uint16, uint32) {
+	if f.NumChannels == 0 {
+		return 0, 0
+	}
+	if isIMA(f.AudioFormat) {
+		if imaCheckFormat(f) != nil {
+			f.BytesPerBloc = imaBlockSize(f.NumChannels, f.SampleRate)
+		}
+		if imaCheckFormat(f) != nil {
+			return f.BytesPerBloc, 0
+		}
+		perSec := uint64(f.SampleRate) * uint64(f.BytesPerBloc) / uint64(imaSamplesPerBlock(f))
+		return f.BytesPerBloc, uint32(perSec)
+	}
+	if f.BitsPerSample%8 != 0 {
+		// packed codecs (G.726) use less than a byte per frame
+		return 1, f.SampleRate * uint32(f.NumChannels) * uint32(f.BitsPerSample) / 8
+	}
+	bytesPerBloc := f.NumChannels * (f.BitsPerSample / 8)
+	return bytesPerBloc, f.SampleRate * uint32(bytesPerBloc)
+}
+
+// NewWriter writes a provisional WAV header to w and returns a Writer
+// ready to receive audio data in the given format.
+func NewWriter(w io.WriteSeeker, format RiffChunkFmt, opts ...WriterOption) (*Writer, error) {
+	encode, err := sampleEncoder(format)
+	if err != nil {
+		return nil, err
+	}
+
+	options := newWriterOptions(opts)
+	writer := &Writer{
+		w:         w,
+		format:    format,
+		extension: options.extension,
+		encode:    encode,
+	}
+	if options.peak {
+		if format.NumChannels == 0 {
+			return nil, fmt.Errorf("invalid number of channels[%d]", format.NumChannels)
+		}
+		writer.decode, _ = sampleDecoder(format)
+		writer.peak = newPeak(int(format.NumChannels))
+	}
+
+	if err := writeHeader(w, format, writer.extension, writer.chunks(), 0); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// Write appends already encoded audio data.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("write on closed wav writer")
+	}
+	n, err := w.w.Write(p)
+	w.written += uint32(n)
+	if w.peak != nil {
+		w.trackPeak(p[:n])
+	}
+	return n, err
+}
+
+func (w *Writer) trackPeak(p []byte) {
+	size := int(w.format.BitsPerSample / 8)
+	data := append(w.partial, p...)
+	samples := make([]float64, len(data)/size)
+	for i := range samples {
+		samples[i] = w.decode(data[i*size:])
+	}
+	w.peak.track(samples, w.tracked)
+	w.tracked += len(samples)
+	w.partial = append(w.partial[:0], data[len(samples)*size:]...)
+}
+
+// chunks are the chunks written between the fmt and data chunks.
+func (w *Writer) chunks() []Chunk {
+	if w.peak == nil {
+		return nil
+	}
+	return []Chunk{{ID: chunkID("PEAK"), Data: peakChunkData(w.peak)}}
+}
+
+// WriteSamples encodes the interleaved normalized samples and appends them.
+func (w *Writer) WriteSamples(samples []float64) error {
+	size := int(w.format.BitsPerSample / 8)
+	data := make([]byte, len(samples)*size)
+	for i, sample := range samples {
+		w.encode(data[i*size:], sample)
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// Close pads the data chunk and rewrites the header with the final
+// sizes. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if w.written%2 != 0 {
+		if _, err := w.w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+	end, err := w.w.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := w.w.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := writeHeader(w.w, w.format, w.extension, w.chunks(), w.written); err != nil {
+		return err
+	}
+	_, err = w.w.Seek(end, io.SeekStart)
+	return err
+}
+
+func riffChunkSize(chunks []Chunk, dataSize uint32) uint32 {
+	size := 4 + 2*chunkHeaderSize + fmtChunkSize + dataSize + dataSize%2
+	for _, chunk := range chunks {
+		size += chunkHeaderSize + paddedSize(chunk.Data)
+	}
+	return size
+}
+
+// fmtExtraSize is the size of the format specific fields written after
+// the common ones on the fmt chunk.
+func fmtExtraSize(f RiffChunkFmt) uint32 {
+	if isIMA(f.AudioFormat) {
+		return imaFmtExtraSize
+	}
+	return 0
+}
+
+// headerExtraSize is fmtExtraSize for the fmt chunk written for hdr,
+// which also carries the extensible fields when hdr has them.
+func headerExtraSize(hdr *WavHeader) uint32 {
+	if hdr.IsExtensible() {
+		return 2 + fmtExtensionSize
+	}
+	return fmtExtraSize(hdr.RIFFChunkFmt)
+}
+
+func paddedSize(data []byte) uint32 {
+	return uint32(len(data) + len(data)%2)
+}
+
+// writeHeader writes everything that comes before the audio data: the
+// RIFF header, the fmt chunk, the extra chunks and the data chunk header.
+func writeHeader(w io.Writer, format RiffChunkFmt, ext FmtExtension, chunks []Chunk, dataSize uint32) error {
+	extensible := ext != FmtExtension{}
+	extra := fmtExtraSize(format)
+	if extensible {
+		extra = 2 + fmtExtensionSize
+	}
+	subFormat := format.AudioFormat
+	format.LengthOfHeader = fmtChunkSize + extra
+	values := []interface{}{
+		RiffHeader{
+			Ident:     [4]byte{'R', 'I', 'F', 'F'},
+			ChunkSize: riffChunkSize(chunks, dataSize) + extra,
+			FileType:  [4]byte{'W', 'A', 'V', 'E'},
+		},
+		[4]byte{'f', 'm', 't', ' '},
+		format,
+	}
+	if extensible {
+		format.AudioFormat = WaveFormatExtensible
+		values[2] = format
+		if ext.SubFormat == ([16]byte{}) {
+			ext.SubFormat = [16]byte{byte(subFormat), byte(subFormat >> 8)}
+			copy(ext.SubFormat[2:], subFormatGUIDSuffix[:])
+		}
+		values = append(values, uint16(fmtExtensionSize), ext)
+	} else if isIMA(format.AudioFormat) {
+		// cbSize and wSamplesPerBlock
+		values = append(values, uint16(2), uint16(imaSamplesPerBlock(format)))
+	}
+	for _, chunk := range chunks {
+		values = append(values, chunk.ID, uint32(len(chunk.Data)), chunk.Data)
+		if len(chunk.Data)%2 != 0 {
+			values = append(values, uint8(0))
+		}
+	}
+	values = append(values, [4]byte{'d', 'a', 't', 'a'}, dataSize)
+
+	for _, v := range values {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
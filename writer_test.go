@@ -0,0 +1,151 @@
+package waveparser
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteToRoundTrip(t *testing.T) {
+	wav := New(WaveFormatPCM, 2, 16000, 16)
+	assertNoError(t, wav.SetSamples([]float64{0.1, -0.1, 0.2, -0.2, 0.3, -0.3}))
+
+	buf := &bytes.Buffer{}
+	_, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+
+	hdr, err := parseHeader(bytes.NewReader(buf.Bytes()))
+	assertNoError(t, err)
+
+	if hdr != wav.Header {
+		t.Fatalf("header differs:\n\n%#v\n\n!=\n\n%#v\n", hdr, wav.Header)
+	}
+	assertBytesEqual(t, wav.Data, buf.Bytes()[hdr.FirstSamplePos:])
+}
+
+func TestBytes(t *testing.T) {
+	wav := New(WaveFormatPCM, 1, 8000, 8)
+	assertNoError(t, wav.SetSamples([]float64{0.5, -0.5, 0.25}))
+	wav.SetInfo(Info{InfoTitle: "bytes"})
+
+	data, err := wav.Bytes()
+	assertNoError(t, err)
+
+	buf := &bytes.Buffer{}
+	n, err := wav.WriteTo(buf)
+	assertNoError(t, err)
+	if n != int64(len(data)) {
+		t.Fatalf("WriteTo wrote [%d] bytes, expected [%d]", n, len(data))
+	}
+	assertBytesEqual(t, buf.Bytes(), data)
+
+	parsed, err := ParseBytes(data)
+	assertNoError(t, err)
+	assertBytesEqual(t, wav.Data, parsed.Data)
+}
+
+func TestWriterPatchesSizes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "waveparser")
+	assertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "streamed.wav")
+	f, err := os.Create(path)
+	assertNoError(t, err)
+
+	expected := New(WaveFormatPCM, 1, 8000, 8)
+	assertNoError(t, expected.SetSamples([]float64{0.5, -0.5, 0.25}))
+
+	writer, err := NewWriter(f, expected.Header.RIFFChunkFmt)
+	assertNoError(t, err)
+	assertNoError(t, writer.WriteSamples([]float64{0.5}))
+	assertNoError(t, writer.WriteSamples([]float64{-0.5, 0.25}))
+	assertNoError(t, writer.Close())
+	assertNoError(t, f.Close())
+
+	got, err := Load(path)
+	assertNoError(t, err)
+
+	if got.Header.DataBlockSize != 3 {
+		t.Fatalf("expected data block size[3], got[%d]", got.Header.DataBlockSize)
+	}
+	if got.Header.RIFFHdr.ChunkSize != expected.Header.RIFFHdr.ChunkSize {
+		t.Fatalf("unexpected RIFF chunk size[%d]", got.Header.RIFFHdr.ChunkSize)
+	}
+	assertBytesEqual(t, expected.Data, got.Data)
+}
+
+func TestWriterExtension(t *testing.T) {
+	dir, err := ioutil.TempDir("", "waveparser")
+	assertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "extensible.wav")
+	f, err := os.Create(path)
+	assertNoError(t, err)
+
+	ext := FmtExtension{ValidBitsPerSample: 16, ChannelMask: 0x60f}
+	format := New(WaveFormatPCM, 6, 48000, 16).Header.RIFFChunkFmt
+	writer, err := NewWriter(f, format, WithExtension(ext))
+	assertNoError(t, err)
+	assertNoError(t, writer.WriteSamples(make([]float64, 12)))
+	assertNoError(t, writer.Close())
+	assertNoError(t, f.Close())
+
+	got, err := Load(path)
+	assertNoError(t, err)
+	if !got.Header.IsExtensible() || got.Format() != WaveFormatPCM || got.Header.Extension.ChannelMask != 0x60f {
+		t.Fatalf("expected extensible PCM, got %+v", got.Header)
+	}
+	data, err := ioutil.ReadFile(path)
+	assertNoError(t, err)
+	if got.Header.RIFFHdr.ChunkSize != uint32(len(data)-8) {
+		t.Fatalf("expected RIFF size [%d], got [%d]", len(data)-8, got.Header.RIFFHdr.ChunkSize)
+	}
+}
+
+func TestWriterPeakChunk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "waveparser")
+	assertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "peak.wav")
+	f, err := os.Create(path)
+	assertNoError(t, err)
+
+	format := New(WaveFormatIEEEFloat, 2, 8000, 32).Header.RIFFChunkFmt
+	writer, err := NewWriter(f, format, WithPeakChunk())
+	assertNoError(t, err)
+	assertNoError(t, writer.WriteSamples([]float64{0.1, -0.2, -0.5}))
+
+	// a sample split between writes
+	encoded := New(WaveFormatIEEEFloat, 2, 8000, 32)
+	assertNoError(t, encoded.SetSamples([]float64{0.25, 0.9, -0.3}))
+	_, err = writer.Write(encoded.Data[:5])
+	assertNoError(t, err)
+	_, err = writer.Write(encoded.Data[5:])
+	assertNoError(t, err)
+
+	assertNoError(t, writer.Close())
+	assertNoError(t, f.Close())
+
+	got, err := Load(path)
+	assertNoError(t, err)
+	if got.Header.DataBlockSize != 24 {
+		t.Fatalf("expected data block size[24], got[%d]", got.Header.DataBlockSize)
+	}
+
+	p, err := got.Peak()
+	assertNoError(t, err)
+	if p == nil {
+		t.Fatal("expected PEAK chunk")
+	}
+	expected := []ChannelPeak{{Value: 0.9, Position: 2}, {Value: 0.3, Position: 2}}
+	for i := range expected {
+		if p.Channels[i] != expected[i] {
+			t.Fatalf("channel[%d]: expected %+v, got %+v", i, expected[i], p.Channels[i])
+		}
+	}
+}